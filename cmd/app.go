@@ -1,49 +1,223 @@
 package main
 
 import (
+	"cinema/internal/cache"
+	"cinema/internal/config"
 	"cinema/internal/controller"
+	"cinema/internal/logger"
+	"cinema/internal/metrics"
+	"cinema/internal/migrations"
 	"cinema/internal/postgres"
 	"cinema/internal/repository"
 	"cinema/internal/routes"
 	"cinema/internal/service"
+	"cinema/internal/storage"
+	"cinema/internal/worker"
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
 )
 
-func Run() error {
-	r := gin.Default()
+func Run(cfg *config.Config, log *slog.Logger) error {
+	// Контекст отменяется по SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if cfg.Env == config.EnvProd {
+		gin.SetMode(gin.ReleaseMode)
+	}
+	r := gin.New()
+	// X-Forwarded-For учитывается только от доверенных прокси, иначе клиент подменит свой IP для ограничителя
+	if err := r.SetTrustedProxies(cfg.HTTP.TrustedProxies); err != nil {
+		return fmt.Errorf("set trusted proxies: %w", err)
+	}
+
 	// Подключение к базе данных
-	db, err := postgres.ConnectDB()
+	db, err := postgres.ConnectDB(cfg.Database)
 	if err != nil {
 		return err
 	}
 
-	defer func() {
-		if err := db.Close(); err != nil {
-			log.Printf("Error closing database connection: %v", err)
-		}
-	}()
+	// Метрики HTTP, запросов к БД и пула соединений
+	appMetrics := metrics.New()
+	appMetrics.RegisterDB(db, cfg.Database.Name)
 
 	// Инициализация слоев репозиториев и сервисов
-	movieStore := repository.NewMovie(db)
-	actorStore := repository.NewActor(db)
-	movieService := service.NewMovie(movieStore)
-	actorService := service.NewActor(actorStore)
+	movieStore := repository.NewMovie(db, log, appMetrics)
+	actorStore := repository.NewActor(db, log, appMetrics)
+	genreStore := repository.NewGenre(db, log, appMetrics)
+	userStore := repository.NewUser(db, log, appMetrics)
+	auditStore := repository.NewAudit(db, log, appMetrics)
+	// Постеры и фотографии хранятся в файлах, в БД - только их ключи
+	imageStore, err := storage.NewLocal(cfg.Storage.Dir)
+	if err != nil {
+		db.Close()
+		return err
+	}
+	imageFiles := service.NewImageFiles(imageStore, cfg.Storage.ThumbnailWidths, log)
+	// Общий кэш чтения: изменения актеров и жанров сбрасывают и записи фильмов
+	var catalogCache *cache.LRU
+	if cfg.Cache.Enabled {
+		catalogCache = cache.New(cfg.Cache.Size, cfg.Cache.TTL)
+		appMetrics.RegisterCache(catalogCache, "catalog")
+	}
+	// Изменения каталога пишутся в журнал аудита в транзакции самого изменения
+	auditLog := service.NewAuditLog(auditStore, log)
+	movieService := service.NewCachedMovie(service.NewMovie(movieStore, auditLog, log, appMetrics), catalogCache)
+	actorService := service.NewCachedActor(service.NewActor(actorStore, auditLog, log, appMetrics), catalogCache)
+	genreService := service.NewCachedGenre(service.NewGenre(genreStore, auditLog, log, appMetrics), catalogCache)
+	trashService := service.NewCachedTrash(service.NewTrash(movieStore, actorStore, imageFiles, auditLog, cfg.Trash.Retention, log, appMetrics), catalogCache)
+	importService := service.NewCachedImporter(service.NewImporter(actorStore, movieStore, auditLog, log, appMetrics), catalogCache)
+	exportService := service.NewExporter(movieStore, actorStore, log)
+	imageService := service.NewCachedImages(service.NewImages(movieStore, actorStore, imageFiles, auditLog, int64(cfg.Storage.MaxImageSize), log, appMetrics), catalogCache)
+	authService := service.NewAuth(userStore, []byte(cfg.Auth.JWTSecret), cfg.Auth.TokenTTL, log)
 
-	// Создание контроллера для работы с фильмами и актерами
-	cinemaController := controller.NewCinema(movieService, actorService)
+	// Создание контроллеров для работы с фильмами, актерами, жанрами и аутентификацией
+	cinemaController := controller.NewCinema(movieService, actorService, genreService, log)
+	authController := controller.NewAuth(authService, log)
+	trashController := controller.NewTrash(trashService, log)
+	auditController := controller.NewAudit(auditLog, log)
+	importController := controller.NewImport(importService, log)
+	exportController := controller.NewExport(exportService, log)
+	imageController := controller.NewImages(imageService, int64(cfg.Storage.MaxImageSize), log)
+
+	// Проверки готовности: БД отвечает, схема на ожидаемой версии
+	migrator, err := migrations.New(db)
+	if err != nil {
+		db.Close()
+		return err
+	}
+	healthService := service.NewHealth(cfg.Health.CheckTimeout)
+	healthService.AddCheck("database", db.PingContext)
+	healthService.AddCheck("migrations", func(ctx context.Context) error {
+		version, err := migrator.AppliedVersion(ctx)
+		if err != nil {
+			return err
+		}
+		if version != migrator.Latest() {
+			return fmt.Errorf("schema version is %d, expected %d", version, migrator.Latest())
+		}
+		return nil
+	})
+	healthController := controller.NewHealth(healthService)
 
 	// Настройка маршрутов
-	routes.SetupRoutes(r, cinemaController)
+	routes.SetupRoutes(r, cfg, log, appMetrics, cinemaController, authController, healthController, trashController, auditController, importController, exportController, imageController)
+
+	// Фоновые задачи приложения
+	workers := worker.NewGroup(log)
+	workers.Go("trash-purge", func(ctx context.Context) {
+		trashService.RunPurge(ctx, cfg.Trash.PurgeInterval)
+	})
+
+	server := &http.Server{
+		Addr:         cfg.HTTP.Addr,
+		Handler:      r,
+		ReadTimeout:  cfg.HTTP.ReadTimeout,
+		WriteTimeout: cfg.HTTP.WriteTimeout,
+		IdleTimeout:  cfg.HTTP.IdleTimeout,
+		ErrorLog:     slog.NewLogLogger(log.Handler(), slog.LevelWarn),
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Info("HTTP server listening", "addr", cfg.HTTP.Addr)
+		serveErr <- server.ListenAndServe()
+	}()
 
-	r.Run(":8080")
-	return nil
+	var runErr error
+	select {
+	case err := <-serveErr:
+		// Сервер не смог запуститься или упал, остальное все равно нужно остановить
+		runErr = fmt.Errorf("HTTP server failed: %w", err)
+	case <-ctx.Done():
+		// Повторный сигнал завершает процесс сразу, не дожидаясь остановки
+		stop()
+
+		// Сначала /readyz начинает отвечать 503, и только через shutdown_delay
+		// сервер перестает принимать соединения
+		healthService.SetShuttingDown()
+		log.Info("Shutdown signal received, draining connections", "delay", cfg.Health.ShutdownDelay.String())
+		time.Sleep(cfg.Health.ShutdownDelay)
+	}
+
+	return errors.Join(runErr, shutdown(log, server, workers, db, cfg.HTTP.ShutdownTimeout))
+}
+
+// Остановка в обратном порядке зависимостей: сервер перестает принимать соединения
+// и дожидается текущих запросов, затем останавливаются фоновые задачи, и только
+// после этого закрывается пул соединений с БД. На все шаги отводится grace.
+func shutdown(log *slog.Logger, server *http.Server, workers *worker.Group, db *sql.DB, grace time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), grace)
+	defer cancel()
+
+	var errs []error
+	if err := server.Shutdown(ctx); err != nil {
+		// Оставшиеся соединения закрываются принудительно, их запросы к БД отменяются
+		errs = append(errs, fmt.Errorf("failed to drain HTTP connections: %w", err))
+		server.Close()
+	}
+	if err := workers.Stop(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	if err := db.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to close database connection: %w", err))
+	}
+
+	if len(errs) == 0 {
+		log.Info("Server stopped gracefully")
+	}
+	return errors.Join(errs...)
 }
 
 func main() {
-	if err := Run(); err != nil {
-		log.Fatal("Error running application:", err)
+	// Путь к файлу конфигурации: флаг -config или переменная CINEMA_CONFIG
+	configPath := flag.String("config", os.Getenv("CINEMA_CONFIG"), "path to YAML config file")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatal("Error loading configuration:", err)
+	}
+
+	// Подкоманда migrate управляет схемой БД, import загружает файл в каталог,
+	// export выгружает каталог, без подкоманды запускается сервер
+	switch flag.Arg(0) {
+	case "migrate":
+		if err := runMigrate(cfg, flag.Args()[1:]); err != nil {
+			log.Fatal("Error running migrations:", err)
+		}
+		return
+	case "import":
+		if err := runImport(cfg, flag.Args()[1:]); err != nil {
+			log.Fatal("Error importing file:", err)
+		}
+		return
+	case "export":
+		if err := runExport(cfg, flag.Args()[1:]); err != nil {
+			log.Fatal("Error exporting catalog:", err)
+		}
+		return
+	}
+
+	// Сервер пишет JSON-логи, стандартный log тоже перенаправляется в них
+	appLog := logger.New(os.Stdout, cfg.Log.SlogLevel())
+	slog.SetDefault(appLog)
+
+	if err := Run(cfg, appLog); err != nil {
+		appLog.Error("Error running application", logger.Err(err))
+		os.Exit(1)
 	}
 }
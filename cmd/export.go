@@ -0,0 +1,149 @@
+package main
+
+import (
+	"cinema/internal/config"
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"cinema/internal/postgres"
+	"cinema/internal/repository"
+	"cinema/internal/service"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const exportUsage = "usage: export -kind movies|actors|credits [-format ndjson|json|csv] [-o FILE] [filters]"
+
+// runExport выполняет подкоманду export: выгружает каталог так же, как GET /api/export/{kind},
+// в файл -o или в stdout. Если выгрузка не удалась, недописанный файл удаляется.
+func runExport(cfg *config.Config, args []string) error {
+	opts := models.ExportOptions{Filter: models.MovieFilter{ActorMatch: models.ActorMatchAny}}
+	var output string
+
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.StringVar(&opts.Kind, "kind", "", "what to export: movies, actors or credits")
+	flags.StringVar(&opts.Format, "format", "", "output format: ndjson, json or csv; by default taken from the -o extension, otherwise ndjson")
+	flags.StringVar(&output, "o", "", "output file; stdout if empty")
+	flags.Func("updated-since", "only movies or actors changed at or after this time (RFC 3339)", func(raw string) error {
+		value, err := time.Parse(time.RFC3339, raw)
+		opts.UpdatedSince = &value
+		return err
+	})
+	flags.Func("min-rating", "minimum movie rating", floatFlag(&opts.Filter.MinRating))
+	flags.Func("max-rating", "maximum movie rating", floatFlag(&opts.Filter.MaxRating))
+	flags.Func("year-from", "movies released in or after this year", intFlag(&opts.Filter.YearFrom))
+	flags.Func("year-to", "movies released in or before this year", intFlag(&opts.Filter.YearTo))
+	flags.Func("actor-ids", "comma-separated actor IDs", idsFlag(&opts.Filter.ActorIDs))
+	flags.StringVar(&opts.Filter.ActorMatch, "actor-match", models.ActorMatchAny, "whether a movie must feature any or all of -actor-ids")
+	flags.Func("genre-ids", "comma-separated genre IDs", idsFlag(&opts.Filter.GenreIDs))
+	flags.StringVar(&opts.Filter.TitlePrefix, "title-prefix", "", "case-insensitive movie title prefix")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 || opts.Kind == "" {
+		return errors.New(exportUsage)
+	}
+	if opts.Format == "" {
+		opts.Format = exportFormat(output)
+	}
+
+	if errs := append(opts.Validate(), opts.Filter.Validate()...); len(errs) > 0 {
+		messages := make([]string, 0, len(errs))
+		for _, e := range errs {
+			messages = append(messages, e.Field+": "+e.Message)
+		}
+		return errors.New(strings.Join(messages, "; "))
+	}
+
+	db, err := postgres.ConnectDB(cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// Прерывание по Ctrl+C останавливает выгрузку
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log := logger.New(os.Stderr, cfg.Log.SlogLevel())
+	exporter := service.NewExporter(repository.NewMovie(db, log, nil), repository.NewActor(db, log, nil), log)
+
+	if output == "" {
+		count, err := exporter.Export(ctx, os.Stdout, opts)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d %s\n", count, opts.Kind)
+		return nil
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	count, err := exporter.Export(ctx, file, opts)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(output)
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d %s\n", count, opts.Kind)
+	return nil
+}
+
+// Формат по расширению файла, для stdout и неизвестных расширений - ndjson
+func exportFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return models.FormatCSV
+	case ".json":
+		return models.FormatJSON
+	default:
+		return models.FormatNDJSON
+	}
+}
+
+func floatFlag(dst **float64) func(string) error {
+	return func(raw string) error {
+		value, err := strconv.ParseFloat(raw, 64)
+		*dst = &value
+		return err
+	}
+}
+
+func intFlag(dst **int) func(string) error {
+	return func(raw string) error {
+		value, err := strconv.Atoi(raw)
+		*dst = &value
+		return err
+	}
+}
+
+func idsFlag(dst *[]uuid.UUID) func(string) error {
+	return func(raw string) error {
+		for _, part := range strings.Split(raw, ",") {
+			id, err := uuid.Parse(strings.TrimSpace(part))
+			if err != nil {
+				return fmt.Errorf("invalid ID %q", part)
+			}
+			*dst = append(*dst, id)
+		}
+		return nil
+	}
+}
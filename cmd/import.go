@@ -0,0 +1,110 @@
+package main
+
+import (
+	"cinema/internal/audit"
+	"cinema/internal/config"
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"cinema/internal/postgres"
+	"cinema/internal/repository"
+	"cinema/internal/service"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+const importUsage = "usage: import -kind actors|movies|credits [-format csv|ndjson] [-dry-run] [-atomic] FILE"
+
+// runImport выполняет подкоманду import: загружает файл так же, как POST /api/import/{kind}.
+// Записи в журнале аудита создаются от имени system.
+func runImport(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	kind := flags.String("kind", "", "what the file contains: actors, movies or credits")
+	format := flags.String("format", "", "file format: csv or ndjson; by default taken from the file extension")
+	dryRun := flags.Bool("dry-run", false, "validate and report errors without saving anything")
+	atomic := flags.Bool("atomic", false, "save nothing if any row has errors")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 || *kind == "" {
+		return errors.New(importUsage)
+	}
+	path := flags.Arg(0)
+
+	opts := models.ImportOptions{Kind: *kind, Format: *format, DryRun: *dryRun, Atomic: *atomic}
+	if opts.Format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			opts.Format = models.FormatCSV
+		case ".ndjson", ".jsonl":
+			opts.Format = models.FormatNDJSON
+		default:
+			return fmt.Errorf("cannot infer format from %q, use -format", path)
+		}
+	}
+
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	db, err := postgres.ConnectDB(cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// Прерывание по Ctrl+C откатывает транзакцию импорта
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx = audit.WithActor(ctx, audit.System)
+
+	log := logger.New(os.Stderr, cfg.Log.SlogLevel())
+	importer := service.NewImporter(
+		repository.NewActor(db, log, nil),
+		repository.NewMovie(db, log, nil),
+		service.NewAuditLog(repository.NewAudit(db, log, nil), log),
+		log,
+		nil,
+	)
+
+	result, err := importer.Import(ctx, r, opts)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range result.Errors {
+		for _, e := range row.Errors {
+			if e.Field != "" {
+				fmt.Printf("row %d: %s: %s\n", row.Row, e.Field, e.Message)
+			} else {
+				fmt.Printf("row %d: %s\n", row.Row, e.Message)
+			}
+		}
+	}
+	state := "committed"
+	switch {
+	case result.DryRun:
+		state = "dry run, nothing saved"
+	case !result.Committed:
+		state = "nothing saved"
+	}
+	fmt.Printf("%d row(s), %d valid, %d with errors (%s)\n", result.Rows, result.Imported, len(result.Errors), state)
+
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("%d row(s) failed", len(result.Errors))
+	}
+	return nil
+}
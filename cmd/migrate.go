@@ -0,0 +1,68 @@
+package main
+
+import (
+	"cinema/internal/config"
+	"cinema/internal/migrations"
+	"cinema/internal/postgres"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+)
+
+const migrateUsage = "usage: migrate up | down [steps] | status"
+
+// runMigrate выполняет подкоманду migrate: up, down [steps] или status
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	db, err := postgres.ConnectDB(cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := migrations.New(db)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		if err != nil {
+			return err
+		}
+		log.Printf("Applied %d migration(s), schema version is %d", len(applied), migrator.Latest())
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps: %s", args[1])
+			}
+		}
+		rolledBack, err := migrator.Down(steps)
+		if err != nil {
+			return err
+		}
+		log.Printf("Rolled back %d migration(s)", len(rolledBack))
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.AppliedAt != nil {
+				state = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%-30s %s\n", status.Version, status.Name, state)
+		}
+	default:
+		return errors.New(migrateUsage)
+	}
+	return nil
+}
@@ -17,7 +17,7 @@ const docTemplate = `{
     "paths": {
         "/api/actors": {
             "get": {
-                "description": "Retrieve a list of all actors with optional pagination",
+                "description": "Retrieve a page of actors sorted by name with cursor pagination",
                 "consumes": [
                     "application/json"
                 ],
@@ -30,6 +30,7 @@ const docTemplate = `{
                 "summary": "Get all actors with pagination",
                 "parameters": [
                     {
+                        "maximum": 100,
                         "type": "integer",
                         "default": 10,
                         "description": "Limit the number of actors returned",
@@ -37,21 +38,24 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset for pagination",
-                        "name": "offset",
+                        "type": "string",
+                        "description": "Cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Include the total number of actors",
+                        "name": "include_total",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of actors",
+                        "description": "Page of actors",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Actor"
-                            }
+                            "$ref": "#/definitions/models.ActorPage"
                         }
                     },
                     "400": {
@@ -60,11 +64,23 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIError"
                         }
                     },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
                     }
                 }
             },
@@ -107,18 +123,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIError"
                         }
                     },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
                     }
                 }
             }
         },
         "/api/actors/with-movies": {
             "get": {
-                "description": "Retrieve a list of actors with the movies they have appeared in",
+                "description": "Retrieve a page of actors sorted by name with the movies they have appeared in",
                 "consumes": [
                     "application/json"
                 ],
@@ -131,6 +159,7 @@ const docTemplate = `{
                 "summary": "Get actors with their movies",
                 "parameters": [
                     {
+                        "maximum": 100,
                         "type": "integer",
                         "default": 10,
                         "description": "Limit the number of actors returned",
@@ -138,21 +167,24 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset for pagination",
-                        "name": "offset",
+                        "type": "string",
+                        "description": "Cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Include the total number of actors",
+                        "name": "include_total",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of actors with movies",
+                        "description": "Page of actors with movies",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.ActorWithMovies"
-                            }
+                            "$ref": "#/definitions/models.ActorWithMoviesPage"
                         }
                     },
                     "400": {
@@ -161,67 +193,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIError"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "429": {
+                        "description": "Rate limit exceeded",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
-                    }
-                }
-            }
-        },
-        "/api/actors/{actor_id}/movies": {
-            "get": {
-                "description": "Retrieve a list of movies by the actor's ID with optional pagination",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Movies"
-                ],
-                "summary": "Get movies by actor ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Actor ID",
-                        "name": "actor_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit the number of movies returned",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Offset for pagination",
-                        "name": "offset",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "List of movies",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Movie"
-                            }
-                        }
                     },
-                    "400": {
-                        "description": "Invalid actor ID format or bad request",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "504": {
+                        "description": "Request timed out",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
@@ -229,7 +214,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/actors/{id}": {
+        "/api/actors/{actor_id}": {
             "get": {
                 "description": "Retrieve an actor's details by their unique ID",
                 "consumes": [
@@ -250,6 +235,12 @@ const docTemplate = `{
                         "name": "actor_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -257,8 +248,17 @@ const docTemplate = `{
                         "description": "Actor details",
                         "schema": {
                             "$ref": "#/definitions/models.Actor"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the actor"
+                            }
                         }
                     },
+                    "304": {
+                        "description": "Not modified since the given ETag"
+                    },
                     "400": {
                         "description": "Invalid actor ID",
                         "schema": {
@@ -271,11 +271,23 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIError"
                         }
                     },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
                     }
                 }
             },
@@ -308,6 +320,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.UpdateActor"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Update only if the actor still has this ETag",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -320,16 +338,40 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIError"
                         }
                     },
+                    "404": {
+                        "description": "Actor not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "412": {
+                        "description": "Actor was modified since the given ETag or does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
                     }
                 }
             },
             "delete": {
-                "description": "Delete an actor based on their ID",
+                "description": "Move an actor to the trash by their ID. They disappear from all public reads, including credits, and can be restored until the retention period ends.",
                 "consumes": [
                     "application/json"
                 ],
@@ -348,6 +390,12 @@ const docTemplate = `{
                         "name": "actor_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delete only if the actor still has this ETag",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -360,126 +408,121 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIError"
                         }
                     },
+                    "404": {
+                        "description": "Actor not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "412": {
+                        "description": "Actor was modified since the given ETag or does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
                     }
                 }
-            }
-        },
-        "/api/movies": {
-            "get": {
-                "description": "Retrieve a list of movies with optional filters for sorting and pagination.",
+            },
+            "patch": {
+                "description": "Applies a JSON Merge Patch (RFC 7396) to the actor's name, gender and date_of_birth.\nFields missing from the patch stay unchanged, null clears gender or date_of_birth. Other fields cannot be patched.",
                 "consumes": [
+                    "application/merge-patch+json",
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Movies"
+                    "Actors"
                 ],
-                "summary": "Get movies with filters",
+                "summary": "Partially update an actor",
                 "parameters": [
                     {
-                        "enum": [
-                            "title",
-                            "release_date",
-                            "rating"
-                        ],
-                        "type": "string",
-                        "default": "rating",
-                        "description": "Field to sort by",
-                        "name": "sortBy",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "ASC",
-                            "DESC"
-                        ],
                         "type": "string",
-                        "default": "DESC",
-                        "description": "Sorting order",
-                        "name": "order",
-                        "in": "query"
+                        "example": "\"f47ac10b-58cc-4372-a567-0e02b2c3d479\"",
+                        "description": "Actor ID",
+                        "name": "actor_id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Limit the number of movies returned",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Merge patch with the fields to change",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ActorFields"
+                        }
                     },
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset for pagination",
-                        "name": "offset",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Update only if the actor still has this ETag",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of filtered movies",
+                        "description": "The updated actor",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Movie"
+                            "$ref": "#/definitions/models.Actor"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the updated actor"
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid request parameters",
+                        "description": "Invalid actor ID, malformed patch or validation errors",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Actor not found",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
-                    }
-                }
-            },
-            "post": {
-                "description": "Adds a new movie to the database",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Movies"
-                ],
-                "summary": "Create a new movie",
-                "parameters": [
-                    {
-                        "description": "Movie details",
-                        "name": "movie",
-                        "in": "body",
-                        "required": true,
+                    },
+                    "412": {
+                        "description": "Actor was modified since the given ETag or does not exist",
                         "schema": {
-                            "$ref": "#/definitions/models.CreateMovie"
+                            "$ref": "#/definitions/models.APIError"
                         }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Movie created successfully\"  example={\"id\": \"1234\"}",
+                    },
+                    "413": {
+                        "description": "Patch is larger than 1 MB",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.APIError"
                         }
                     },
-                    "400": {
-                        "description": "Invalid JSON format or validation errors",
+                    "415": {
+                        "description": "Unsupported Content-Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
@@ -489,65 +532,75 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
                     }
                 }
             }
         },
-        "/api/movies/search": {
+        "/api/actors/{actor_id}/headshot": {
             "get": {
-                "description": "Search for movies by a partial title and actor's name with optional pagination.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns the headshot image or, with size, its thumbnail of that width. Thumbnails of the widths in storage.thumbnail_widths\n(92, 185 and 500 by default) are made on upload as JPEG, or PNG for PNG headshots. If the headshot is not wider than size,\nthe original is returned. Responses carry an ETag that changes on every upload; send it in If-None-Match to get 304.",
                 "produces": [
-                    "application/json"
+                    "image/jpeg",
+                    "image/png",
+                    "image/webp"
                 ],
                 "tags": [
-                    "Movies"
+                    "Images"
                 ],
-                "summary": "Search movies by title and actor name",
+                "summary": "Get an actor headshot",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "\"Inception\"",
-                        "description": "Movie title fragment",
-                        "name": "title",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"Leonardo\"",
-                        "description": "Actor name fragment",
-                        "name": "actor_name",
-                        "in": "query"
+                        "example": "\"f47ac10b-58cc-4372-a567-0e02b2c3d479\"",
+                        "description": "Actor ID",
+                        "name": "actor_id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "default": 10,
-                        "description": "Limit the number of movies returned",
-                        "name": "limit",
+                        "example": 185,
+                        "description": "Thumbnail width in pixels, one of storage.thumbnail_widths",
+                        "name": "size",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset for pagination",
-                        "name": "offset",
-                        "in": "query"
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of movies matching the search",
+                        "description": "Headshot image",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Movie"
-                            }
+                            "type": "file"
                         }
                     },
+                    "304": {
+                        "description": "Cached copy is up to date"
+                    },
                     "400": {
-                        "description": "Invalid search parameters",
+                        "description": "Invalid actor ID or unknown size",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Actor or headshot not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
@@ -557,48 +610,80 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
                     }
                 }
-            }
-        },
-        "/api/movies/{id}": {
-            "get": {
-                "description": "Retrieves a movie by its unique identifier",
+            },
+            "put": {
+                "description": "Replaces the actor's headshot with a JPEG, PNG or WebP image. The type is detected from the file content,\nnot from the file name. The size limit is set by storage.max_image_size in the configuration (5 MB by default).\nThumbnails are made on upload; the headshot and its thumbnails are then served at headshot_url of the actor.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Movies"
+                    "Images"
                 ],
-                "summary": "Get movie by ID",
+                "summary": "Upload an actor headshot",
                 "parameters": [
                     {
                         "type": "string",
                         "example": "\"f47ac10b-58cc-4372-a567-0e02b2c3d479\"",
-                        "description": "Movie ID",
-                        "name": "movie_id",
+                        "description": "Actor ID",
+                        "name": "actor_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Headshot image",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The movie details",
+                        "description": "URL of the headshot",
                         "schema": {
-                            "$ref": "#/definitions/models.Movie"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid movie ID format",
+                        "description": "Invalid actor ID or missing file field",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
                     },
                     "404": {
-                        "description": "Movie not found",
+                        "description": "Actor not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "413": {
+                        "description": "Image is too large",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "415": {
+                        "description": "Not multipart/form-data, or not a valid JPEG, PNG or WebP image",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
@@ -608,46 +693,52 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
                     }
                 }
             },
-            "put": {
-                "description": "Update the details of a movie based on its ID",
-                "consumes": [
-                    "application/json"
-                ],
+            "delete": {
+                "description": "Removes the actor's headshot, if it has one.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Movies"
+                    "Images"
                 ],
-                "summary": "Update movie details",
+                "summary": "Remove an actor headshot",
                 "parameters": [
                     {
                         "type": "string",
                         "example": "\"f47ac10b-58cc-4372-a567-0e02b2c3d479\"",
-                        "description": "Movie ID",
-                        "name": "movie_id",
+                        "description": "Actor ID",
+                        "name": "actor_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated movie details",
-                        "name": "movie",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateMovie"
-                        }
                     }
                 ],
                 "responses": {
                     "204": {
-                        "description": "Movie successfully updated"
+                        "description": "Headshot removed"
                     },
                     "400": {
-                        "description": "Invalid request body or parameters",
+                        "description": "Invalid actor ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Actor not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
@@ -657,11 +748,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a movie by its ID",
+            }
+        },
+        "/api/actors/{actor_id}/movies": {
+            "get": {
+                "description": "Retrieve a page of the actor's movies, newest first, with cursor pagination",
                 "consumes": [
                     "application/json"
                 ],
@@ -671,29 +770,64 @@ const docTemplate = `{
                 "tags": [
                     "Movies"
                 ],
-                "summary": "Delete movie",
+                "summary": "Get movies by actor ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "\"f47ac10b-58cc-4372-a567-0e02b2c3d479\"",
-                        "description": "Movie ID",
-                        "name": "movie_id",
+                        "description": "Actor ID",
+                        "name": "actor_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit the number of movies returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Include the total number of movies",
+                        "name": "include_total",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Movie successfully deleted"
+                    "200": {
+                        "description": "Page of movies",
+                        "schema": {
+                            "$ref": "#/definitions/models.MoviePage"
+                        }
                     },
                     "400": {
-                        "description": "Invalid movie ID",
+                        "description": "Invalid actor ID format or bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
@@ -701,63 +835,124 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/movies/{movie_id}/actors": {
-            "put": {
-                "description": "Update the list of actors for a movie by movie ID",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/audit": {
+            "get": {
+                "description": "Changes made through the admin endpoints, newest first. Each entry records who made the change,\nthe entity state before and after it, and the request ID. Entries are written in the same transaction as the change.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "movie-actors"
+                    "Audit"
                 ],
-                "summary": "Update actors for a movie",
+                "summary": "Browse the audit log",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID of the movie",
-                        "name": "movie_id",
-                        "in": "path",
+                        "description": "JWT subject of the user who made the change",
+                        "name": "subject",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "update",
+                            "delete",
+                            "restore"
+                        ],
+                        "type": "string",
+                        "description": "Action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "movie",
+                            "movie_credits",
+                            "actor",
+                            "genre"
+                        ],
+                        "type": "string",
+                        "description": "Entity type; movie_credits entries use the movie ID",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"f47ac10b-58cc-4372-a567-0e02b2c3d479\"",
+                        "description": "Entity ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2024-01-01T00:00:00Z\"",
+                        "description": "Changes made at or after this time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2024-02-01T00:00:00Z\"",
+                        "description": "Changes made before this time (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "description": "Number of entries to return",
+                        "name": "limit",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "List of actor IDs to be updated for the movie",
-                        "name": "actor_ids",
-                        "in": "body",
-                        "required": true,
+                        "type": "integer",
+                        "description": "Number of entries to skip",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Audit log entries",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "string"
+                                "$ref": "#/definitions/models.AuditEntry"
                             }
                         }
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content",
+                    },
+                    "400": {
+                        "description": "Invalid filter, limit or offset",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.APIError"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body",
+                    "429": {
+                        "description": "Rate limit exceeded",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/auth/login": {
             "post": {
-                "description": "Add a list of actors to a movie by movie ID",
+                "description": "Checks the username and password and issues a signed JWT access token",
                 "consumes": [
                     "application/json"
                 ],
@@ -765,53 +960,272 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "movie-actors"
+                    "Auth"
                 ],
-                "summary": "Add actors to a movie",
+                "summary": "Log in",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID of the movie",
-                        "name": "movie_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "List of actor IDs to be added to the movie",
-                        "name": "actor_ids",
+                        "description": "User credentials",
+                        "name": "credentials",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.LoginRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "Access token",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.LoginResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid JSON format or validation errors",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Invalid username or password",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/export/{kind}": {
+            "get": {
+                "description": "Streams all movies, actors or credits (movie-actor links) that are not in the trash, ordered by ID.\nRows go straight from the database to the response, so the export works for catalogs of any size.\nAll data comes from one repeatable-read snapshot, consistent even while the catalog changes.\nMovie filters select movies and, for credits, the movies whose credits are exported; they are not supported for actors.\nCSV columns: movies: id, title, description, release_date, rating, genre_ids, version, updated_at;\nactors: id, name, gender, date_of_birth, version, updated_at; credits: movie_id, actor_id, role, character, order.\nIf an error occurs after streaming has started, the connection is closed without finishing the response.",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Export"
+                ],
+                "summary": "Export the catalog",
+                "parameters": [
+                    {
+                        "enum": [
+                            "movies",
+                            "actors",
+                            "credits"
+                        ],
+                        "type": "string",
+                        "description": "What to export",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "ndjson",
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "default": "ndjson",
+                        "description": "Output format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2024-01-01T00:00:00Z\"",
+                        "description": "Only movies or actors changed at or after this time (RFC 3339)",
+                        "name": "updated_since",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 10,
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Minimum rating, inclusive",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 10,
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Maximum rating, inclusive",
+                        "name": "max_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2000-01-01\"",
+                        "description": "Released on or after this date (YYYY-MM-DD)",
+                        "name": "release_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2010-12-31\"",
+                        "description": "Released on or before this date (YYYY-MM-DD)",
+                        "name": "release_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 2000,
+                        "description": "Released in or after this year",
+                        "name": "year_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 2010,
+                        "description": "Released in or before this year",
+                        "name": "year_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Actor IDs, comma separated or repeated",
+                        "name": "actor_ids",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Whether a movie must feature any or all of actor_ids",
+                        "name": "actor_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Genre IDs, comma separated or repeated; a movie matches if it has any of them",
+                        "name": "genre_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"The \"",
+                        "description": "Case-insensitive title prefix",
+                        "name": "title_prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only movies with (true) or without (false) a description",
+                        "name": "has_description",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exported records; actors export models.Actor, credits export models.ExportCredit",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Movie"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown kind or format, or invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/genres": {
+            "get": {
+                "description": "Retrieves all genres sorted by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Genres"
+                ],
+                "summary": "Get all genres",
+                "responses": {
+                    "200": {
+                        "description": "List of genres",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Genre"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
                         "schema": {
                             "$ref": "#/definitions/models.APIError"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "Remove a list of actors from a movie by movie ID",
+            "post": {
+                "description": "Adds a new genre. Genre names are unique, case-insensitively.",
                 "consumes": [
                     "application/json"
                 ],
@@ -819,79 +1233,2164 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "movie-actors"
+                    "Genres"
                 ],
-                "summary": "Remove actors from a movie",
+                "summary": "Create a new genre",
+                "parameters": [
+                    {
+                        "description": "Genre details",
+                        "name": "genre",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateGenre"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Genre created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON format or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Genre with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/genres/{genre_id}": {
+            "get": {
+                "description": "Retrieves a genre by its unique identifier",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Genres"
+                ],
+                "summary": "Get genre by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID of the movie",
-                        "name": "movie_id",
+                        "example": "\"f47ac10b-58cc-4372-a567-0e02b2c3d479\"",
+                        "description": "Genre ID",
+                        "name": "genre_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The genre",
+                        "schema": {
+                            "$ref": "#/definitions/models.Genre"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid genre ID format",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Genre not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Renames a genre",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Genres"
+                ],
+                "summary": "Update genre",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"f47ac10b-58cc-4372-a567-0e02b2c3d479\"",
+                        "description": "Genre ID",
+                        "name": "genre_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "List of actor IDs to be removed from the movie",
-                        "name": "actor_ids",
+                        "description": "Updated genre details",
+                        "name": "genre",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.UpdateGenre"
                         }
                     }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request body",
-                        "schema": {
-                            "$ref": "#/definitions/models.APIError"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.APIError"
-                        }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Genre successfully updated"
+                    },
+                    "400": {
+                        "description": "Invalid request body or parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Genre not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Genre with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a genre and removes it from all movies",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Genres"
+                ],
+                "summary": "Delete genre",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"f47ac10b-58cc-4372-a567-0e02b2c3d479\"",
+                        "description": "Genre ID",
+                        "name": "genre_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Genre successfully deleted"
+                    },
+                    "400": {
+                        "description": "Invalid genre ID format",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Genre not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/import/{kind}": {
+            "post": {
+                "description": "Imports a CSV or NDJSON file in a single transaction. Each row is validated like the corresponding create request;\nrows with errors are skipped and reported with their line numbers. CSV files need a header row with column names:\nactors: name, gender, date_of_birth; movies: title, description, release_date, rating, actor_ids, genre_ids\n(comma-separated IDs); credits: movie_id or movie_title + movie_release_date, actor_id or actor_name + actor_date_of_birth,\nrole, character, order. Dates are YYYY-MM-DD. NDJSON lines use the same fields as the JSON API.\nWith dry_run nothing is saved; with atomic nothing is saved if any row has errors. The file is limited to 32 MB.",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Import"
+                ],
+                "summary": "Bulk import actors, movies or credits",
+                "parameters": [
+                    {
+                        "enum": [
+                            "actors",
+                            "movies",
+                            "credits"
+                        ],
+                        "type": "string",
+                        "description": "What the file contains",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "File format; defaults to the Content-Type",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate and report errors without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Save nothing if any row has errors",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "description": "File contents",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Import result with per-row errors",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown kind or format, invalid CSV header or malformed file",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "413": {
+                        "description": "File is larger than 32 MB",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/movies": {
+            "get": {
+                "description": "Retrieve a page of movies matching the filters, with sorting and cursor pagination.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Get movies with filters",
+                "parameters": [
+                    {
+                        "enum": [
+                            "title",
+                            "release_date",
+                            "rating"
+                        ],
+                        "type": "string",
+                        "default": "rating",
+                        "description": "Field to sort by",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ASC",
+                            "DESC"
+                        ],
+                        "type": "string",
+                        "default": "DESC",
+                        "description": "Sorting order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 10,
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Minimum rating, inclusive",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 10,
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Maximum rating, inclusive",
+                        "name": "max_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2000-01-01\"",
+                        "description": "Released on or after this date (YYYY-MM-DD)",
+                        "name": "release_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2010-12-31\"",
+                        "description": "Released on or before this date (YYYY-MM-DD)",
+                        "name": "release_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 2000,
+                        "description": "Released in or after this year",
+                        "name": "year_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 2010,
+                        "description": "Released in or before this year",
+                        "name": "year_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Actor IDs, comma separated or repeated",
+                        "name": "actor_ids",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Whether a movie must feature any or all of actor_ids",
+                        "name": "actor_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Genre IDs, comma separated or repeated; a movie matches if it has any of them",
+                        "name": "genre_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"The \"",
+                        "description": "Case-insensitive title prefix",
+                        "name": "title_prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only movies with (true) or without (false) a description",
+                        "name": "has_description",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit the number of movies returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from next_cursor of the previous page, valid only with the same sortBy and order",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Include the total number of matching movies",
+                        "name": "include_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of filtered movies",
+                        "schema": {
+                            "$ref": "#/definitions/models.MoviePage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a new movie to the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Create a new movie",
+                "parameters": [
+                    {
+                        "description": "Movie details",
+                        "name": "movie",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateMovie"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Movie created successfully\"  example={\"id\": \"1234\"}",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON format or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/movies/batch": {
+            "post": {
+                "description": "Adds up to 100 movies in a single transaction. Every movie is validated like POST /api/movies,\nand its actors and genres must exist. Results are returned per index in request order.\nBy default nothing is created if any movie has errors; with best_effort the valid movies are created anyway.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Create movies in a batch",
+                "parameters": [
+                    {
+                        "description": "Movies to create",
+                        "name": "movies",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CreateMovie"
+                            }
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Create the valid movies even if some have errors",
+                        "name": "best_effort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "At least one movie was created",
+                        "schema": {
+                            "$ref": "#/definitions/models.BatchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON format, empty batch or more than 100 movies",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "No movies were created because of validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/models.BatchResult"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/movies/search": {
+            "get": {
+                "description": "Search movies by title, description and cast names. Russian and English words are matched by their stems,\nresults are ordered by relevance and contain highlighted fragments. Supports web search syntax: \"quoted phrases\", OR, -exclusion.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Full-text search for movies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"Inception DiCaprio\"",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Inception\"",
+                        "description": "Deprecated: appended to the search query",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Leonardo\"",
+                        "description": "Deprecated: appended to the search query",
+                        "name": "actor_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit the number of movies returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of movies matching the search",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.MovieSearchResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid search parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/movies/{movie_id}": {
+            "get": {
+                "description": "Retrieves a movie by its unique identifier",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Get movie by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"f47ac10b-58cc-4372-a567-0e02b2c3d479\"",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The movie details",
+                        "schema": {
+                            "$ref": "#/definitions/models.Movie"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the movie"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified since the given ETag"
+                    },
+                    "400": {
+                        "description": "Invalid movie ID format",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update the details of a movie based on its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Update movie details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"f47ac10b-58cc-4372-a567-0e02b2c3d479\"",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated movie details",
+                        "name": "movie",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateMovie"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Update only if the movie still has this ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Movie successfully updated"
+                    },
+                    "400": {
+                        "description": "Invalid request body or parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "412": {
+                        "description": "Movie was modified since the given ETag or does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Move a movie to the trash by its ID. It disappears from all public reads and can be restored until the retention period ends.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Delete movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"f47ac10b-58cc-4372-a567-0e02b2c3d479\"",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delete only if the movie still has this ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Movie successfully deleted"
+                    },
+                    "400": {
+                        "description": "Invalid movie ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "412": {
+                        "description": "Movie was modified since the given ETag or does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Applies a JSON Merge Patch (RFC 7396) to the movie's title, description, release_date, rating and genre_ids.\nFields missing from the patch stay unchanged, null clears description or rating, and genre_ids replaces the whole list.\nThe patched movie is validated like a new one. Other fields, such as id or version, cannot be patched.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Partially update a movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"f47ac10b-58cc-4372-a567-0e02b2c3d479\"",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch with the fields to change",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MovieFields"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Update only if the movie still has this ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The updated movie",
+                        "schema": {
+                            "$ref": "#/definitions/models.Movie"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the updated movie"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid movie ID, malformed patch or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "412": {
+                        "description": "Movie was modified since the given ETag or does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "413": {
+                        "description": "Patch is larger than 1 MB",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Content-Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/movies/{movie_id}/actors": {
+            "put": {
+                "description": "Replace all cast and crew credits of a movie by movie ID. Each item is either a credit object or a plain actor ID meaning the actor role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movie-actors"
+                ],
+                "summary": "Replace credits of a movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the movie",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New credits of the movie",
+                        "name": "credits",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CreditInput"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add cast and crew credits to a movie by movie ID. Each item is either a credit object or, as before, a plain actor ID meaning the actor role. Existing credits with the same person and role get their character and order updated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movie-actors"
+                ],
+                "summary": "Add credits to a movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the movie",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credits to be added to the movie",
+                        "name": "credits",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CreditInput"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a list of people from a movie by movie ID, together with all their credits in it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movie-actors"
+                ],
+                "summary": "Remove actors from a movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the movie",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "List of actor IDs to be removed from the movie",
+                        "name": "actor_ids",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/movies/{movie_id}/credits": {
+            "get": {
+                "description": "Retrieves the cast and crew of a movie. Both lists are ordered by billing order, then by name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Get movie credits",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"f47ac10b-58cc-4372-a567-0e02b2c3d479\"",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cast and crew",
+                        "schema": {
+                            "$ref": "#/definitions/models.MovieCredits"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid movie ID format",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/movies/{movie_id}/poster": {
+            "get": {
+                "description": "Returns the poster image or, with size, its thumbnail of that width. Thumbnails of the widths in storage.thumbnail_widths\n(92, 185 and 500 by default) are made on upload as JPEG, or PNG for PNG posters. If the poster is not wider than size,\nthe original is returned. Responses carry an ETag that changes on every upload; send it in If-None-Match to get 304.",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/webp"
+                ],
+                "tags": [
+                    "Images"
+                ],
+                "summary": "Get a movie poster",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"f47ac10b-58cc-4372-a567-0e02b2c3d479\"",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 185,
+                        "description": "Thumbnail width in pixels, one of storage.thumbnail_widths",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Poster image",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Cached copy is up to date"
+                    },
+                    "400": {
+                        "description": "Invalid movie ID or unknown size",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie or poster not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the movie's poster with a JPEG, PNG or WebP image. The type is detected from the file content,\nnot from the file name. The size limit is set by storage.max_image_size in the configuration (5 MB by default).\nThumbnails are made on upload; the poster and its thumbnails are then served at poster_url of the movie.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Images"
+                ],
+                "summary": "Upload a movie poster",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"f47ac10b-58cc-4372-a567-0e02b2c3d479\"",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Poster image",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "URL of the poster",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid movie ID or missing file field",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "413": {
+                        "description": "Image is too large",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "415": {
+                        "description": "Not multipart/form-data, or not a valid JPEG, PNG or WebP image",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes the movie's poster, if it has one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Images"
+                ],
+                "summary": "Remove a movie poster",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"f47ac10b-58cc-4372-a567-0e02b2c3d479\"",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Poster removed"
+                    },
+                    "400": {
+                        "description": "Invalid movie ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trash/actors": {
+            "get": {
+                "description": "Actors in the trash, most recently deleted first. They stay restorable until the retention period ends.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "List deleted actors",
+                "parameters": [
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "description": "Number of actors to return",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of actors to skip",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted actors",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TrashedActor"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid limit or offset",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trash/actors/{actor_id}/restore": {
+            "post": {
+                "description": "Moves an actor out of the trash. The actor reappears in the credits of all their movies.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore a deleted actor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"f47ac10b-58cc-4372-a567-0e02b2c3d479\"",
+                        "description": "Actor ID",
+                        "name": "actor_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Actor restored"
+                    },
+                    "400": {
+                        "description": "Invalid actor ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Actor is not in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trash/movies": {
+            "get": {
+                "description": "Movies in the trash, most recently deleted first. They stay restorable until the retention period ends.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "List deleted movies",
+                "parameters": [
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "description": "Number of movies to return",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of movies to skip",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted movies",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TrashedMovie"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid limit or offset",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trash/movies/{movie_id}/restore": {
+            "post": {
+                "description": "Moves a movie out of the trash. Its cast, crew and genres are restored with it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore a deleted movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"f47ac10b-58cc-4372-a567-0e02b2c3d479\"",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Movie restored"
+                    },
+                    "400": {
+                        "description": "Invalid movie ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie is not in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Reports that the process is running. Does not check any dependencies.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "Process is alive",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Checks that the database answers a ping, the schema is migrated to the expected version\nand the server is not shutting down. Each check is reported separately.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "Ready to serve requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthReport"
+                        }
+                    },
+                    "503": {
+                        "description": "One or more checks failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthReport"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Код ошибки (например, VALIDATION_ERROR, INVALID_JSON, etc.)",
+                    "type": "string"
+                },
+                "details": {
+                    "description": "Дополнительные данные об ошибке (может быть nil или содержать ValidationError, массив и т.д.)"
+                },
+                "message": {
+                    "description": "Общее описание ошибки",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Actor": {
+            "type": "object",
+            "properties": {
+                "date_of_birth": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "headshot_url": {
+                    "description": "пусто, если фотография не загружена; уменьшенные копии - с параметром size",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "время последнего изменения",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "увеличивается при каждом изменении, из него строится ETag",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ActorFields": {
+            "type": "object",
+            "properties": {
+                "date_of_birth": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ActorPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Actor"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ActorWithMovies": {
+            "type": "object",
+            "properties": {
+                "date_of_birth": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "headshot_url": {
+                    "description": "пусто, если фотография не загружена; уменьшенные копии - с параметром size",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "movies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Movie"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "время последнего изменения",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "увеличивается при каждом изменении, из него строится ETag",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ActorWithMoviesPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ActorWithMovies"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete",
+                        "restore"
+                    ]
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "enum": [
+                        "movie",
+                        "movie_credits",
+                        "actor",
+                        "genre"
+                    ]
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "subject": {
+                    "description": "sub из JWT",
+                    "type": "string"
+                }
+            }
+        },
+        "models.BatchItemResult": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ValidationError"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BatchResult": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BatchItemResult"
+                    }
+                }
+            }
+        },
+        "models.CreateActor": {
+            "type": "object",
+            "properties": {
+                "date_of_birth": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateGenre": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateMovie": {
+            "type": "object",
+            "properties": {
+                "actor_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "genre_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.APIError": {
+        },
+        "models.Credit": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "Код ошибки (например, VALIDATION_ERROR, INVALID_JSON, etc.)",
+                "actor_id": {
                     "type": "string"
                 },
-                "details": {
-                    "description": "Дополнительные данные об ошибке (может быть nil или содержать ValidationError, массив и т.д.)"
+                "character": {
+                    "type": "string"
                 },
-                "message": {
-                    "description": "Общее описание ошибки",
+                "name": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "integer"
+                },
+                "role": {
                     "type": "string"
                 }
             }
         },
-        "models.Actor": {
+        "models.CreditInput": {
             "type": "object",
             "properties": {
-                "date_of_birth": {
+                "actor_id": {
                     "type": "string"
                 },
-                "gender": {
+                "character": {
                     "type": "string"
                 },
+                "order": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "actor"
+                }
+            }
+        },
+        "models.Genre": {
+            "type": "object",
+            "properties": {
                 "id": {
                     "type": "string"
                 },
@@ -900,83 +3399,330 @@ const docTemplate = `{
                 }
             }
         },
-        "models.ActorWithMovies": {
+        "models.HealthCheck": {
             "type": "object",
             "properties": {
-                "date_of_birth": {
+                "duration_ms": {
+                    "type": "number",
+                    "example": 1.25
+                },
+                "error": {
                     "type": "string"
                 },
-                "gender": {
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "models.HealthReport": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.HealthCheck"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "models.ImportResult": {
+            "type": "object",
+            "properties": {
+                "atomic": {
+                    "type": "boolean"
+                },
+                "committed": {
+                    "type": "boolean"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportRowError"
+                    }
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "actors",
+                        "movies",
+                        "credits"
+                    ]
+                },
+                "rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ValidationError"
+                    }
+                },
+                "row": {
+                    "description": "номер строки в файле, начиная с 1; заголовок CSV - строка 1",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
                     "type": "string"
                 },
-                "id": {
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
                     "type": "string"
                 },
-                "movies": {
+                "expires_in": {
+                    "description": "Время жизни токена в секундах",
+                    "type": "integer"
+                },
+                "token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Movie": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "genres": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Movie"
+                        "$ref": "#/definitions/models.Genre"
                     }
                 },
-                "name": {
+                "id": {
+                    "type": "string"
+                },
+                "poster_url": {
+                    "description": "пусто, если постер не загружен; уменьшенные копии - с параметром size",
+                    "type": "string"
+                },
+                "rating": {
+                    "description": "null, если у фильма нет рейтинга",
+                    "type": "number",
+                    "x-nullable": true
+                },
+                "release_date": {
                     "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "время последнего изменения",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "увеличивается при каждом изменении, из него строится ETag",
+                    "type": "integer"
                 }
             }
         },
-        "models.CreateActor": {
+        "models.MovieCredits": {
             "type": "object",
             "properties": {
-                "date_of_birth": {
+                "cast": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Credit"
+                    }
+                },
+                "crew": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Credit"
+                    }
+                }
+            }
+        },
+        "models.MovieFields": {
+            "type": "object",
+            "properties": {
+                "description": {
                     "type": "string"
                 },
-                "gender": {
+                "genre_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "release_date": {
                     "type": "string"
                 },
-                "name": {
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "models.CreateMovie": {
+        "models.MoviePage": {
             "type": "object",
             "properties": {
-                "actor_ids": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/models.Movie"
                     }
                 },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MovieSearchResult": {
+            "type": "object",
+            "properties": {
                 "description": {
                     "type": "string"
                 },
-                "rating": {
+                "description_snippet": {
+                    "type": "string"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Genre"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "poster_url": {
+                    "description": "пусто, если постер не загружен; уменьшенные копии - с параметром size",
+                    "type": "string"
+                },
+                "rank": {
                     "type": "number"
                 },
+                "rating": {
+                    "description": "null, если у фильма нет рейтинга",
+                    "type": "number",
+                    "x-nullable": true
+                },
                 "release_date": {
                     "type": "string"
                 },
                 "title": {
                     "type": "string"
+                },
+                "title_highlight": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "время последнего изменения",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "увеличивается при каждом изменении, из него строится ETag",
+                    "type": "integer"
                 }
             }
         },
-        "models.Movie": {
+        "models.TrashedActor": {
+            "type": "object",
+            "properties": {
+                "date_of_birth": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "headshot_url": {
+                    "description": "пусто, если фотография не загружена; уменьшенные копии - с параметром size",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "время последнего изменения",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "увеличивается при каждом изменении, из него строится ETag",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TrashedMovie": {
             "type": "object",
             "properties": {
+                "deleted_at": {
+                    "type": "string"
+                },
                 "description": {
                     "type": "string"
                 },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Genre"
+                    }
+                },
                 "id": {
                     "type": "string"
                 },
+                "poster_url": {
+                    "description": "пусто, если постер не загружен; уменьшенные копии - с параметром size",
+                    "type": "string"
+                },
                 "rating": {
-                    "type": "number"
+                    "description": "null, если у фильма нет рейтинга",
+                    "type": "number",
+                    "x-nullable": true
                 },
                 "release_date": {
                     "type": "string"
                 },
                 "title": {
                     "type": "string"
+                },
+                "updated_at": {
+                    "description": "время последнего изменения",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "увеличивается при каждом изменении, из него строится ETag",
+                    "type": "integer"
                 }
             }
         },
@@ -994,6 +3740,14 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UpdateGenre": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
         "models.UpdateMovie": {
             "type": "object",
             "properties": {
@@ -1006,6 +3760,12 @@ const docTemplate = `{
                 "description": {
                     "type": "string"
                 },
+                "genre_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "rating": {
                     "type": "number"
                 },
@@ -1016,6 +3776,17 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "models.ValidationError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
@@ -0,0 +1,29 @@
+// Package audit передает через контекст запроса, кто выполняет изменение,
+// чтобы сервисы могли записать это в журнал аудита.
+package audit
+
+import "context"
+
+// Кто выполняет изменение: субъект и роль из JWT
+type Actor struct {
+	Subject string
+	Role    string
+}
+
+// System записывается в журнал для изменений, сделанных не через HTTP: фоновые задачи, команды CLI
+var System = Actor{Subject: "system", Role: "system"}
+
+type actorKey struct{}
+
+// WithActor сохраняет в контексте, кто выполняет изменение
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom возвращает исполнителя из контекста или System, если его там нет
+func ActorFrom(ctx context.Context) Actor {
+	if actor, ok := ctx.Value(actorKey{}).(Actor); ok {
+		return actor
+	}
+	return System
+}
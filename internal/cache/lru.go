@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LRU - потокобезопасный кэш с ограничением по числу записей и временем жизни записи.
+// При переполнении вытесняется запись, к которой дольше всего не обращались.
+// Все методы допускают nil-получатель: nil-кэш ничего не хранит, поэтому его можно
+// передать, когда кэширование выключено.
+type LRU struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu         sync.Mutex
+	items      map[string]*list.Element
+	order      *list.List // в начале - последние использованные
+	generation uint64
+	stats      Stats
+}
+
+// Stats - счетчики с момента создания кэша
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64 // вытеснены при переполнении
+	Expired   uint64 // удалены по истечении ttl
+	Entries   int
+}
+
+type entry struct {
+	key     string
+	value   any
+	expires time.Time
+}
+
+func New(size int, ttl time.Duration) *LRU {
+	return newLRU(size, ttl, time.Now)
+}
+
+func newLRU(size int, ttl time.Duration, now func() time.Time) *LRU {
+	return &LRU{
+		size:  size,
+		ttl:   ttl,
+		now:   now,
+		items: make(map[string]*list.Element),
+		order: list.New(),
+	}
+}
+
+// Get возвращает значение, если оно есть и не устарело
+func (c *LRU) Get(key string) (any, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+	e := elem.Value.(*entry)
+	if !c.now().Before(e.expires) {
+		c.remove(elem)
+		c.stats.Expired++
+		c.stats.Misses++
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	c.stats.Hits++
+	return e.value, true
+}
+
+// Generation возвращает номер поколения, который нужно запомнить до чтения из источника
+// и передать в Set. Каждый вызов Invalidate увеличивает поколение.
+func (c *LRU) Generation() uint64 {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// Set сохраняет значение, прочитанное в поколении generation. Если с тех пор была инвалидация,
+// значение могло устареть еще до записи, поэтому оно отбрасывается.
+func (c *LRU) Set(key string, value any, generation uint64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	expires := c.now().Add(c.ttl)
+	if elem, ok := c.items[key]; ok {
+		e := elem.Value.(*entry)
+		e.value, e.expires = value, expires
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&entry{key: key, value: value, expires: expires})
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
+	}
+}
+
+// Invalidate удаляет все записи, ключ которых начинается с одного из префиксов
+func (c *LRU) Invalidate(prefixes ...string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for key, elem := range c.items {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(key, prefix) {
+				c.remove(elem)
+				break
+			}
+		}
+	}
+}
+
+func (c *LRU) Stats() Stats {
+	if c == nil {
+		return Stats{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.order.Len()
+	return stats
+}
+
+func (c *LRU) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.items, elem.Value.(*entry).key)
+}
@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := New(2, time.Minute)
+
+	c.Set("a", 1, c.Generation())
+	c.Set("b", 2, c.Generation())
+	_, _ = c.Get("a") // "b" становится самой старой записью
+	c.Set("c", 3, c.Generation())
+
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+	value, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	stats := c.Stats()
+	assert.Equal(t, uint64(1), stats.Evictions)
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, 2, stats.Entries)
+}
+
+func TestLRUExpiresEntries(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := newLRU(10, time.Minute, func() time.Time { return now })
+
+	c.Set("a", 1, c.Generation())
+	now = now.Add(59 * time.Second)
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+
+	now = now.Add(time.Second)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, uint64(1), c.Stats().Expired)
+	assert.Equal(t, 0, c.Stats().Entries)
+}
+
+func TestLRUInvalidateByPrefix(t *testing.T) {
+	c := New(10, time.Minute)
+	gen := c.Generation()
+	c.Set("movie:1", 1, gen)
+	c.Set("movies:filter:x", 2, gen)
+	c.Set("actor:1", 3, gen)
+
+	c.Invalidate("movies:")
+
+	_, ok := c.Get("movies:filter:x")
+	assert.False(t, ok)
+	_, ok = c.Get("movie:1")
+	assert.True(t, ok)
+	_, ok = c.Get("actor:1")
+	assert.True(t, ok)
+}
+
+func TestLRUDropsValueReadBeforeInvalidation(t *testing.T) {
+	c := New(10, time.Minute)
+
+	// Чтение началось до изменения данных, а закончилось после инвалидации
+	gen := c.Generation()
+	c.Invalidate("movie:1")
+	c.Set("movie:1", "stale", gen)
+
+	_, ok := c.Get("movie:1")
+	assert.False(t, ok)
+}
+
+func TestNilLRUIsNoop(t *testing.T) {
+	var c *LRU
+
+	assert.NotPanics(t, func() {
+		c.Set("a", 1, c.Generation())
+		c.Invalidate("a")
+	})
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, Stats{}, c.Stats())
+}
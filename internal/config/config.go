@@ -0,0 +1,461 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	EnvDev  = "dev"
+	EnvTest = "test"
+	EnvProd = "prod"
+)
+
+// Секрет по умолчанию годится только для локальной разработки
+const defaultJWTSecret = "dev-secret-change-me"
+
+type Config struct {
+	Env       string    `yaml:"env"`
+	HTTP      HTTP      `yaml:"http"`
+	Database  Database  `yaml:"database"`
+	Auth      Auth      `yaml:"auth"`
+	Log       Log       `yaml:"log"`
+	Health    Health    `yaml:"health"`
+	RateLimit RateLimit `yaml:"rate_limit"`
+	Cache     Cache     `yaml:"cache"`
+	Trash     Trash     `yaml:"trash"`
+	Storage   Storage   `yaml:"storage"`
+}
+
+type HTTP struct {
+	Addr string `yaml:"addr"`
+	// Предельное время обработки одного запроса, включая запросы к БД
+	RequestTimeout time.Duration `yaml:"request_timeout"`
+	// Предельное время выгрузки каталога: она идет потоком и может длиться дольше обычного запроса
+	ExportTimeout time.Duration `yaml:"export_timeout"`
+	// Предельное время импорта: файл до 32 МБ читается и сохраняется в рамках одного запроса
+	ImportTimeout time.Duration `yaml:"import_timeout"`
+	// Таймауты соединения http.Server
+	ReadTimeout  time.Duration `yaml:"read_timeout"`
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout"`
+	// Сколько ждать завершения текущих запросов и фоновых задач при остановке
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// Адреса или подсети прокси, которым можно доверить X-Forwarded-For.
+	// Пустой список - IP клиента берется из соединения.
+	TrustedProxies []string `yaml:"trusted_proxies"`
+}
+
+type Database struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Name     string `yaml:"name"`
+	SSLMode  string `yaml:"sslmode"`
+}
+
+type Auth struct {
+	JWTSecret string        `yaml:"jwt_secret"`
+	TokenTTL  time.Duration `yaml:"token_ttl"`
+}
+
+type Log struct {
+	// debug, info, warn или error
+	Level string `yaml:"level"`
+}
+
+// SlogLevel уровень логирования для log/slog, неизвестное значение отсекает Validate
+func (l Log) SlogLevel() slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(l.Level)); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
+type Health struct {
+	// Предельное время каждой проверки /readyz
+	CheckTimeout time.Duration `yaml:"check_timeout"`
+	// Сколько /readyz отвечает fail после сигнала остановки, прежде чем сервер
+	// перестанет принимать соединения: балансировщик успевает убрать экземпляр
+	ShutdownDelay time.Duration `yaml:"shutdown_delay"`
+}
+
+// Лимиты запросов по группам маршрутов, имена групп задаются в routes.SetupRoutes
+type RateLimit struct {
+	Enabled bool                     `yaml:"enabled"`
+	Groups  map[string]RateLimitRule `yaml:"groups"`
+}
+
+type RateLimitRule struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	Burst             int     `yaml:"burst"`
+}
+
+// Кэш чтения фильмов и актеров в памяти процесса. Изменения через этот же экземпляр
+// сбрасывают кэш сразу, изменения через другие экземпляры видны не позже чем через TTL.
+type Cache struct {
+	Enabled bool          `yaml:"enabled"`
+	Size    int           `yaml:"size"` // максимальное число записей
+	TTL     time.Duration `yaml:"ttl"`
+}
+
+// Корзина удаленных фильмов и актеров
+type Trash struct {
+	// Сколько удаленные записи можно восстановить, после этого они удаляются окончательно
+	Retention time.Duration `yaml:"retention"`
+	// Как часто запускается окончательное удаление
+	PurgeInterval time.Duration `yaml:"purge_interval"`
+}
+
+// Наибольшая ширина уменьшенной копии изображения
+const MaxThumbnailWidth = 4096
+
+// Хранилище изображений: постеров фильмов и фотографий актеров
+type Storage struct {
+	// Каталог, в котором хранятся файлы изображений
+	Dir string `yaml:"dir"`
+	// Максимальный размер загружаемого изображения в байтах
+	MaxImageSize int `yaml:"max_image_size"`
+	// Ширины уменьшенных копий, которые создаются при загрузке изображения
+	ThumbnailWidths []int `yaml:"thumbnail_widths"`
+}
+
+// Значения по умолчанию совпадают с deployments/docker-compose.yaml
+func Default() *Config {
+	return &Config{
+		Env: EnvDev,
+		HTTP: HTTP{
+			Addr:            ":8080",
+			RequestTimeout:  10 * time.Second,
+			ExportTimeout:   10 * time.Minute,
+			ImportTimeout:   5 * time.Minute,
+			ReadTimeout:     15 * time.Second,
+			WriteTimeout:    30 * time.Second,
+			IdleTimeout:     time.Minute,
+			ShutdownTimeout: 20 * time.Second,
+		},
+		Database: Database{
+			Host:     "localhost",
+			Port:     5432,
+			User:     "postgres",
+			Password: "password",
+			Name:     "cinematheque",
+			SSLMode:  "disable",
+		},
+		Auth: Auth{
+			JWTSecret: defaultJWTSecret,
+			TokenTTL:  time.Hour,
+		},
+		Log: Log{
+			Level: "info",
+		},
+		Health: Health{
+			CheckTimeout: 2 * time.Second,
+		},
+		RateLimit: RateLimit{
+			Enabled: true,
+			Groups: map[string]RateLimitRule{
+				"public": {RequestsPerSecond: 20, Burst: 40},
+				"heavy":  {RequestsPerSecond: 2, Burst: 5},
+				"auth":   {RequestsPerSecond: 0.2, Burst: 5},
+				"admin":  {RequestsPerSecond: 10, Burst: 20},
+			},
+		},
+		Cache: Cache{
+			Enabled: true,
+			Size:    10000,
+			TTL:     time.Minute,
+		},
+		Trash: Trash{
+			Retention:     30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+		Storage: Storage{
+			Dir:             "data/images",
+			MaxImageSize:    5 << 20,
+			ThumbnailWidths: []int{92, 185, 500},
+		},
+	}
+}
+
+// Load собирает конфигурацию: значения по умолчанию, затем YAML-файл (если path не пустой),
+// затем переменные окружения CINEMA_*. Результат проходит валидацию.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) applyEnv() error {
+	setString(&c.Env, "CINEMA_ENV")
+	setString(&c.HTTP.Addr, "CINEMA_HTTP_ADDR")
+	setString(&c.Database.Host, "CINEMA_DB_HOST")
+	setString(&c.Database.User, "CINEMA_DB_USER")
+	setString(&c.Database.Password, "CINEMA_DB_PASSWORD")
+	setString(&c.Database.Name, "CINEMA_DB_NAME")
+	setString(&c.Database.SSLMode, "CINEMA_DB_SSLMODE")
+	setString(&c.Auth.JWTSecret, "CINEMA_JWT_SECRET")
+	setString(&c.Log.Level, "CINEMA_LOG_LEVEL")
+	setString(&c.Storage.Dir, "CINEMA_STORAGE_DIR")
+
+	if err := setInt(&c.Database.Port, "CINEMA_DB_PORT"); err != nil {
+		return err
+	}
+	if err := setBool(&c.RateLimit.Enabled, "CINEMA_RATE_LIMIT_ENABLED"); err != nil {
+		return err
+	}
+	if err := setBool(&c.Cache.Enabled, "CINEMA_CACHE_ENABLED"); err != nil {
+		return err
+	}
+	if err := setInt(&c.Cache.Size, "CINEMA_CACHE_SIZE"); err != nil {
+		return err
+	}
+	if err := setInt(&c.Storage.MaxImageSize, "CINEMA_STORAGE_MAX_IMAGE_SIZE"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.HTTP.RequestTimeout, "CINEMA_HTTP_REQUEST_TIMEOUT"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.HTTP.ExportTimeout, "CINEMA_HTTP_EXPORT_TIMEOUT"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.HTTP.ImportTimeout, "CINEMA_HTTP_IMPORT_TIMEOUT"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.HTTP.ReadTimeout, "CINEMA_HTTP_READ_TIMEOUT"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.HTTP.WriteTimeout, "CINEMA_HTTP_WRITE_TIMEOUT"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.HTTP.IdleTimeout, "CINEMA_HTTP_IDLE_TIMEOUT"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.HTTP.ShutdownTimeout, "CINEMA_HTTP_SHUTDOWN_TIMEOUT"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.Auth.TokenTTL, "CINEMA_JWT_TTL"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.Health.CheckTimeout, "CINEMA_HEALTH_CHECK_TIMEOUT"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.Health.ShutdownDelay, "CINEMA_HEALTH_SHUTDOWN_DELAY"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.Cache.TTL, "CINEMA_CACHE_TTL"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.Trash.Retention, "CINEMA_TRASH_RETENTION"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.Trash.PurgeInterval, "CINEMA_TRASH_PURGE_INTERVAL"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func setString(dst *string, key string) {
+	if value, ok := os.LookupEnv(key); ok {
+		*dst = value
+	}
+}
+
+func setInt(dst *int, key string) error {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", key, err)
+	}
+	*dst = parsed
+	return nil
+}
+
+func setBool(dst *bool, key string) error {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", key, err)
+	}
+	*dst = parsed
+	return nil
+}
+
+func setDuration(dst *time.Duration, key string) error {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return nil
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", key, err)
+	}
+	*dst = parsed
+	return nil
+}
+
+// Validate возвращает все найденные ошибки конфигурации разом
+func (c *Config) Validate() error {
+	var errs []error
+
+	switch c.Env {
+	case EnvDev, EnvTest, EnvProd:
+	default:
+		errs = append(errs, fmt.Errorf("env must be one of %q, %q, %q, got %q", EnvDev, EnvTest, EnvProd, c.Env))
+	}
+
+	if c.HTTP.Addr == "" {
+		errs = append(errs, errors.New("http.addr is required"))
+	}
+	for _, timeout := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"http.request_timeout", c.HTTP.RequestTimeout},
+		{"http.export_timeout", c.HTTP.ExportTimeout},
+		{"http.import_timeout", c.HTTP.ImportTimeout},
+		{"http.read_timeout", c.HTTP.ReadTimeout},
+		{"http.write_timeout", c.HTTP.WriteTimeout},
+		{"http.idle_timeout", c.HTTP.IdleTimeout},
+		{"http.shutdown_timeout", c.HTTP.ShutdownTimeout},
+	} {
+		if timeout.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive", timeout.name))
+		}
+	}
+	// Иначе соединение закроется раньше, чем клиент получит ответ 504
+	if c.HTTP.WriteTimeout <= c.HTTP.RequestTimeout {
+		errs = append(errs, fmt.Errorf("http.write_timeout (%s) must be greater than http.request_timeout (%s)", c.HTTP.WriteTimeout, c.HTTP.RequestTimeout))
+	}
+
+	if c.Database.Host == "" {
+		errs = append(errs, errors.New("database.host is required"))
+	}
+	if c.Database.Port < 1 || c.Database.Port > 65535 {
+		errs = append(errs, fmt.Errorf("database.port must be between 1 and 65535, got %d", c.Database.Port))
+	}
+	if c.Database.User == "" {
+		errs = append(errs, errors.New("database.user is required"))
+	}
+	if c.Database.Name == "" {
+		errs = append(errs, errors.New("database.name is required"))
+	}
+
+	if c.Auth.JWTSecret == "" {
+		errs = append(errs, errors.New("auth.jwt_secret is required"))
+	}
+	if c.Env == EnvProd && (c.Auth.JWTSecret == defaultJWTSecret || len(c.Auth.JWTSecret) < 32) {
+		errs = append(errs, errors.New("auth.jwt_secret must be set to a random value of at least 32 characters in prod"))
+	}
+	if c.Auth.TokenTTL <= 0 {
+		errs = append(errs, errors.New("auth.token_ttl must be positive"))
+	}
+
+	for _, proxy := range c.HTTP.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			errs = append(errs, fmt.Errorf("http.trusted_proxies: %q is neither an IP nor a CIDR", proxy))
+		}
+	}
+
+	groups := make([]string, 0, len(c.RateLimit.Groups))
+	for name := range c.RateLimit.Groups {
+		groups = append(groups, name)
+	}
+	sort.Strings(groups)
+	for _, name := range groups {
+		if rule := c.RateLimit.Groups[name]; rule.RequestsPerSecond <= 0 || rule.Burst < 1 {
+			errs = append(errs, fmt.Errorf("rate_limit.groups.%s: requests_per_second must be positive and burst at least 1", name))
+		}
+	}
+
+	if c.Health.CheckTimeout <= 0 {
+		errs = append(errs, errors.New("health.check_timeout must be positive"))
+	}
+	if c.Health.ShutdownDelay < 0 {
+		errs = append(errs, errors.New("health.shutdown_delay must not be negative"))
+	}
+
+	if c.Cache.Enabled {
+		if c.Cache.Size < 1 {
+			errs = append(errs, errors.New("cache.size must be at least 1"))
+		}
+		if c.Cache.TTL <= 0 {
+			errs = append(errs, errors.New("cache.ttl must be positive"))
+		}
+	}
+
+	if c.Trash.Retention <= 0 {
+		errs = append(errs, errors.New("trash.retention must be positive"))
+	}
+	if c.Trash.PurgeInterval <= 0 {
+		errs = append(errs, errors.New("trash.purge_interval must be positive"))
+	}
+
+	if c.Storage.Dir == "" {
+		errs = append(errs, errors.New("storage.dir is required"))
+	}
+	if c.Storage.MaxImageSize < 1 {
+		errs = append(errs, errors.New("storage.max_image_size must be positive"))
+	}
+	widths := make(map[int]bool, len(c.Storage.ThumbnailWidths))
+	for _, width := range c.Storage.ThumbnailWidths {
+		if width < 1 || width > MaxThumbnailWidth || widths[width] {
+			errs = append(errs, fmt.Errorf("storage.thumbnail_widths must contain distinct widths from 1 to %d, got %d", MaxThumbnailWidth, width))
+		}
+		widths[width] = true
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Log.Level)); err != nil {
+		errs = append(errs, fmt.Errorf("log.level must be one of debug, info, warn, error, got %q", c.Log.Level))
+	}
+
+	return errors.Join(errs...)
+}
+
+// DSN строка подключения для lib/pq, спецсимволы в пароле экранируются
+func (d Database) DSN() string {
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(d.User, d.Password),
+		Host:     net.JoinHostPort(d.Host, strconv.Itoa(d.Port)),
+		Path:     d.Name,
+		RawQuery: url.Values{"sslmode": {d.SSLMode}}.Encode(),
+	}
+	return dsn.String()
+}
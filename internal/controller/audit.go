@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"cinema/internal/models"
+	"cinema/internal/utils"
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type serviceAudit interface {
+	GetAuditLog(ctx context.Context, filter models.AuditFilter, limit, offset int) ([]models.AuditEntry, error)
+}
+
+type Audit struct {
+	audit serviceAudit
+	log   *slog.Logger
+}
+
+func NewAudit(audit serviceAudit, log *slog.Logger) *Audit {
+	return &Audit{audit: audit, log: log}
+}
+
+func parseAuditFilter(ctx *gin.Context) (models.AuditFilter, []models.ValidationError) {
+	var errs []models.ValidationError
+	filter := models.AuditFilter{
+		Subject:    ctx.Query("subject"),
+		Action:     ctx.Query("action"),
+		EntityType: ctx.Query("entity_type"),
+	}
+
+	if raw := ctx.Query("entity_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			errs = append(errs, models.ValidationError{Field: "entity_id", Message: "Must be a UUID"})
+		} else {
+			filter.EntityID = &id
+		}
+	}
+
+	parseTime := func(field string) *time.Time {
+		raw := ctx.Query(field)
+		if raw == "" {
+			return nil
+		}
+		value, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			errs = append(errs, models.ValidationError{Field: field, Message: "Must be a timestamp in RFC 3339 format"})
+			return nil
+		}
+		return &value
+	}
+	filter.From = parseTime("from")
+	filter.To = parseTime("to")
+
+	errs = append(errs, filter.Validate()...)
+	return filter, errs
+}
+
+// List godoc
+// @Summary      Browse the audit log
+// @Description  Changes made through the admin endpoints, newest first. Each entry records who made the change,
+// @Description  the entity state before and after it, and the request ID. Entries are written in the same transaction as the change.
+// @Tags         Audit
+// @Produce      json
+// @Param        subject      query   string  false "JWT subject of the user who made the change"
+// @Param        action       query   string  false "Action" Enums(create, update, delete, restore)
+// @Param        entity_type  query   string  false "Entity type; movie_credits entries use the movie ID" Enums(movie, movie_credits, actor, genre)
+// @Param        entity_id    query   string  false "Entity ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+// @Param        from         query   string  false "Changes made at or after this time (RFC 3339)" example("2024-01-01T00:00:00Z")
+// @Param        to           query   string  false "Changes made before this time (RFC 3339)" example("2024-02-01T00:00:00Z")
+// @Param        limit        query   int     true  "Number of entries to return" maximum(100)
+// @Param        offset       query   int     true  "Number of entries to skip"
+// @Success      200  {array}   models.AuditEntry  "Audit log entries"
+// @Failure      400  {object}  models.APIError  "Invalid filter, limit or offset"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/audit [get]
+func (c *Audit) List(ctx *gin.Context) {
+	filter, validationErrors := parseAuditFilter(ctx)
+	if len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(ctx, validationErrors)
+		return
+	}
+
+	limit, offset, ok := parseOffsetPage(ctx)
+	if !ok {
+		return
+	}
+
+	entries, err := c.audit.GetAuditLog(ctx.Request.Context(), filter, limit, offset)
+	if err != nil {
+		serverErrorResponse(ctx, c.log, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, entries)
+}
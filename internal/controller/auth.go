@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"cinema/internal/service"
+	"cinema/internal/utils"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type serviceAuth interface {
+	Login(ctx context.Context, credentials models.LoginRequest) (*models.LoginResponse, error)
+}
+
+type Auth struct {
+	auth serviceAuth
+	log  *slog.Logger
+}
+
+func NewAuth(auth serviceAuth, log *slog.Logger) *Auth {
+	return &Auth{auth: auth, log: log}
+}
+
+// Login godoc
+// @Summary      Log in
+// @Description  Checks the username and password and issues a signed JWT access token
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        credentials  body      models.LoginRequest   true  "User credentials"
+// @Success      200          {object}  models.LoginResponse  "Access token"
+// @Failure      400          {object}  models.APIError       "Invalid JSON format or validation errors"
+// @Failure      401          {object}  models.APIError       "Invalid username or password"
+// @Failure      429          {object}  models.APIError       "Rate limit exceeded"
+// @Failure      500          {object}  models.APIError       "Internal server error"
+// @Failure      504          {object}  models.APIError       "Request timed out"
+// @Router       /api/auth/login [post]
+func (c *Auth) Login(ctx *gin.Context) {
+	var credentials models.LoginRequest
+	if err := ctx.ShouldBindJSON(&credentials); err != nil {
+		utils.InvalidJSONResponse(ctx)
+		return
+	}
+
+	validationErrors := credentials.Validate()
+	if len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(ctx, validationErrors)
+		return
+	}
+
+	token, err := c.auth.Login(ctx.Request.Context(), credentials)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCredentials) {
+			c.log.WarnContext(ctx.Request.Context(), "Failed login attempt", "username", credentials.Username, "client_ip", ctx.ClientIP())
+			utils.UnauthorizedResponse(ctx, err.Error())
+			return
+		}
+		if requestTimedOut(ctx, err) {
+			c.log.WarnContext(ctx.Request.Context(), "Request timed out", "route", ctx.FullPath(), logger.Err(err))
+			utils.GatewayTimeoutResponse(ctx, "Request timed out")
+			return
+		}
+		c.log.ErrorContext(ctx.Request.Context(), "Request failed", "route", ctx.FullPath(), logger.Err(err))
+		utils.InternalServerErrorResponse(ctx, "Failed to log in")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, token)
+}
@@ -2,12 +2,18 @@ package controller
 
 import (
 	_ "cinema/docs"
+	"cinema/internal/logger"
 	"cinema/internal/models"
+	"cinema/internal/pagination"
 	"cinema/internal/utils"
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -15,35 +21,49 @@ import (
 
 type serviceMovie interface {
 	// связи
-	AddMovieActorRelations(movieID uuid.UUID, actorIDs []uuid.UUID) error
-	RemoveSelectedMovieActorRelations(movieID uuid.UUID, actorIDs []uuid.UUID) error
-	UpdateMovieActorRelations(movieID uuid.UUID, actorIDs []uuid.UUID) error
+	AddMovieActorRelations(ctx context.Context, movieID uuid.UUID, credits []models.CreditInput) error
+	RemoveSelectedMovieActorRelations(ctx context.Context, movieID uuid.UUID, actorIDs []uuid.UUID) error
+	UpdateMovieActorRelations(ctx context.Context, movieID uuid.UUID, credits []models.CreditInput) error
+	GetMovieCredits(ctx context.Context, movieID uuid.UUID) (*models.MovieCredits, error)
 	// фильмы
-	CreateMovie(movie models.CreateMovie) (uuid.UUID, error)
-	GetMovieByID(id uuid.UUID) (*models.Movie, error)
-	GetMoviesByActorID(actorID uuid.UUID, limit, offset int) ([]*models.Movie, error)
-	GetMoviesWithFilters(sortBy string, order string, limit, offset int) ([]*models.Movie, error)
-	SearchMoviesByTitleAndActor(filterTitle, filterActor string, limit, offset int) ([]*models.Movie, error)
-	UpdateMovie(id uuid.UUID, movie models.UpdateMovie) error
-	DeleteMovie(id uuid.UUID) error
+	CreateMovie(ctx context.Context, movie models.CreateMovie) (uuid.UUID, error)
+	CreateMovies(ctx context.Context, movies []models.CreateMovie, bestEffort bool) (*models.BatchResult, error)
+	GetMovieByID(ctx context.Context, id uuid.UUID) (*models.Movie, error)
+	GetMoviesByActorID(ctx context.Context, actorID uuid.UUID, page models.PageRequest) (*models.Page[models.Movie], error)
+	GetMoviesWithFilters(ctx context.Context, filter models.MovieFilter, sortBy string, order string, page models.PageRequest) (*models.Page[models.Movie], error)
+	SearchMovies(ctx context.Context, query string, limit, offset int) ([]models.MovieSearchResult, error)
+	UpdateMovie(ctx context.Context, id uuid.UUID, movie models.UpdateMovie, version *int64) error
+	PatchMovie(ctx context.Context, id uuid.UUID, patch []byte, version *int64) (*models.Movie, error)
+	DeleteMovie(ctx context.Context, id uuid.UUID, version *int64) error
 }
 
 type serviceActor interface {
-	CreateActor(actor models.CreateActor) (uuid.UUID, error)
-	GetActor(id uuid.UUID) (*models.Actor, error)
-	GetAllActors(limit, offset int) ([]*models.Actor, error)
-	GetActorsWithMovies(limit, offset int) ([]*models.ActorWithMovies, error)
-	UpdateActor(id uuid.UUID, actor models.UpdateActor) error
-	DeleteActor(id uuid.UUID) error
+	CreateActor(ctx context.Context, actor models.CreateActor) (uuid.UUID, error)
+	GetActor(ctx context.Context, id uuid.UUID) (*models.Actor, error)
+	GetAllActors(ctx context.Context, page models.PageRequest) (*models.ActorPage, error)
+	GetActorsWithMovies(ctx context.Context, page models.PageRequest) (*models.ActorWithMoviesPage, error)
+	UpdateActor(ctx context.Context, id uuid.UUID, actor models.UpdateActor, version *int64) error
+	PatchActor(ctx context.Context, id uuid.UUID, patch []byte, version *int64) (*models.Actor, error)
+	DeleteActor(ctx context.Context, id uuid.UUID, version *int64) error
+}
+
+type serviceGenre interface {
+	CreateGenre(ctx context.Context, genre models.CreateGenre) (uuid.UUID, error)
+	GetGenre(ctx context.Context, id uuid.UUID) (*models.Genre, error)
+	GetAllGenres(ctx context.Context) ([]models.Genre, error)
+	UpdateGenre(ctx context.Context, id uuid.UUID, genre models.UpdateGenre) error
+	DeleteGenre(ctx context.Context, id uuid.UUID) error
 }
 
 type Cinema struct {
 	movie serviceMovie
 	actor serviceActor
+	genre serviceGenre
+	log   *slog.Logger
 }
 
-func NewCinema(movie serviceMovie, actor serviceActor) *Cinema {
-	return &Cinema{movie: movie, actor: actor}
+func NewCinema(movie serviceMovie, actor serviceActor, genre serviceGenre, log *slog.Logger) *Cinema {
+	return &Cinema{movie: movie, actor: actor, genre: genre, log: log}
 }
 
 func parseLimitOffset(ctx *gin.Context) (int, int, error) {
@@ -53,22 +73,87 @@ func parseLimitOffset(ctx *gin.Context) (int, int, error) {
 	}
 	offset, err := strconv.Atoi(ctx.Query("offset"))
 	if err != nil || offset < 0 {
-		return 0, 0, fmt.Errorf("invalid or missing limit: %s", ctx.Query("limit"))
+		return 0, 0, fmt.Errorf("invalid or missing offset: %s", ctx.Query("offset"))
 	}
 	return limit, offset, nil
 }
 
+const (
+	defaultPageLimit = 10
+	maxPageLimit     = 100
+)
+
+// Параметры курсорной пагинации: limit (по умолчанию 10, не больше 100), cursor и include_total
+func parsePageRequest(ctx *gin.Context) (models.PageRequest, error) {
+	page := models.PageRequest{
+		Limit:  defaultPageLimit,
+		Cursor: ctx.Query("cursor"),
+	}
+
+	if rawLimit := ctx.Query("limit"); rawLimit != "" {
+		limit, err := strconv.Atoi(rawLimit)
+		if err != nil || limit <= 0 || limit > maxPageLimit {
+			return page, fmt.Errorf("invalid limit: %s, must be between 1 and %d", rawLimit, maxPageLimit)
+		}
+		page.Limit = limit
+	}
+
+	if rawTotal := ctx.Query("include_total"); rawTotal != "" {
+		includeTotal, err := strconv.ParseBool(rawTotal)
+		if err != nil {
+			return page, fmt.Errorf("invalid include_total: %s", rawTotal)
+		}
+		page.IncludeTotal = includeTotal
+	}
+
+	return page, nil
+}
+
+// Ответ на ошибку получения страницы: неверный курсор - 400, остальное - как serverErrorResponse
+func (c *Cinema) pageErrorResponse(ctx *gin.Context, err error) {
+	if errors.Is(err, pagination.ErrInvalidCursor) {
+		utils.BadRequestResponse(ctx, err.Error())
+		return
+	}
+	c.serverErrorResponse(ctx, err)
+}
+
+func (c *Cinema) serverErrorResponse(ctx *gin.Context, err error) {
+	serverErrorResponse(ctx, c.log, err)
+}
+
+// Ответ на ошибку сервиса: истекший таймаут запроса - 504, остальное - 500.
+// Ошибка пишется в лог вместе с маршрутом, request_id добавляется из контекста запроса.
+func serverErrorResponse(ctx *gin.Context, log *slog.Logger, err error) {
+	if requestTimedOut(ctx, err) {
+		log.WarnContext(ctx.Request.Context(), "Request timed out", "route", ctx.FullPath(), logger.Err(err))
+		utils.GatewayTimeoutResponse(ctx, "Request timed out")
+		return
+	}
+	log.ErrorContext(ctx.Request.Context(), "Request failed", "route", ctx.FullPath(), logger.Err(err))
+	utils.InternalServerErrorResponse(ctx, err.Error())
+}
+
+// Драйвер БД при отмене запроса может вернуть собственную ошибку вместо
+// context.DeadlineExceeded, поэтому проверяется и контекст самого запроса.
+func requestTimedOut(ctx *gin.Context, err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Request.Context().Err(), context.DeadlineExceeded)
+}
+
 // AddMovieActorRelations godoc
-// @Summary      Add actors to a movie
-// @Description  Add a list of actors to a movie by movie ID
+// @Summary      Add credits to a movie
+// @Description  Add cast and crew credits to a movie by movie ID. Each item is either a credit object or, as before, a plain actor ID meaning the actor role. Existing credits with the same person and role get their character and order updated.
 // @Tags         movie-actors
 // @Accept       json
 // @Produce      json
 // @Param        movie_id  path     string  true  "ID of the movie"
-// @Param        actor_ids body     []uuid.UUID  true  "List of actor IDs to be added to the movie"
+// @Param        credits   body     []models.CreditInput  true  "Credits to be added to the movie"
 // @Success      204       {string}  string  "No Content"
-// @Failure      400       {object}  models.APIError "Invalid request body"
+// @Failure      400       {object}  models.APIError "Invalid request body or validation errors"
+// @Failure      404       {object}  models.APIError "Movie not found"
+// @Failure      429       {object}  models.APIError "Rate limit exceeded"
 // @Failure      500       {object}  models.APIError "Internal Server Error"
+// @Failure      504       {object}  models.APIError "Request timed out"
 // @Router       /api/movies/{movie_id}/actors [post]
 func (c *Cinema) AddMovieActorRelations(ctx *gin.Context) {
 	movieIDStr := ctx.Param("movie_id") // Получаем movie_id из параметров пути
@@ -79,14 +164,20 @@ func (c *Cinema) AddMovieActorRelations(ctx *gin.Context) {
 		return
 	}
 
-	var actorIDs []uuid.UUID
-	if err := ctx.ShouldBindJSON(&actorIDs); err != nil {
+	var credits []models.CreditInput
+	if err := ctx.ShouldBindJSON(&credits); err != nil {
 		utils.InvalidJSONResponse(ctx)
 		return
 	}
 
-	if err := c.movie.AddMovieActorRelations(movieID, actorIDs); err != nil {
-		utils.InternalServerErrorResponse(ctx, err.Error())
+	validationErrors := models.ValidateCredits(credits)
+	if len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(ctx, validationErrors)
+		return
+	}
+
+	if err := c.movie.AddMovieActorRelations(ctx.Request.Context(), movieID, credits); err != nil {
+		c.versionErrorResponse(ctx, err, "Movie not found")
 		return
 	}
 
@@ -94,16 +185,19 @@ func (c *Cinema) AddMovieActorRelations(ctx *gin.Context) {
 }
 
 // UpdateMovieActorRelations godoc
-// @Summary      Update actors for a movie
-// @Description  Update the list of actors for a movie by movie ID
+// @Summary      Replace credits of a movie
+// @Description  Replace all cast and crew credits of a movie by movie ID. Each item is either a credit object or a plain actor ID meaning the actor role.
 // @Tags         movie-actors
 // @Accept       json
 // @Produce      json
 // @Param        movie_id  path     string  true  "ID of the movie"
-// @Param        actor_ids body     []uuid.UUID  true  "List of actor IDs to be updated for the movie"
+// @Param        credits   body     []models.CreditInput  true  "New credits of the movie"
 // @Success      204       {string}  string  "No Content"
-// @Failure      400       {object}  models.APIError "Invalid request body"
+// @Failure      400       {object}  models.APIError "Invalid request body or validation errors"
+// @Failure      404       {object}  models.APIError "Movie not found"
+// @Failure      429       {object}  models.APIError "Rate limit exceeded"
 // @Failure      500       {object}  models.APIError "Internal Server Error"
+// @Failure      504       {object}  models.APIError "Request timed out"
 // @Router       /api/movies/{movie_id}/actors [put]
 func (c *Cinema) UpdateMovieActorRelations(ctx *gin.Context) {
 	movieIDStr := ctx.Param("movie_id") // Получаем movie_id из параметров пути
@@ -114,14 +208,20 @@ func (c *Cinema) UpdateMovieActorRelations(ctx *gin.Context) {
 		return
 	}
 
-	var actorIDs []uuid.UUID
-	if err := ctx.ShouldBindJSON(&actorIDs); err != nil {
+	var credits []models.CreditInput
+	if err := ctx.ShouldBindJSON(&credits); err != nil {
 		utils.InvalidJSONResponse(ctx)
 		return
 	}
 
-	if err := c.movie.UpdateMovieActorRelations(movieID, actorIDs); err != nil {
-		utils.InternalServerErrorResponse(ctx, err.Error())
+	validationErrors := models.ValidateCredits(credits)
+	if len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(ctx, validationErrors)
+		return
+	}
+
+	if err := c.movie.UpdateMovieActorRelations(ctx.Request.Context(), movieID, credits); err != nil {
+		c.versionErrorResponse(ctx, err, "Movie not found")
 		return
 	}
 
@@ -130,7 +230,7 @@ func (c *Cinema) UpdateMovieActorRelations(ctx *gin.Context) {
 
 // RemoveSelectedMovieActorRelations godoc
 // @Summary      Remove actors from a movie
-// @Description  Remove a list of actors from a movie by movie ID
+// @Description  Remove a list of people from a movie by movie ID, together with all their credits in it
 // @Tags         movie-actors
 // @Accept       json
 // @Produce      json
@@ -138,7 +238,10 @@ func (c *Cinema) UpdateMovieActorRelations(ctx *gin.Context) {
 // @Param        actor_ids body     []uuid.UUID  true  "List of actor IDs to be removed from the movie"
 // @Success      204       {string}  string  "No Content"
 // @Failure      400       {object}  models.APIError "Invalid request body"
+// @Failure      404       {object}  models.APIError "Movie not found"
+// @Failure      429       {object}  models.APIError "Rate limit exceeded"
 // @Failure      500       {object}  models.APIError "Internal Server Error"
+// @Failure      504       {object}  models.APIError "Request timed out"
 // @Router       /api/movies/{movie_id}/actors [delete]
 func (c *Cinema) RemoveSelectedMovieActorRelations(ctx *gin.Context) {
 	movieIDStr := ctx.Param("movie_id") // Получаем movie_id из параметров пути
@@ -155,8 +258,8 @@ func (c *Cinema) RemoveSelectedMovieActorRelations(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.movie.RemoveSelectedMovieActorRelations(movieID, actorIDs); err != nil {
-		utils.InternalServerErrorResponse(ctx, err.Error())
+	if err := c.movie.RemoveSelectedMovieActorRelations(ctx.Request.Context(), movieID, actorIDs); err != nil {
+		c.versionErrorResponse(ctx, err, "Movie not found")
 		return
 	}
 
@@ -172,7 +275,9 @@ func (c *Cinema) RemoveSelectedMovieActorRelations(ctx *gin.Context) {
 // @Param        movie  body      models.CreateMovie  true  "Movie details"
 // @Success      201    {object}  map[string]string       "Movie created successfully"  example={"id": "1234"}
 // @Failure      400    {object}  models.APIError  "Invalid JSON format or validation errors"
+// @Failure      429    {object}  models.APIError  "Rate limit exceeded"
 // @Failure      500    {object}  models.APIError  "Internal server error"
+// @Failure      504    {object}  models.APIError  "Request timed out"
 // @Router       /api/movies [post]
 func (c *Cinema) CreateMovie(ctx *gin.Context) {
 	var newMovie models.CreateMovie
@@ -188,15 +293,66 @@ func (c *Cinema) CreateMovie(ctx *gin.Context) {
 		return
 	}
 
-	id, err := c.movie.CreateMovie(newMovie)
+	id, err := c.movie.CreateMovie(ctx.Request.Context(), newMovie)
 	if err != nil {
-		utils.InternalServerErrorResponse(ctx, err.Error())
+		c.serverErrorResponse(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusCreated, gin.H{"id": id})
 }
 
+// CreateMovies godoc
+// @Summary      Create movies in a batch
+// @Description  Adds up to 100 movies in a single transaction. Every movie is validated like POST /api/movies,
+// @Description  and its actors and genres must exist. Results are returned per index in request order.
+// @Description  By default nothing is created if any movie has errors; with best_effort the valid movies are created anyway.
+// @Tags         Movies
+// @Accept       json
+// @Produce      json
+// @Param        movies       body   []models.CreateMovie  true   "Movies to create"
+// @Param        best_effort  query  bool                  false  "Create the valid movies even if some have errors"
+// @Success      201  {object}  models.BatchResult  "At least one movie was created"
+// @Failure      400  {object}  models.APIError     "Invalid JSON format, empty batch or more than 100 movies"
+// @Failure      422  {object}  models.BatchResult  "No movies were created because of validation errors"
+// @Failure      429  {object}  models.APIError     "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError     "Internal server error"
+// @Failure      504  {object}  models.APIError     "Request timed out"
+// @Router       /api/movies/batch [post]
+func (c *Cinema) CreateMovies(ctx *gin.Context) {
+	var bestEffort bool
+	if raw := ctx.Query("best_effort"); raw != "" {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			utils.BadRequestResponse(ctx, "best_effort must be true or false")
+			return
+		}
+		bestEffort = value
+	}
+
+	var movies []models.CreateMovie
+	if err := ctx.ShouldBindJSON(&movies); err != nil {
+		utils.InvalidJSONResponse(ctx)
+		return
+	}
+	if len(movies) == 0 || len(movies) > models.MaxBatchSize {
+		utils.BadRequestResponse(ctx, fmt.Sprintf("Batch must contain from 1 to %d movies", models.MaxBatchSize))
+		return
+	}
+
+	result, err := c.movie.CreateMovies(ctx.Request.Context(), movies, bestEffort)
+	if err != nil {
+		c.serverErrorResponse(ctx, err)
+		return
+	}
+
+	if result.Created == 0 {
+		ctx.JSON(http.StatusUnprocessableEntity, result)
+		return
+	}
+	ctx.JSON(http.StatusCreated, result)
+}
+
 // GetMovieByID godoc
 // @Summary      Get movie by ID
 // @Description  Retrieves a movie by its unique identifier
@@ -204,14 +360,19 @@ func (c *Cinema) CreateMovie(ctx *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        movie_id   path     string  true  "Movie ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+// @Param        If-None-Match  header  string  false  "ETag from a previous response"
 // @Success      200  {object}  models.Movie  "The movie details"
+// @Header       200  {string}  ETag  "Version of the movie"
+// @Success      304  "Not modified since the given ETag"
 // @Failure      400  {object}  models.APIError  "Invalid movie ID format"
 // @Failure      404  {object}  models.APIError  "Movie not found"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
 // @Failure      500  {object}  models.APIError  "Internal server error"
-// @Router       /api/movies/{id} [get]
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/movies/{movie_id} [get]
 func (c *Cinema) GetMovieByID(ctx *gin.Context) {
 	// Получаем параметр id из URL
-	movieIDStr := ctx.Param("id")
+	movieIDStr := ctx.Param("movie_id")
 	movieID, err := uuid.Parse(movieIDStr)
 	if err != nil {
 		utils.BadRequestResponse(ctx, "Invalid movie ID format")
@@ -219,9 +380,9 @@ func (c *Cinema) GetMovieByID(ctx *gin.Context) {
 	}
 
 	// Получаем фильм по ID
-	movie, err := c.movie.GetMovieByID(movieID)
+	movie, err := c.movie.GetMovieByID(ctx.Request.Context(), movieID)
 	if err != nil {
-		utils.InternalServerErrorResponse(ctx, err.Error())
+		c.serverErrorResponse(ctx, err)
 		return
 	}
 
@@ -230,25 +391,65 @@ func (c *Cinema) GetMovieByID(ctx *gin.Context) {
 		return
 	}
 
+	if notModified(ctx, movie.Version) {
+		return
+	}
+
 	// Если фильм найден, возвращаем его с кодом 200
 	ctx.JSON(http.StatusOK, movie)
 }
 
+// GetMovieCredits godoc
+// @Summary      Get movie credits
+// @Description  Retrieves the cast and crew of a movie. Both lists are ordered by billing order, then by name.
+// @Tags         Movies
+// @Produce      json
+// @Param        movie_id   path     string  true  "Movie ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+// @Success      200  {object}  models.MovieCredits  "Cast and crew"
+// @Failure      400  {object}  models.APIError  "Invalid movie ID format"
+// @Failure      404  {object}  models.APIError  "Movie not found"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/movies/{movie_id}/credits [get]
+func (c *Cinema) GetMovieCredits(ctx *gin.Context) {
+	movieID, err := uuid.Parse(ctx.Param("movie_id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "Invalid movie ID format")
+		return
+	}
+
+	credits, err := c.movie.GetMovieCredits(ctx.Request.Context(), movieID)
+	if err != nil {
+		c.serverErrorResponse(ctx, err)
+		return
+	}
+	if credits == nil {
+		utils.NotFoundResponse(ctx, "Movie not found")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, credits)
+}
+
 // GetMoviesByActorID godoc
 // @Summary      Get movies by actor ID
-// @Description  Retrieve a list of movies by the actor's ID with optional pagination
+// @Description  Retrieve a page of the actor's movies, newest first, with cursor pagination
 // @Tags         Movies
 // @Accept       json
 // @Produce      json
-// @Param        actor_id  path    string  true  "Actor ID"  Format: uuid
-// @Param        limit     query   int     false "Limit the number of movies returned"
-// @Param        offset    query   int     false "Offset for pagination"
-// @Success      200       {array}  models.Movie  "List of movies"
+// @Param        actor_id       path    string  true  "Actor ID"  Format: uuid
+// @Param        limit          query   int     false "Limit the number of movies returned" default(10) maximum(100)
+// @Param        cursor         query   string  false "Cursor from next_cursor of the previous page"
+// @Param        include_total  query   bool    false "Include the total number of movies" default(false)
+// @Success      200       {object}  models.MoviePage  "Page of movies"
 // @Failure      400       {object}  models.APIError "Invalid actor ID format or bad request"
+// @Failure      429       {object}  models.APIError "Rate limit exceeded"
 // @Failure      500       {object}  models.APIError "Internal Server Error"
+// @Failure      504       {object}  models.APIError "Request timed out"
 // @Router       /api/actors/{actor_id}/movies [get]
 func (c *Cinema) GetMoviesByActorID(ctx *gin.Context) {
-	actorIDStr := ctx.Param("id")
+	actorIDStr := ctx.Param("actor_id")
 	actorID, err := uuid.Parse(actorIDStr)
 	if err != nil {
 		// Если ID актера некорректен, возвращаем ошибку 400
@@ -256,39 +457,143 @@ func (c *Cinema) GetMoviesByActorID(ctx *gin.Context) {
 		return
 	}
 
-	limit, offset, err := parseLimitOffset(ctx)
+	page, err := parsePageRequest(ctx)
 	if err != nil {
 		utils.BadRequestResponse(ctx, err.Error())
 		return
 	}
 
-	movies, err := c.movie.GetMoviesByActorID(actorID, limit, offset)
+	movies, err := c.movie.GetMoviesByActorID(ctx.Request.Context(), actorID, page)
 	if err != nil {
-		utils.InternalServerErrorResponse(ctx, err.Error())
+		c.pageErrorResponse(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, movies)
 }
 
+// Разбор фильтров списка фильмов из query-параметров. Ошибки формата возвращаются
+// вместе с ошибками валидации, чтобы клиент увидел все проблемы сразу.
+func parseMovieFilter(ctx *gin.Context) (models.MovieFilter, []models.ValidationError) {
+	var errs []models.ValidationError
+	filter := models.MovieFilter{
+		ActorMatch:  strings.ToLower(ctx.DefaultQuery("actor_match", models.ActorMatchAny)),
+		TitlePrefix: ctx.Query("title_prefix"),
+	}
+
+	parseFloat := func(field string) *float64 {
+		raw := ctx.Query(field)
+		if raw == "" {
+			return nil
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			errs = append(errs, models.ValidationError{Field: field, Message: "Must be a number"})
+			return nil
+		}
+		return &value
+	}
+	parseInt := func(field string) *int {
+		raw := ctx.Query(field)
+		if raw == "" {
+			return nil
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			errs = append(errs, models.ValidationError{Field: field, Message: "Must be an integer"})
+			return nil
+		}
+		return &value
+	}
+	parseDate := func(field string) *time.Time {
+		raw := ctx.Query(field)
+		if raw == "" {
+			return nil
+		}
+		value, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			errs = append(errs, models.ValidationError{Field: field, Message: "Must be a date in YYYY-MM-DD format"})
+			return nil
+		}
+		return &value
+	}
+
+	filter.MinRating = parseFloat("min_rating")
+	filter.MaxRating = parseFloat("max_rating")
+	filter.ReleasedFrom = parseDate("release_from")
+	filter.ReleasedTo = parseDate("release_to")
+	filter.YearFrom = parseInt("year_from")
+	filter.YearTo = parseInt("year_to")
+
+	// Списки ID принимаются и через запятую, и повторяющимся параметром
+	parseIDs := func(field, entity string) []uuid.UUID {
+		var ids []uuid.UUID
+		for _, rawIDs := range ctx.QueryArray(field) {
+			for _, rawID := range strings.Split(rawIDs, ",") {
+				if rawID = strings.TrimSpace(rawID); rawID == "" {
+					continue
+				}
+				id, err := uuid.Parse(rawID)
+				if err != nil {
+					errs = append(errs, models.ValidationError{Field: field, Message: fmt.Sprintf("Invalid %s ID: %s", entity, rawID)})
+					continue
+				}
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}
+	filter.ActorIDs = parseIDs("actor_ids", "actor")
+	filter.GenreIDs = parseIDs("genre_ids", "genre")
+
+	if raw := ctx.Query("has_description"); raw != "" {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			errs = append(errs, models.ValidationError{Field: "has_description", Message: "Must be true or false"})
+		} else {
+			filter.HasDescription = &value
+		}
+	}
+
+	errs = append(errs, filter.Validate()...)
+	return filter, errs
+}
+
 // GetMoviesWithFilters godoc
 // @Summary      Get movies with filters
-// @Description  Retrieve a list of movies with optional filters for sorting and pagination.
+// @Description  Retrieve a page of movies matching the filters, with sorting and cursor pagination.
 // @Tags         Movies
 // @Accept       json
 // @Produce      json
-// @Param        sortBy  query   string  false "Field to sort by" Enums(title, release_date, rating) default(rating)
-// @Param        order   query   string  false "Sorting order" Enums(ASC, DESC) default(DESC)
-// @Param        limit   query   int     false "Limit the number of movies returned" default(10)
-// @Param        offset  query   int     false "Offset for pagination" default(0)
-// @Success      200     {array} models.Movie   "List of filtered movies"
+// @Param        sortBy           query   string  false "Field to sort by" Enums(title, release_date, rating) default(rating)
+// @Param        order            query   string  false "Sorting order" Enums(ASC, DESC) default(DESC)
+// @Param        min_rating       query   number  false "Minimum rating, inclusive" minimum(0) maximum(10)
+// @Param        max_rating       query   number  false "Maximum rating, inclusive" minimum(0) maximum(10)
+// @Param        release_from     query   string  false "Released on or after this date (YYYY-MM-DD)" example("2000-01-01")
+// @Param        release_to       query   string  false "Released on or before this date (YYYY-MM-DD)" example("2010-12-31")
+// @Param        year_from        query   int     false "Released in or after this year" example(2000)
+// @Param        year_to          query   int     false "Released in or before this year" example(2010)
+// @Param        actor_ids        query   []string false "Actor IDs, comma separated or repeated" collectionFormat(csv)
+// @Param        actor_match      query   string  false "Whether a movie must feature any or all of actor_ids" Enums(any, all) default(any)
+// @Param        genre_ids        query   []string false "Genre IDs, comma separated or repeated; a movie matches if it has any of them" collectionFormat(csv)
+// @Param        title_prefix     query   string  false "Case-insensitive title prefix" example("The ")
+// @Param        has_description  query   bool    false "Only movies with (true) or without (false) a description"
+// @Param        limit            query   int     false "Limit the number of movies returned" default(10) maximum(100)
+// @Param        cursor           query   string  false "Cursor from next_cursor of the previous page, valid only with the same sortBy and order"
+// @Param        include_total    query   bool    false "Include the total number of matching movies" default(false)
+// @Success      200     {object} models.MoviePage   "Page of filtered movies"
 // @Failure      400     {object} models.APIError "Invalid request parameters"
+// @Failure      429     {object} models.APIError "Rate limit exceeded"
 // @Failure      500     {object} models.APIError "Internal server error"
+// @Failure      504     {object} models.APIError "Request timed out"
 // @Router       /api/movies [get]
 func (c *Cinema) GetMoviesWithFilters(ctx *gin.Context) {
-	sortBy := ctx.DefaultQuery("sortBy", "rating")
-	order := ctx.DefaultQuery("order", "DESC")
-	// Валидация
+	sortBy := strings.ToLower(ctx.DefaultQuery("sortBy", "rating"))
+	order := strings.ToUpper(ctx.DefaultQuery("order", "DESC"))
+
+	filter, validationErrors := parseMovieFilter(ctx)
+
+	// Валидация сортировки
 	validSortColumns := map[string]struct{}{
 		"title":        {},
 		"release_date": {},
@@ -299,62 +604,75 @@ func (c *Cinema) GetMoviesWithFilters(ctx *gin.Context) {
 		"DESC": {},
 	}
 	if _, ok := validSortColumns[sortBy]; !ok {
-		sortBy = "rating"
+		validationErrors = append(validationErrors, models.ValidationError{
+			Field:   "sortBy",
+			Message: "Sort field must be 'title', 'release_date' or 'rating'",
+		})
 	}
 	if _, ok := validOrder[order]; !ok {
-		order = "DESC"
+		validationErrors = append(validationErrors, models.ValidationError{
+			Field:   "order",
+			Message: "Order must be 'ASC' or 'DESC'",
+		})
+	}
+	if len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(ctx, validationErrors)
+		return
 	}
-	sortBy = strings.ToLower(ctx.DefaultQuery("sortBy", "rating"))
-	order = strings.ToUpper(ctx.DefaultQuery("order", "DESC"))
 
-	limit, offset, err := parseLimitOffset(ctx)
+	page, err := parsePageRequest(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.BadRequestResponse(ctx, err.Error())
 		return
 	}
 
-	movies, err := c.movie.GetMoviesWithFilters(sortBy, order, limit, offset)
+	movies, err := c.movie.GetMoviesWithFilters(ctx.Request.Context(), filter, sortBy, order, page)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.pageErrorResponse(ctx, err)
 		return
 	}
 
-	// Возвращаем список фильмов в формате JSON
+	// Возвращаем страницу фильмов в формате JSON
 	ctx.JSON(http.StatusOK, movies)
 }
 
-// SearchMoviesByTitleAndActor godoc
-// @Summary      Search movies by title and actor name
-// @Description  Search for movies by a partial title and actor's name with optional pagination.
+// SearchMovies godoc
+// @Summary      Full-text search for movies
+// @Description  Search movies by title, description and cast names. Russian and English words are matched by their stems,
+// @Description  results are ordered by relevance and contain highlighted fragments. Supports web search syntax: "quoted phrases", OR, -exclusion.
 // @Tags         Movies
 // @Accept       json
 // @Produce      json
-// @Param        title        query   string  false "Movie title fragment" example("Inception")
-// @Param        actor_name   query   string  false "Actor name fragment" example("Leonardo")
+// @Param        q            query   string  false "Search query" example("Inception DiCaprio")
+// @Param        title        query   string  false "Deprecated: appended to the search query" example("Inception")
+// @Param        actor_name   query   string  false "Deprecated: appended to the search query" example("Leonardo")
 // @Param        limit        query   int     false "Limit the number of movies returned" default(10)
 // @Param        offset       query   int     false "Offset for pagination" default(0)
-// @Success      200          {array} models.Movie "List of movies matching the search"
+// @Success      200          {array} models.MovieSearchResult "List of movies matching the search"
 // @Failure      400          {object} models.APIError "Invalid search parameters"
+// @Failure      429          {object} models.APIError "Rate limit exceeded"
 // @Failure      500          {object} models.APIError "Internal server error"
+// @Failure      504          {object} models.APIError "Request timed out"
 // @Router       /api/movies/search [get]
-func (c *Cinema) SearchMoviesByTitleAndActor(ctx *gin.Context) {
-	// Получаем фрагмент названия фильма из параметров запроса
-	titleFragment := ctx.DefaultQuery("title", "")
-	actorNameFragment := ctx.DefaultQuery("actor_name", "")
+func (c *Cinema) SearchMovies(ctx *gin.Context) {
+	// Старые параметры title и actor_name дополняют поисковый запрос
+	terms := []string{ctx.Query("q"), ctx.Query("title"), ctx.Query("actor_name")}
+	query := strings.TrimSpace(strings.Join(terms, " "))
+	if query == "" {
+		utils.BadRequestResponse(ctx, "Search query must not be empty")
+		return
+	}
 
 	// Получаем лимит и смещение из запроса
 	limit, offset, err := parseLimitOffset(ctx)
 	if err != nil {
-		// Если есть ошибка с лимитом или смещением, возвращаем ошибку
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.BadRequestResponse(ctx, err.Error())
 		return
 	}
 
-	// Получаем фильмы, которые соответствуют фрагменту названия
-	movies, err := c.movie.SearchMoviesByTitleAndActor(titleFragment, actorNameFragment, limit, offset)
+	movies, err := c.movie.SearchMovies(ctx.Request.Context(), query, limit, offset)
 	if err != nil {
-		// Если произошла ошибка при поиске фильмов, возвращаем ошибку
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.serverErrorResponse(ctx, err)
 		return
 	}
 
@@ -370,12 +688,17 @@ func (c *Cinema) SearchMoviesByTitleAndActor(ctx *gin.Context) {
 // @Produce      json
 // @Param        movie_id        path    string  true  "Movie ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
 // @Param        movie     body    models.UpdateMovie true "Updated movie details"
+// @Param        If-Match  header  string  false  "Update only if the movie still has this ETag"
 // @Success      204       "Movie successfully updated"
 // @Failure      400       {object} models.APIError "Invalid request body or parameters"
+// @Failure      404       {object} models.APIError "Movie not found"
+// @Failure      412       {object} models.APIError "Movie was modified since the given ETag or does not exist"
+// @Failure      429       {object} models.APIError "Rate limit exceeded"
 // @Failure      500       {object} models.APIError "Internal server error"
-// @Router       /api/movies/{id} [put]
+// @Failure      504       {object} models.APIError "Request timed out"
+// @Router       /api/movies/{movie_id} [put]
 func (c *Cinema) UpdateMovie(ctx *gin.Context) {
-	movieIDStr := ctx.Param("id")
+	movieIDStr := ctx.Param("movie_id")
 	movieID, err := uuid.Parse(movieIDStr)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid movie ID"})
@@ -394,8 +717,14 @@ func (c *Cinema) UpdateMovie(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.movie.UpdateMovie(movieID, updatedMovie); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	version, ok := ifMatchVersion(ctx)
+	if !ok {
+		preconditionFailed(ctx)
+		return
+	}
+
+	if err := c.movie.UpdateMovie(ctx.Request.Context(), movieID, updatedMovie, version); err != nil {
+		c.versionErrorResponse(ctx, err, "Movie not found")
 		return
 	}
 
@@ -404,25 +733,36 @@ func (c *Cinema) UpdateMovie(ctx *gin.Context) {
 
 // DeleteMovie godoc
 // @Summary      Delete movie
-// @Description  Delete a movie by its ID
+// @Description  Move a movie to the trash by its ID. It disappears from all public reads and can be restored until the retention period ends.
 // @Tags         Movies
 // @Accept       json
 // @Produce      json
 // @Param        movie_id   path    string  true  "Movie ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+// @Param        If-Match  header  string  false  "Delete only if the movie still has this ETag"
 // @Success      204  "Movie successfully deleted"
 // @Failure      400  {object} models.APIError "Invalid movie ID"
+// @Failure      404  {object} models.APIError "Movie not found"
+// @Failure      412  {object} models.APIError "Movie was modified since the given ETag or does not exist"
+// @Failure      429  {object} models.APIError "Rate limit exceeded"
 // @Failure      500  {object} models.APIError "Internal server error"
-// @Router       /api/movies/{id} [delete]
+// @Failure      504  {object} models.APIError "Request timed out"
+// @Router       /api/movies/{movie_id} [delete]
 func (c *Cinema) DeleteMovie(ctx *gin.Context) {
-	movieIDStr := ctx.Param("id")
+	movieIDStr := ctx.Param("movie_id")
 	movieID, err := uuid.Parse(movieIDStr)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid movie ID"})
 		return
 	}
 
-	if err := c.movie.DeleteMovie(movieID); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	version, ok := ifMatchVersion(ctx)
+	if !ok {
+		preconditionFailed(ctx)
+		return
+	}
+
+	if err := c.movie.DeleteMovie(ctx.Request.Context(), movieID, version); err != nil {
+		c.versionErrorResponse(ctx, err, "Movie not found")
 		return
 	}
 
@@ -438,7 +778,9 @@ func (c *Cinema) DeleteMovie(ctx *gin.Context) {
 // @Param        actor     body    models.CreateActor true "New actor details"
 // @Success      201       {object} map[string]string "Actor ID" example({ "actor_id": "f47ac10b-58cc-4372-a567-0e02b2c3d479" })
 // @Failure      400       {object} models.APIError "Invalid request body or validation errors"
+// @Failure      429       {object} models.APIError "Rate limit exceeded"
 // @Failure      500       {object} models.APIError "Internal server error"
+// @Failure      504       {object} models.APIError "Request timed out"
 // @Router       /api/actors [post]
 func (c *Cinema) CreateActor(ctx *gin.Context) {
 	var newActor models.CreateActor
@@ -453,9 +795,9 @@ func (c *Cinema) CreateActor(ctx *gin.Context) {
 		return
 	}
 
-	actorID, err := c.actor.CreateActor(newActor)
+	actorID, err := c.actor.CreateActor(ctx.Request.Context(), newActor)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()}) // status 500
+		c.serverErrorResponse(ctx, err)
 		return
 	}
 	ctx.JSON(http.StatusCreated, gin.H{"actor_id": actorID}) // status 200
@@ -468,52 +810,63 @@ func (c *Cinema) CreateActor(ctx *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        actor_id    path    string  true  "Actor ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+// @Param        If-None-Match  header  string  false  "ETag from a previous response"
 // @Success      200   {object} models.Actor "Actor details"
+// @Header       200   {string} ETag "Version of the actor"
+// @Success      304   "Not modified since the given ETag"
 // @Failure      400   {object} models.APIError "Invalid actor ID"
 // @Failure      404   {object} models.APIError "Actor not found"
+// @Failure      429   {object} models.APIError "Rate limit exceeded"
 // @Failure      500   {object} models.APIError "Internal server error"
-// @Router       /api/actors/{id} [get]
+// @Failure      504   {object} models.APIError "Request timed out"
+// @Router       /api/actors/{actor_id} [get]
 func (c *Cinema) GetActor(ctx *gin.Context) {
-	actorIDStr := ctx.Param("id")
+	actorIDStr := ctx.Param("actor_id")
 	actorID, err := uuid.Parse(actorIDStr)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid actor ID"})
 		return
 	}
-	actor, err := c.actor.GetActor(actorID)
+	actor, err := c.actor.GetActor(ctx.Request.Context(), actorID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.serverErrorResponse(ctx, err)
 		return
 	}
 	if actor == nil {
 		ctx.JSON(http.StatusNotFound, gin.H{"error": "Actor not found"})
 		return
 	}
+	if notModified(ctx, actor.Version) {
+		return
+	}
 	ctx.JSON(http.StatusOK, actor)
 }
 
 // GetAllActors godoc
 // @Summary      Get all actors with pagination
-// @Description  Retrieve a list of all actors with optional pagination
+// @Description  Retrieve a page of actors sorted by name with cursor pagination
 // @Tags         Actors
 // @Accept       json
 // @Produce      json
-// @Param        limit   query   int     false "Limit the number of actors returned" default(10)
-// @Param        offset  query   int     false "Offset for pagination" default(0)
-// @Success      200     {array} models.Actor "List of actors"
+// @Param        limit          query   int     false "Limit the number of actors returned" default(10) maximum(100)
+// @Param        cursor         query   string  false "Cursor from next_cursor of the previous page"
+// @Param        include_total  query   bool    false "Include the total number of actors" default(false)
+// @Success      200     {object} models.ActorPage "Page of actors"
 // @Failure      400     {object} models.APIError "Invalid pagination parameters"
+// @Failure      429     {object} models.APIError "Rate limit exceeded"
 // @Failure      500     {object} models.APIError "Internal server error"
+// @Failure      504     {object} models.APIError "Request timed out"
 // @Router       /api/actors [get]
 func (c *Cinema) GetAllActors(ctx *gin.Context) {
-	limit, offset, err := parseLimitOffset(ctx)
+	page, err := parsePageRequest(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		utils.BadRequestResponse(ctx, err.Error())
 		return
 	}
 
-	actors, err := c.actor.GetAllActors(limit, offset)
+	actors, err := c.actor.GetAllActors(ctx.Request.Context(), page)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.pageErrorResponse(ctx, err)
 		return
 	}
 	ctx.JSON(http.StatusOK, actors)
@@ -521,26 +874,29 @@ func (c *Cinema) GetAllActors(ctx *gin.Context) {
 
 // GetActorsWithMovies godoc
 // @Summary      Get actors with their movies
-// @Description  Retrieve a list of actors with the movies they have appeared in
+// @Description  Retrieve a page of actors sorted by name with the movies they have appeared in
 // @Tags         Actors
 // @Accept       json
 // @Produce      json
-// @Param        limit   query   int     false "Limit the number of actors returned" default(10)
-// @Param        offset  query   int     false "Offset for pagination" default(0)
-// @Success      200     {array} models.ActorWithMovies "List of actors with movies"
+// @Param        limit          query   int     false "Limit the number of actors returned" default(10) maximum(100)
+// @Param        cursor         query   string  false "Cursor from next_cursor of the previous page"
+// @Param        include_total  query   bool    false "Include the total number of actors" default(false)
+// @Success      200     {object} models.ActorWithMoviesPage "Page of actors with movies"
 // @Failure      400     {object} models.APIError "Invalid pagination parameters"
+// @Failure      429     {object} models.APIError "Rate limit exceeded"
 // @Failure      500     {object} models.APIError "Internal server error"
+// @Failure      504     {object} models.APIError "Request timed out"
 // @Router       /api/actors/with-movies [get]
 func (c *Cinema) GetActorsWithMovies(ctx *gin.Context) {
-	limit, offset, err := parseLimitOffset(ctx)
+	page, err := parsePageRequest(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.BadRequestResponse(ctx, err.Error())
 		return
 	}
 
-	actors, err := c.actor.GetActorsWithMovies(limit, offset)
+	actors, err := c.actor.GetActorsWithMovies(ctx.Request.Context(), page)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.pageErrorResponse(ctx, err)
 		return
 	}
 
@@ -555,12 +911,17 @@ func (c *Cinema) GetActorsWithMovies(ctx *gin.Context) {
 // @Produce      json
 // @Param        actor_id      path    string  true  "Actor ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
 // @Param        actor   body    models.UpdateActor true "Updated actor details"
+// @Param        If-Match  header  string  false  "Update only if the actor still has this ETag"
 // @Success      204     "Actor successfully updated"
 // @Failure      400     {object} models.APIError "Invalid request body or parameters"
+// @Failure      404     {object} models.APIError "Actor not found"
+// @Failure      412     {object} models.APIError "Actor was modified since the given ETag or does not exist"
+// @Failure      429     {object} models.APIError "Rate limit exceeded"
 // @Failure      500     {object} models.APIError "Internal server error"
-// @Router       /api/actors/{id} [put]
+// @Failure      504     {object} models.APIError "Request timed out"
+// @Router       /api/actors/{actor_id} [put]
 func (c *Cinema) UpdateActor(ctx *gin.Context) {
-	actorIDStr := ctx.Param("id")
+	actorIDStr := ctx.Param("actor_id")
 	actorID, err := uuid.Parse(actorIDStr)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid actor ID"})
@@ -579,8 +940,14 @@ func (c *Cinema) UpdateActor(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.actor.UpdateActor(actorID, updateActor); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	version, ok := ifMatchVersion(ctx)
+	if !ok {
+		preconditionFailed(ctx)
+		return
+	}
+
+	if err := c.actor.UpdateActor(ctx.Request.Context(), actorID, updateActor, version); err != nil {
+		c.versionErrorResponse(ctx, err, "Actor not found")
 		return
 	}
 
@@ -589,24 +956,34 @@ func (c *Cinema) UpdateActor(ctx *gin.Context) {
 
 // DeleteActor godoc
 // @Summary      Delete actor
-// @Description  Delete an actor based on their ID
+// @Description  Move an actor to the trash by their ID. They disappear from all public reads, including credits, and can be restored until the retention period ends.
 // @Tags         Actors
 // @Accept       json
 // @Produce      json
 // @Param        actor_id   path    string  true  "Actor ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+// @Param        If-Match  header  string  false  "Delete only if the actor still has this ETag"
 // @Success      204  "Actor successfully deleted"
 // @Failure      400  {object} models.APIError "Invalid actor ID"
+// @Failure      404  {object} models.APIError "Actor not found"
+// @Failure      412  {object} models.APIError "Actor was modified since the given ETag or does not exist"
+// @Failure      429  {object} models.APIError "Rate limit exceeded"
 // @Failure      500  {object} models.APIError "Internal server error"
-// @Router       /api/actors/{id} [delete]
+// @Failure      504  {object} models.APIError "Request timed out"
+// @Router       /api/actors/{actor_id} [delete]
 func (c *Cinema) DeleteActor(ctx *gin.Context) {
-	actorIDStr := ctx.Param("id")
+	actorIDStr := ctx.Param("actor_id")
 	actorID, err := uuid.Parse(actorIDStr)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid actor ID"})
 		return
 	}
-	if err := c.actor.DeleteActor(actorID); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	version, ok := ifMatchVersion(ctx)
+	if !ok {
+		preconditionFailed(ctx)
+		return
+	}
+	if err := c.actor.DeleteActor(ctx.Request.Context(), actorID, version); err != nil {
+		c.versionErrorResponse(ctx, err, "Actor not found")
 		return
 	}
 	ctx.Status(http.StatusNoContent)
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"cinema/internal/models"
+	"cinema/internal/utils"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ETag записи строится из ее версии, например "3"
+func versionETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// Ставит заголовок ETag и, если клиент прислал совпадающий If-None-Match, отвечает 304.
+// Возвращает true, когда ответ уже отправлен.
+func notModified(ctx *gin.Context, version int64) bool {
+	return etagMatches(ctx, versionETag(version))
+}
+
+// То же, что notModified, для произвольного ETag, например файла изображения
+func etagMatches(ctx *gin.Context, etag string) bool {
+	ctx.Header("ETag", etag)
+
+	for _, candidate := range strings.Split(ctx.GetHeader("If-None-Match"), ",") {
+		// If-None-Match сравнивает теги без учета слабости (RFC 9110, 13.1.2)
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			ctx.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// Версия из If-Match. Без заголовка или с "*" версия не проверяется и возвращается nil,
+// а отсутствие записи при "*" обрабатывает notFoundResponse.
+// ok равен false, если заголовок не содержит ETag версии: такое условие не выполнится
+// ни для одной версии записи, и клиент должен получить 412.
+func ifMatchVersion(ctx *gin.Context) (version *int64, ok bool) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, true
+	}
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return nil, false
+	}
+	value, err := strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	return &value, true
+}
+
+// Ответ на ошибку изменения записи: 412 при несовпадении версии из If-Match, отсутствие записи -
+// как notFoundResponse, остальное как serverErrorResponse
+func (c *Cinema) versionErrorResponse(ctx *gin.Context, err error, notFound string) {
+	switch {
+	case errors.Is(err, models.ErrVersionMismatch):
+		utils.PreconditionFailedResponse(ctx, "The resource was modified or deleted, fetch it again and retry")
+	case errors.Is(err, models.ErrNotFound):
+		notFoundResponse(ctx, notFound)
+	default:
+		c.serverErrorResponse(ctx, err)
+	}
+}
+
+// Записи нет: 404, а при любом If-Match, включая "*", условие не выполнено и ответ 412 (RFC 9110, 13.1.1)
+func notFoundResponse(ctx *gin.Context, message string) {
+	if strings.TrimSpace(ctx.GetHeader("If-Match")) != "" {
+		utils.PreconditionFailedResponse(ctx, "The resource does not exist, If-Match cannot match")
+		return
+	}
+	utils.NotFoundResponse(ctx, message)
+}
+
+func preconditionFailed(ctx *gin.Context) {
+	utils.PreconditionFailedResponse(ctx, "If-Match must be \"*\" or a single ETag returned by GET")
+}
@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"cinema/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type serviceExport interface {
+	Export(ctx context.Context, w io.Writer, opts models.ExportOptions) (int, error)
+}
+
+type Export struct {
+	exporter serviceExport
+	log      *slog.Logger
+}
+
+func NewExport(exporter serviceExport, log *slog.Logger) *Export {
+	return &Export{exporter: exporter, log: log}
+}
+
+var exportContentTypes = map[string]string{
+	models.FormatJSON:   "application/json",
+	models.FormatNDJSON: "application/x-ndjson",
+	models.FormatCSV:    "text/csv; charset=utf-8",
+}
+
+func parseExportOptions(ctx *gin.Context) (models.ExportOptions, []models.ValidationError) {
+	opts := models.ExportOptions{Kind: ctx.Param("kind"), Format: ctx.DefaultQuery("format", models.FormatNDJSON)}
+
+	filter, errs := parseMovieFilter(ctx)
+	opts.Filter = filter
+
+	if raw := ctx.Query("updated_since"); raw != "" {
+		value, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			errs = append(errs, models.ValidationError{Field: "updated_since", Message: "Must be a timestamp in RFC 3339 format"})
+		} else {
+			opts.UpdatedSince = &value
+		}
+	}
+
+	return opts, append(errs, opts.Validate()...)
+}
+
+// Export godoc
+// @Summary      Export the catalog
+// @Description  Streams all movies, actors or credits (movie-actor links) that are not in the trash, ordered by ID.
+// @Description  Rows go straight from the database to the response, so the export works for catalogs of any size.
+// @Description  All data comes from one repeatable-read snapshot, consistent even while the catalog changes.
+// @Description  Movie filters select movies and, for credits, the movies whose credits are exported; they are not supported for actors.
+// @Description  CSV columns: movies: id, title, description, release_date, rating, genre_ids, version, updated_at;
+// @Description  actors: id, name, gender, date_of_birth, version, updated_at; credits: movie_id, actor_id, role, character, order.
+// @Description  If an error occurs after streaming has started, the connection is closed without finishing the response.
+// @Tags         Export
+// @Produce      json
+// @Produce      application/x-ndjson
+// @Produce      text/csv
+// @Param        kind             path    string  true  "What to export" Enums(movies, actors, credits)
+// @Param        format           query   string  false "Output format" Enums(ndjson, json, csv) default(ndjson)
+// @Param        updated_since    query   string  false "Only movies or actors changed at or after this time (RFC 3339)" example("2024-01-01T00:00:00Z")
+// @Param        min_rating       query   number  false "Minimum rating, inclusive" minimum(0) maximum(10)
+// @Param        max_rating       query   number  false "Maximum rating, inclusive" minimum(0) maximum(10)
+// @Param        release_from     query   string  false "Released on or after this date (YYYY-MM-DD)" example("2000-01-01")
+// @Param        release_to       query   string  false "Released on or before this date (YYYY-MM-DD)" example("2010-12-31")
+// @Param        year_from        query   int     false "Released in or after this year" example(2000)
+// @Param        year_to          query   int     false "Released in or before this year" example(2010)
+// @Param        actor_ids        query   []string false "Actor IDs, comma separated or repeated" collectionFormat(csv)
+// @Param        actor_match      query   string  false "Whether a movie must feature any or all of actor_ids" Enums(any, all) default(any)
+// @Param        genre_ids        query   []string false "Genre IDs, comma separated or repeated; a movie matches if it has any of them" collectionFormat(csv)
+// @Param        title_prefix     query   string  false "Case-insensitive title prefix" example("The ")
+// @Param        has_description  query   bool    false "Only movies with (true) or without (false) a description"
+// @Success      200  {array}   models.Movie  "Exported records; actors export models.Actor, credits export models.ExportCredit"
+// @Failure      400  {object}  models.APIError  "Unknown kind or format, or invalid filter"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/export/{kind} [get]
+func (c *Export) Export(ctx *gin.Context) {
+	opts, validationErrors := parseExportOptions(ctx)
+	if len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(ctx, validationErrors)
+		return
+	}
+
+	// Соединение не должно закрыться по write_timeout сервера раньше, чем истечет время выгрузки
+	if deadline, ok := ctx.Request.Context().Deadline(); ok {
+		err := http.NewResponseController(ctx.Writer).SetWriteDeadline(deadline)
+		if err != nil && !errors.Is(err, http.ErrNotSupported) {
+			c.log.WarnContext(ctx.Request.Context(), "Failed to extend write deadline", "route", ctx.FullPath(), logger.Err(err))
+		}
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", opts.Kind, time.Now().UTC().Format("20060102T150405Z"), opts.Format)
+	ctx.Header("Content-Type", exportContentTypes[opts.Format])
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	_, err := c.exporter.Export(ctx.Request.Context(), ctx.Writer, opts)
+	if err == nil {
+		return
+	}
+
+	// Пока ничего не отправлено, об ошибке можно сообщить обычным ответом
+	if !ctx.Writer.Written() {
+		ctx.Writer.Header().Del("Content-Disposition")
+		ctx.Writer.Header().Del("Content-Type")
+		serverErrorResponse(ctx, c.log, err)
+		return
+	}
+	// Ответ уже частично отправлен с кодом 200. Соединение закрывается без завершения ответа,
+	// чтобы клиент получил ошибку чтения, а не принял обрывок за всю выгрузку.
+	c.log.ErrorContext(ctx.Request.Context(), "Export aborted after streaming started", "route", ctx.FullPath(), logger.Err(err))
+	panic(http.ErrAbortHandler)
+}
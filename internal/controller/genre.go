@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"cinema/internal/models"
+	"cinema/internal/utils"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Разбор genre_id из пути и проверка существования жанра.
+// При ошибке ответ уже отправлен и возвращается false.
+func (c *Cinema) existingGenreID(ctx *gin.Context) (uuid.UUID, bool) {
+	genreID, err := uuid.Parse(ctx.Param("genre_id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "Invalid genre ID format")
+		return uuid.Nil, false
+	}
+
+	genre, err := c.genre.GetGenre(ctx.Request.Context(), genreID)
+	if err != nil {
+		c.serverErrorResponse(ctx, err)
+		return uuid.Nil, false
+	}
+	if genre == nil {
+		utils.NotFoundResponse(ctx, "Genre not found")
+		return uuid.Nil, false
+	}
+	return genreID, true
+}
+
+// CreateGenre godoc
+// @Summary      Create a new genre
+// @Description  Adds a new genre. Genre names are unique, case-insensitively.
+// @Tags         Genres
+// @Accept       json
+// @Produce      json
+// @Param        genre  body      models.CreateGenre  true  "Genre details"
+// @Success      201    {object}  map[string]string  "Genre created successfully"
+// @Failure      400    {object}  models.APIError  "Invalid JSON format or validation errors"
+// @Failure      409    {object}  models.APIError  "Genre with this name already exists"
+// @Failure      429    {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500    {object}  models.APIError  "Internal server error"
+// @Failure      504    {object}  models.APIError  "Request timed out"
+// @Router       /api/genres [post]
+func (c *Cinema) CreateGenre(ctx *gin.Context) {
+	var newGenre models.CreateGenre
+	if err := ctx.ShouldBindJSON(&newGenre); err != nil {
+		utils.InvalidJSONResponse(ctx)
+		return
+	}
+
+	validationErrors := newGenre.Validate()
+	if len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(ctx, validationErrors)
+		return
+	}
+
+	id, err := c.genre.CreateGenre(ctx.Request.Context(), newGenre)
+	if err != nil {
+		if errors.Is(err, models.ErrAlreadyExists) {
+			utils.ConflictResponse(ctx, "Genre with this name already exists")
+			return
+		}
+		c.serverErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"id": id})
+}
+
+// GetGenre godoc
+// @Summary      Get genre by ID
+// @Description  Retrieves a genre by its unique identifier
+// @Tags         Genres
+// @Produce      json
+// @Param        genre_id  path     string  true  "Genre ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+// @Success      200  {object}  models.Genre  "The genre"
+// @Failure      400  {object}  models.APIError  "Invalid genre ID format"
+// @Failure      404  {object}  models.APIError  "Genre not found"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/genres/{genre_id} [get]
+func (c *Cinema) GetGenre(ctx *gin.Context) {
+	genreID, err := uuid.Parse(ctx.Param("genre_id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "Invalid genre ID format")
+		return
+	}
+
+	genre, err := c.genre.GetGenre(ctx.Request.Context(), genreID)
+	if err != nil {
+		c.serverErrorResponse(ctx, err)
+		return
+	}
+	if genre == nil {
+		utils.NotFoundResponse(ctx, "Genre not found")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, genre)
+}
+
+// GetAllGenres godoc
+// @Summary      Get all genres
+// @Description  Retrieves all genres sorted by name
+// @Tags         Genres
+// @Produce      json
+// @Success      200  {array}   models.Genre  "List of genres"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/genres [get]
+func (c *Cinema) GetAllGenres(ctx *gin.Context) {
+	genres, err := c.genre.GetAllGenres(ctx.Request.Context())
+	if err != nil {
+		c.serverErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, genres)
+}
+
+// UpdateGenre godoc
+// @Summary      Update genre
+// @Description  Renames a genre
+// @Tags         Genres
+// @Accept       json
+// @Produce      json
+// @Param        genre_id  path     string  true  "Genre ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+// @Param        genre     body     models.UpdateGenre  true  "Updated genre details"
+// @Success      204  "Genre successfully updated"
+// @Failure      400  {object}  models.APIError  "Invalid request body or parameters"
+// @Failure      404  {object}  models.APIError  "Genre not found"
+// @Failure      409  {object}  models.APIError  "Genre with this name already exists"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/genres/{genre_id} [put]
+func (c *Cinema) UpdateGenre(ctx *gin.Context) {
+	var updateGenre models.UpdateGenre
+	if err := ctx.ShouldBindJSON(&updateGenre); err != nil {
+		utils.InvalidJSONResponse(ctx)
+		return
+	}
+
+	validationErrors := updateGenre.Validate()
+	if len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(ctx, validationErrors)
+		return
+	}
+
+	genreID, ok := c.existingGenreID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.genre.UpdateGenre(ctx.Request.Context(), genreID, updateGenre); err != nil {
+		if errors.Is(err, models.ErrAlreadyExists) {
+			utils.ConflictResponse(ctx, "Genre with this name already exists")
+			return
+		}
+		c.serverErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// DeleteGenre godoc
+// @Summary      Delete genre
+// @Description  Deletes a genre and removes it from all movies
+// @Tags         Genres
+// @Produce      json
+// @Param        genre_id  path     string  true  "Genre ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+// @Success      204  "Genre successfully deleted"
+// @Failure      400  {object}  models.APIError  "Invalid genre ID format"
+// @Failure      404  {object}  models.APIError  "Genre not found"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/genres/{genre_id} [delete]
+func (c *Cinema) DeleteGenre(ctx *gin.Context) {
+	genreID, ok := c.existingGenreID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.genre.DeleteGenre(ctx.Request.Context(), genreID); err != nil {
+		c.serverErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
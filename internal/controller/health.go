@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"cinema/internal/models"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type serviceHealth interface {
+	Ready(ctx context.Context) models.HealthReport
+}
+
+type Health struct {
+	health serviceHealth
+}
+
+func NewHealth(health serviceHealth) *Health {
+	return &Health{health: health}
+}
+
+// Liveness godoc
+// @Summary      Liveness probe
+// @Description  Reports that the process is running. Does not check any dependencies.
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  models.HealthReport  "Process is alive"
+// @Router       /healthz [get]
+func (c *Health) Liveness(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, models.HealthReport{Status: models.HealthStatusOK})
+}
+
+// Readiness godoc
+// @Summary      Readiness probe
+// @Description  Checks that the database answers a ping, the schema is migrated to the expected version
+// @Description  and the server is not shutting down. Each check is reported separately.
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  models.HealthReport  "Ready to serve requests"
+// @Failure      503  {object}  models.HealthReport  "One or more checks failed"
+// @Router       /readyz [get]
+func (c *Health) Readiness(ctx *gin.Context) {
+	report := c.health.Ready(ctx.Request.Context())
+	if report.Status != models.HealthStatusOK {
+		ctx.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
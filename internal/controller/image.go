@@ -0,0 +1,363 @@
+package controller
+
+import (
+	"cinema/internal/models"
+	"cinema/internal/service"
+	"cinema/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Запас на заголовки частей multipart сверх размера самого файла
+const multipartOverhead = 64 << 10
+
+type serviceImages interface {
+	SetMoviePoster(ctx context.Context, movieID uuid.UUID, r io.Reader) (bool, error)
+	DeleteMoviePoster(ctx context.Context, movieID uuid.UUID) (bool, error)
+	GetMoviePoster(ctx context.Context, movieID uuid.UUID, width int) (*models.Image, error)
+	SetActorHeadshot(ctx context.Context, actorID uuid.UUID, r io.Reader) (bool, error)
+	DeleteActorHeadshot(ctx context.Context, actorID uuid.UUID) (bool, error)
+	GetActorHeadshot(ctx context.Context, actorID uuid.UUID, width int) (*models.Image, error)
+}
+
+type Images struct {
+	images  serviceImages
+	maxSize int64
+	log     *slog.Logger
+}
+
+// NewImages создает контроллер изображений. maxSize - допустимый размер файла в байтах,
+// он же используется в сообщении об ошибке 413.
+func NewImages(images serviceImages, maxSize int64, log *slog.Logger) *Images {
+	return &Images{images: images, maxSize: maxSize, log: log}
+}
+
+// Загружает файл из поля file тела multipart/form-data через upload.
+// Файл передается сервису потоком, не сохраняясь во временный файл.
+func (c *Images) upload(ctx *gin.Context, upload func(r io.Reader) (bool, error)) (found bool, ok bool) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, c.maxSize+multipartOverhead)
+	reader, err := ctx.Request.MultipartReader()
+	if err != nil {
+		utils.UnsupportedMediaTypeResponse(ctx, "Content-Type must be multipart/form-data")
+		return false, false
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			utils.BadRequestResponse(ctx, "Form field file is required")
+			return false, false
+		}
+		if err != nil {
+			c.uploadErrorResponse(ctx, err)
+			return false, false
+		}
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		found, err := upload(part)
+		part.Close()
+		if err != nil {
+			c.uploadErrorResponse(ctx, err)
+			return false, false
+		}
+		return found, true
+	}
+}
+
+// Ответ на ошибку загрузки: файл больше допустимого - 413, не изображение - 415,
+// испорченное тело запроса - 400
+func (c *Images) uploadErrorResponse(ctx *gin.Context, err error) {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.Is(err, service.ErrImageTooLarge), errors.As(err, &tooLarge):
+		utils.PayloadTooLargeResponse(ctx, fmt.Sprintf("Image must not exceed %d bytes", c.maxSize))
+	case errors.Is(err, service.ErrUnsupportedImage):
+		utils.UnsupportedMediaTypeResponse(ctx, err.Error())
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		utils.BadRequestResponse(ctx, "Malformed multipart body")
+	default:
+		serverErrorResponse(ctx, c.log, err)
+	}
+}
+
+// Ширина уменьшенной копии из параметра size, 0 - оригинал. Допустимые ширины проверяет сервис.
+func imageSize(ctx *gin.Context) (int, bool) {
+	raw := ctx.Query("size")
+	if raw == "" {
+		return 0, true
+	}
+	width, err := strconv.Atoi(raw)
+	if err != nil || width < 1 {
+		utils.BadRequestResponse(ctx, "size must be a positive integer")
+		return 0, false
+	}
+	return width, true
+}
+
+// Ответ на ошибку получения изображения: неизвестная ширина - 400, остальное - как serverErrorResponse
+func (c *Images) imageErrorResponse(ctx *gin.Context, err error) {
+	if errors.Is(err, service.ErrUnknownImageSize) {
+		utils.BadRequestResponse(ctx, err.Error())
+		return
+	}
+	serverErrorResponse(ctx, c.log, err)
+}
+
+// Отдает изображение. ETag меняется при каждой загрузке, а no-cache заставляет клиента
+// проверять его, поэтому замененное изображение видно сразу.
+func (c *Images) serveImage(ctx *gin.Context, image *models.Image) {
+	defer image.Body.Close()
+
+	ctx.Header("Cache-Control", "no-cache")
+	if etagMatches(ctx, image.ETag) {
+		return
+	}
+	ctx.DataFromReader(http.StatusOK, -1, image.ContentType, image.Body, nil)
+}
+
+// SetMoviePoster godoc
+// @Summary      Upload a movie poster
+// @Description  Replaces the movie's poster with a JPEG, PNG or WebP image. The type is detected from the file content,
+// @Description  not from the file name. The size limit is set by storage.max_image_size in the configuration (5 MB by default).
+// @Description  Thumbnails are made on upload; the poster and its thumbnails are then served at poster_url of the movie.
+// @Tags         Images
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        movie_id  path      string  true  "Movie ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+// @Param        file      formData  file    true  "Poster image"
+// @Success      200  {object}  map[string]string  "URL of the poster"
+// @Failure      400  {object}  models.APIError  "Invalid movie ID or missing file field"
+// @Failure      404  {object}  models.APIError  "Movie not found"
+// @Failure      413  {object}  models.APIError  "Image is too large"
+// @Failure      415  {object}  models.APIError  "Not multipart/form-data, or not a valid JPEG, PNG or WebP image"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/movies/{movie_id}/poster [put]
+func (c *Images) SetMoviePoster(ctx *gin.Context) {
+	movieID, err := uuid.Parse(ctx.Param("movie_id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "Invalid movie ID format")
+		return
+	}
+
+	found, ok := c.upload(ctx, func(r io.Reader) (bool, error) {
+		return c.images.SetMoviePoster(ctx.Request.Context(), movieID, r)
+	})
+	if !ok {
+		return
+	}
+	if !found {
+		utils.NotFoundResponse(ctx, "Movie not found")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"url": models.MoviePosterURL(movieID)})
+}
+
+// DeleteMoviePoster godoc
+// @Summary      Remove a movie poster
+// @Description  Removes the movie's poster, if it has one.
+// @Tags         Images
+// @Produce      json
+// @Param        movie_id  path  string  true  "Movie ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+// @Success      204  "Poster removed"
+// @Failure      400  {object}  models.APIError  "Invalid movie ID"
+// @Failure      404  {object}  models.APIError  "Movie not found"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/movies/{movie_id}/poster [delete]
+func (c *Images) DeleteMoviePoster(ctx *gin.Context) {
+	movieID, err := uuid.Parse(ctx.Param("movie_id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "Invalid movie ID format")
+		return
+	}
+
+	found, err := c.images.DeleteMoviePoster(ctx.Request.Context(), movieID)
+	if err != nil {
+		serverErrorResponse(ctx, c.log, err)
+		return
+	}
+	if !found {
+		utils.NotFoundResponse(ctx, "Movie not found")
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetMoviePoster godoc
+// @Summary      Get a movie poster
+// @Description  Returns the poster image or, with size, its thumbnail of that width. Thumbnails of the widths in storage.thumbnail_widths
+// @Description  (92, 185 and 500 by default) are made on upload as JPEG, or PNG for PNG posters. If the poster is not wider than size,
+// @Description  the original is returned. Responses carry an ETag that changes on every upload; send it in If-None-Match to get 304.
+// @Tags         Images
+// @Produce      image/jpeg
+// @Produce      image/png
+// @Produce      image/webp
+// @Param        movie_id       path    string  true   "Movie ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+// @Param        size           query   int     false  "Thumbnail width in pixels, one of storage.thumbnail_widths" example(185)
+// @Param        If-None-Match  header  string  false  "ETag of a cached copy"
+// @Success      200  {file}    file  "Poster image"
+// @Success      304  "Cached copy is up to date"
+// @Failure      400  {object}  models.APIError  "Invalid movie ID or unknown size"
+// @Failure      404  {object}  models.APIError  "Movie or poster not found"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/movies/{movie_id}/poster [get]
+func (c *Images) GetMoviePoster(ctx *gin.Context) {
+	movieID, err := uuid.Parse(ctx.Param("movie_id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "Invalid movie ID format")
+		return
+	}
+
+	width, ok := imageSize(ctx)
+	if !ok {
+		return
+	}
+
+	image, err := c.images.GetMoviePoster(ctx.Request.Context(), movieID, width)
+	if err != nil {
+		c.imageErrorResponse(ctx, err)
+		return
+	}
+	if image == nil {
+		utils.NotFoundResponse(ctx, "Poster not found")
+		return
+	}
+
+	c.serveImage(ctx, image)
+}
+
+// SetActorHeadshot godoc
+// @Summary      Upload an actor headshot
+// @Description  Replaces the actor's headshot with a JPEG, PNG or WebP image. The type is detected from the file content,
+// @Description  not from the file name. The size limit is set by storage.max_image_size in the configuration (5 MB by default).
+// @Description  Thumbnails are made on upload; the headshot and its thumbnails are then served at headshot_url of the actor.
+// @Tags         Images
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        actor_id  path      string  true  "Actor ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+// @Param        file      formData  file    true  "Headshot image"
+// @Success      200  {object}  map[string]string  "URL of the headshot"
+// @Failure      400  {object}  models.APIError  "Invalid actor ID or missing file field"
+// @Failure      404  {object}  models.APIError  "Actor not found"
+// @Failure      413  {object}  models.APIError  "Image is too large"
+// @Failure      415  {object}  models.APIError  "Not multipart/form-data, or not a valid JPEG, PNG or WebP image"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/actors/{actor_id}/headshot [put]
+func (c *Images) SetActorHeadshot(ctx *gin.Context) {
+	actorID, err := uuid.Parse(ctx.Param("actor_id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "Invalid actor ID format")
+		return
+	}
+
+	found, ok := c.upload(ctx, func(r io.Reader) (bool, error) {
+		return c.images.SetActorHeadshot(ctx.Request.Context(), actorID, r)
+	})
+	if !ok {
+		return
+	}
+	if !found {
+		utils.NotFoundResponse(ctx, "Actor not found")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"url": models.ActorHeadshotURL(actorID)})
+}
+
+// DeleteActorHeadshot godoc
+// @Summary      Remove an actor headshot
+// @Description  Removes the actor's headshot, if it has one.
+// @Tags         Images
+// @Produce      json
+// @Param        actor_id  path  string  true  "Actor ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+// @Success      204  "Headshot removed"
+// @Failure      400  {object}  models.APIError  "Invalid actor ID"
+// @Failure      404  {object}  models.APIError  "Actor not found"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/actors/{actor_id}/headshot [delete]
+func (c *Images) DeleteActorHeadshot(ctx *gin.Context) {
+	actorID, err := uuid.Parse(ctx.Param("actor_id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "Invalid actor ID format")
+		return
+	}
+
+	found, err := c.images.DeleteActorHeadshot(ctx.Request.Context(), actorID)
+	if err != nil {
+		serverErrorResponse(ctx, c.log, err)
+		return
+	}
+	if !found {
+		utils.NotFoundResponse(ctx, "Actor not found")
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetActorHeadshot godoc
+// @Summary      Get an actor headshot
+// @Description  Returns the headshot image or, with size, its thumbnail of that width. Thumbnails of the widths in storage.thumbnail_widths
+// @Description  (92, 185 and 500 by default) are made on upload as JPEG, or PNG for PNG headshots. If the headshot is not wider than size,
+// @Description  the original is returned. Responses carry an ETag that changes on every upload; send it in If-None-Match to get 304.
+// @Tags         Images
+// @Produce      image/jpeg
+// @Produce      image/png
+// @Produce      image/webp
+// @Param        actor_id       path    string  true   "Actor ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+// @Param        size           query   int     false  "Thumbnail width in pixels, one of storage.thumbnail_widths" example(185)
+// @Param        If-None-Match  header  string  false  "ETag of a cached copy"
+// @Success      200  {file}    file  "Headshot image"
+// @Success      304  "Cached copy is up to date"
+// @Failure      400  {object}  models.APIError  "Invalid actor ID or unknown size"
+// @Failure      404  {object}  models.APIError  "Actor or headshot not found"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/actors/{actor_id}/headshot [get]
+func (c *Images) GetActorHeadshot(ctx *gin.Context) {
+	actorID, err := uuid.Parse(ctx.Param("actor_id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "Invalid actor ID format")
+		return
+	}
+
+	width, ok := imageSize(ctx)
+	if !ok {
+		return
+	}
+
+	image, err := c.images.GetActorHeadshot(ctx.Request.Context(), actorID, width)
+	if err != nil {
+		c.imageErrorResponse(ctx, err)
+		return
+	}
+	if image == nil {
+		utils.NotFoundResponse(ctx, "Headshot not found")
+		return
+	}
+
+	c.serveImage(ctx, image)
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"cinema/internal/service"
+	"cinema/internal/utils"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Максимальный размер файла импорта
+const maxImportSize = 32 << 20
+
+type serviceImport interface {
+	Import(ctx context.Context, r io.Reader, opts models.ImportOptions) (*models.ImportResult, error)
+}
+
+type Import struct {
+	importer serviceImport
+	log      *slog.Logger
+}
+
+func NewImport(importer serviceImport, log *slog.Logger) *Import {
+	return &Import{importer: importer, log: log}
+}
+
+// Формат файла из параметра format, иначе из Content-Type
+func importFormat(ctx *gin.Context) string {
+	if format := ctx.Query("format"); format != "" {
+		return format
+	}
+	mediaType, _, _ := mime.ParseMediaType(ctx.GetHeader("Content-Type"))
+	switch mediaType {
+	case "text/csv":
+		return models.FormatCSV
+	case "application/x-ndjson", "application/jsonl":
+		return models.FormatNDJSON
+	default:
+		return ""
+	}
+}
+
+// Import godoc
+// @Summary      Bulk import actors, movies or credits
+// @Description  Imports a CSV or NDJSON file in a single transaction. Each row is validated like the corresponding create request;
+// @Description  rows with errors are skipped and reported with their line numbers. CSV files need a header row with column names:
+// @Description  actors: name, gender, date_of_birth; movies: title, description, release_date, rating, actor_ids, genre_ids
+// @Description  (comma-separated IDs); credits: movie_id or movie_title + movie_release_date, actor_id or actor_name + actor_date_of_birth,
+// @Description  role, character, order. Dates are YYYY-MM-DD. NDJSON lines use the same fields as the JSON API.
+// @Description  With dry_run nothing is saved; with atomic nothing is saved if any row has errors. The file is limited to 32 MB.
+// @Tags         Import
+// @Accept       text/csv
+// @Accept       application/x-ndjson
+// @Produce      json
+// @Param        kind     path    string  true   "What the file contains" Enums(actors, movies, credits)
+// @Param        format   query   string  false  "File format; defaults to the Content-Type" Enums(csv, ndjson)
+// @Param        dry_run  query   bool    false  "Validate and report errors without saving anything"
+// @Param        atomic   query   bool    false  "Save nothing if any row has errors"
+// @Param        file     body    string  true   "File contents"
+// @Success      200  {object}  models.ImportResult  "Import result with per-row errors"
+// @Failure      400  {object}  models.APIError  "Unknown kind or format, invalid CSV header or malformed file"
+// @Failure      413  {object}  models.APIError  "File is larger than 32 MB"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/import/{kind} [post]
+func (c *Import) Import(ctx *gin.Context) {
+	opts := models.ImportOptions{Kind: ctx.Param("kind"), Format: importFormat(ctx)}
+
+	for name, value := range map[string]*bool{"dry_run": &opts.DryRun, "atomic": &opts.Atomic} {
+		raw := ctx.Query(name)
+		if raw == "" {
+			continue
+		}
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			utils.BadRequestResponse(ctx, name+" must be true or false")
+			return
+		}
+		*value = parsed
+	}
+
+	// Файл читается по мере импорта, а ответ пишется после него, поэтому соединение не должно
+	// закрыться по read_timeout или write_timeout сервера раньше, чем истечет время импорта
+	if deadline, ok := ctx.Request.Context().Deadline(); ok {
+		controller := http.NewResponseController(ctx.Writer)
+		for _, err := range []error{controller.SetReadDeadline(deadline), controller.SetWriteDeadline(deadline)} {
+			if err != nil && !errors.Is(err, http.ErrNotSupported) {
+				c.log.WarnContext(ctx.Request.Context(), "Failed to extend connection deadline", "route", ctx.FullPath(), logger.Err(err))
+			}
+		}
+	}
+
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportSize)
+	result, err := c.importer.Import(ctx.Request.Context(), body, opts)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			utils.PayloadTooLargeResponse(ctx, "Import file must not exceed 32 MB")
+		case errors.Is(err, service.ErrInvalidImport):
+			utils.BadRequestResponse(ctx, err.Error())
+		default:
+			serverErrorResponse(ctx, c.log, err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"cinema/internal/models"
+	"cinema/internal/service"
+	"cinema/internal/utils"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Максимальный размер тела PATCH
+const maxPatchSize = 1 << 20
+
+// Тело PATCH: JSON Merge Patch. application/json тоже принимается, потому что многие клиенты
+// не умеют задавать другой тип для JSON.
+func readMergePatch(ctx *gin.Context) ([]byte, bool) {
+	switch ctx.ContentType() {
+	case "application/merge-patch+json", "application/json":
+	default:
+		utils.UnsupportedMediaTypeResponse(ctx, "Content-Type must be application/merge-patch+json")
+		return nil, false
+	}
+
+	patch, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxPatchSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			utils.PayloadTooLargeResponse(ctx, "Patch must not exceed 1 MB")
+		} else {
+			utils.InvalidJSONResponse(ctx)
+		}
+		return nil, false
+	}
+	return patch, true
+}
+
+// Ответ на ошибку PATCH: неверный патч - 400, ошибки проверки результата - 400 с полями,
+// несовпадение версии - 412
+func (c *Cinema) patchErrorResponse(ctx *gin.Context, err error, notFound string) {
+	var validationErrors models.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrors):
+		utils.ValidationErrorResponse(ctx, validationErrors)
+	case errors.Is(err, service.ErrInvalidPatch):
+		utils.BadRequestResponse(ctx, err.Error())
+	default:
+		c.versionErrorResponse(ctx, err, notFound)
+	}
+}
+
+// PatchMovie godoc
+// @Summary      Partially update a movie
+// @Description  Applies a JSON Merge Patch (RFC 7396) to the movie's title, description, release_date, rating and genre_ids.
+// @Description  Fields missing from the patch stay unchanged, null clears description or rating, and genre_ids replaces the whole list.
+// @Description  The patched movie is validated like a new one. Other fields, such as id or version, cannot be patched.
+// @Tags         Movies
+// @Accept       application/merge-patch+json
+// @Accept       json
+// @Produce      json
+// @Param        movie_id  path    string  true  "Movie ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+// @Param        patch     body    models.MovieFields  true  "Merge patch with the fields to change"
+// @Param        If-Match  header  string  false  "Update only if the movie still has this ETag"
+// @Success      200  {object}  models.Movie  "The updated movie"
+// @Header       200  {string}  ETag  "Version of the updated movie"
+// @Failure      400  {object}  models.APIError  "Invalid movie ID, malformed patch or validation errors"
+// @Failure      404  {object}  models.APIError  "Movie not found"
+// @Failure      412  {object}  models.APIError  "Movie was modified since the given ETag or does not exist"
+// @Failure      413  {object}  models.APIError  "Patch is larger than 1 MB"
+// @Failure      415  {object}  models.APIError  "Unsupported Content-Type"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/movies/{movie_id} [patch]
+func (c *Cinema) PatchMovie(ctx *gin.Context) {
+	movieID, err := uuid.Parse(ctx.Param("movie_id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "Invalid movie ID")
+		return
+	}
+
+	version, ok := ifMatchVersion(ctx)
+	if !ok {
+		preconditionFailed(ctx)
+		return
+	}
+
+	patch, ok := readMergePatch(ctx)
+	if !ok {
+		return
+	}
+
+	movie, err := c.movie.PatchMovie(ctx.Request.Context(), movieID, patch, version)
+	if err != nil {
+		c.patchErrorResponse(ctx, err, "Movie not found")
+		return
+	}
+	if movie == nil {
+		notFoundResponse(ctx, "Movie not found")
+		return
+	}
+
+	ctx.Header("ETag", versionETag(movie.Version))
+	ctx.JSON(http.StatusOK, movie)
+}
+
+// PatchActor godoc
+// @Summary      Partially update an actor
+// @Description  Applies a JSON Merge Patch (RFC 7396) to the actor's name, gender and date_of_birth.
+// @Description  Fields missing from the patch stay unchanged, null clears gender or date_of_birth. Other fields cannot be patched.
+// @Tags         Actors
+// @Accept       application/merge-patch+json
+// @Accept       json
+// @Produce      json
+// @Param        actor_id  path    string  true  "Actor ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+// @Param        patch     body    models.ActorFields  true  "Merge patch with the fields to change"
+// @Param        If-Match  header  string  false  "Update only if the actor still has this ETag"
+// @Success      200  {object}  models.Actor  "The updated actor"
+// @Header       200  {string}  ETag  "Version of the updated actor"
+// @Failure      400  {object}  models.APIError  "Invalid actor ID, malformed patch or validation errors"
+// @Failure      404  {object}  models.APIError  "Actor not found"
+// @Failure      412  {object}  models.APIError  "Actor was modified since the given ETag or does not exist"
+// @Failure      413  {object}  models.APIError  "Patch is larger than 1 MB"
+// @Failure      415  {object}  models.APIError  "Unsupported Content-Type"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/actors/{actor_id} [patch]
+func (c *Cinema) PatchActor(ctx *gin.Context) {
+	actorID, err := uuid.Parse(ctx.Param("actor_id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "Invalid actor ID")
+		return
+	}
+
+	version, ok := ifMatchVersion(ctx)
+	if !ok {
+		preconditionFailed(ctx)
+		return
+	}
+
+	patch, ok := readMergePatch(ctx)
+	if !ok {
+		return
+	}
+
+	actor, err := c.actor.PatchActor(ctx.Request.Context(), actorID, patch, version)
+	if err != nil {
+		c.patchErrorResponse(ctx, err, "Actor not found")
+		return
+	}
+	if actor == nil {
+		notFoundResponse(ctx, "Actor not found")
+		return
+	}
+
+	ctx.Header("ETag", versionETag(actor.Version))
+	ctx.JSON(http.StatusOK, actor)
+}
@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"cinema/internal/models"
+	"cinema/internal/utils"
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type serviceTrash interface {
+	GetDeletedMovies(ctx context.Context, limit, offset int) ([]models.TrashedMovie, error)
+	GetDeletedActors(ctx context.Context, limit, offset int) ([]models.TrashedActor, error)
+	RestoreMovie(ctx context.Context, id uuid.UUID) (bool, error)
+	RestoreActor(ctx context.Context, id uuid.UUID) (bool, error)
+}
+
+type Trash struct {
+	trash serviceTrash
+	log   *slog.Logger
+}
+
+func NewTrash(trash serviceTrash, log *slog.Logger) *Trash {
+	return &Trash{trash: trash, log: log}
+}
+
+// Лимит и смещение админских списков, лимит не больше maxPageLimit
+func parseOffsetPage(ctx *gin.Context) (int, int, bool) {
+	limit, offset, err := parseLimitOffset(ctx)
+	if err != nil {
+		utils.BadRequestResponse(ctx, err.Error())
+		return 0, 0, false
+	}
+	if limit > maxPageLimit {
+		utils.BadRequestResponse(ctx, "limit must not exceed 100")
+		return 0, 0, false
+	}
+	return limit, offset, true
+}
+
+// ListMovies godoc
+// @Summary      List deleted movies
+// @Description  Movies in the trash, most recently deleted first. They stay restorable until the retention period ends.
+// @Tags         Trash
+// @Produce      json
+// @Param        limit   query   int  true  "Number of movies to return" maximum(100)
+// @Param        offset  query   int  true  "Number of movies to skip"
+// @Success      200  {array}   models.TrashedMovie  "Deleted movies"
+// @Failure      400  {object}  models.APIError  "Invalid limit or offset"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/trash/movies [get]
+func (c *Trash) ListMovies(ctx *gin.Context) {
+	limit, offset, ok := parseOffsetPage(ctx)
+	if !ok {
+		return
+	}
+
+	movies, err := c.trash.GetDeletedMovies(ctx.Request.Context(), limit, offset)
+	if err != nil {
+		serverErrorResponse(ctx, c.log, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, movies)
+}
+
+// ListActors godoc
+// @Summary      List deleted actors
+// @Description  Actors in the trash, most recently deleted first. They stay restorable until the retention period ends.
+// @Tags         Trash
+// @Produce      json
+// @Param        limit   query   int  true  "Number of actors to return" maximum(100)
+// @Param        offset  query   int  true  "Number of actors to skip"
+// @Success      200  {array}   models.TrashedActor  "Deleted actors"
+// @Failure      400  {object}  models.APIError  "Invalid limit or offset"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/trash/actors [get]
+func (c *Trash) ListActors(ctx *gin.Context) {
+	limit, offset, ok := parseOffsetPage(ctx)
+	if !ok {
+		return
+	}
+
+	actors, err := c.trash.GetDeletedActors(ctx.Request.Context(), limit, offset)
+	if err != nil {
+		serverErrorResponse(ctx, c.log, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, actors)
+}
+
+// RestoreMovie godoc
+// @Summary      Restore a deleted movie
+// @Description  Moves a movie out of the trash. Its cast, crew and genres are restored with it.
+// @Tags         Trash
+// @Produce      json
+// @Param        movie_id  path  string  true  "Movie ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+// @Success      204  "Movie restored"
+// @Failure      400  {object}  models.APIError  "Invalid movie ID"
+// @Failure      404  {object}  models.APIError  "Movie is not in the trash"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/trash/movies/{movie_id}/restore [post]
+func (c *Trash) RestoreMovie(ctx *gin.Context) {
+	movieID, err := uuid.Parse(ctx.Param("movie_id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "Invalid movie ID format")
+		return
+	}
+
+	restored, err := c.trash.RestoreMovie(ctx.Request.Context(), movieID)
+	if err != nil {
+		serverErrorResponse(ctx, c.log, err)
+		return
+	}
+	if !restored {
+		utils.NotFoundResponse(ctx, "Movie is not in the trash")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RestoreActor godoc
+// @Summary      Restore a deleted actor
+// @Description  Moves an actor out of the trash. The actor reappears in the credits of all their movies.
+// @Tags         Trash
+// @Produce      json
+// @Param        actor_id  path  string  true  "Actor ID" example("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+// @Success      204  "Actor restored"
+// @Failure      400  {object}  models.APIError  "Invalid actor ID"
+// @Failure      404  {object}  models.APIError  "Actor is not in the trash"
+// @Failure      429  {object}  models.APIError  "Rate limit exceeded"
+// @Failure      500  {object}  models.APIError  "Internal server error"
+// @Failure      504  {object}  models.APIError  "Request timed out"
+// @Router       /api/trash/actors/{actor_id}/restore [post]
+func (c *Trash) RestoreActor(ctx *gin.Context) {
+	actorID, err := uuid.Parse(ctx.Param("actor_id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "Invalid actor ID format")
+		return
+	}
+
+	restored, err := c.trash.RestoreActor(ctx.Request.Context(), actorID)
+	if err != nil {
+		serverErrorResponse(ctx, c.log, err)
+		return
+	}
+	if !restored {
+		utils.NotFoundResponse(ctx, "Actor is not in the trash")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+)
+
+type requestIDKey struct{}
+
+// New создает логгер, который пишет JSON-записи в w. В каждую запись, сделанную
+// с контекстом запроса (InfoContext, ErrorContext и т.д.), добавляется request_id.
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level})
+	return slog.New(contextHandler{Handler: handler})
+}
+
+// Discard логгер, который ничего не пишет, для тестов
+func Discard() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError + 1}))
+}
+
+// WithRequestID сохраняет ID запроса в контексте
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID возвращает ID запроса из контекста или пустую строку
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// Err атрибут с ошибкой: итоговое сообщение и цепочка обернутых ошибок от внешней к исходной
+func Err(err error) slog.Attr {
+	if err == nil {
+		return slog.Attr{}
+	}
+	return slog.Group("error",
+		slog.String("message", err.Error()),
+		slog.Any("chain", chain(err)),
+	)
+}
+
+func chain(err error) []string {
+	var messages []string
+	for err != nil {
+		messages = append(messages, err.Error())
+
+		// errors.Join и fmt.Errorf с несколькими %w оборачивают список ошибок
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, inner := range joined.Unwrap() {
+				messages = append(messages, chain(inner)...)
+			}
+			break
+		}
+		err = errors.Unwrap(err)
+	}
+	return messages
+}
+
+// Добавляет request_id из контекста записи
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggerAddsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(&buf, slog.LevelInfo).With("component", "test")
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	log.InfoContext(ctx, "hello")
+
+	var record map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "hello", record["msg"])
+	assert.Equal(t, "req-1", record["request_id"])
+	assert.Equal(t, "test", record["component"])
+}
+
+func TestLoggerSkipsBelowLevel(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(&buf, slog.LevelWarn)
+
+	log.Info("ignored")
+	assert.Empty(t, buf.String())
+}
+
+func TestErrChain(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(&buf, slog.LevelInfo)
+
+	cause := errors.New("connection refused")
+	err := fmt.Errorf("failed to get actor: %w", cause)
+	log.Error("request failed", Err(err))
+
+	var record struct {
+		Error struct {
+			Message string   `json:"message"`
+			Chain   []string `json:"chain"`
+		} `json:"error"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, err.Error(), record.Error.Message)
+	assert.Equal(t, []string{err.Error(), cause.Error()}, record.Error.Chain)
+}
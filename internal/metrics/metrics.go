@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"cinema/internal/cache"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "cinema"
+
+// Маршрут для запросов, не совпавших ни с одним шаблоном. Сырой путь в метку не пишется,
+// иначе число временных рядов росло бы с каждым новым URL.
+const unmatchedRoute = "unmatched"
+
+// Metrics хранит собственный реестр и метрики приложения. Все методы допускают
+// nil-получатель, поэтому слоям, которым метрики не нужны (например, в тестах), можно передать nil.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests    *prometheus.CounterVec
+	httpDuration    *prometheus.HistogramVec
+	dbQueryDuration *prometheus.HistogramVec
+	dbTransactions  *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Number of HTTP requests by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method and route template.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		dbQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Duration of repository queries by operation.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		}, []string{"operation"}),
+		dbTransactions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "db_transactions_total",
+			Help:      "Number of finished database transactions by result (commit or rollback).",
+		}, []string{"result"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.dbQueryDuration,
+		m.dbTransactions,
+	)
+	return m
+}
+
+// RegisterDB добавляет метрики пула соединений (sql.DBStats) для db
+func (m *Metrics) RegisterDB(db *sql.DB, dbName string) {
+	if m == nil {
+		return
+	}
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// RegisterCache добавляет счетчики попаданий, промахов и вытеснений кэша с меткой cache=name.
+// Значения читаются из c.Stats() в момент сбора метрик.
+func (m *Metrics) RegisterCache(c *cache.LRU, name string) {
+	if m == nil || c == nil {
+		return
+	}
+	counter := func(metric, help, label, value string, read func(cache.Stats) uint64) prometheus.Collector {
+		return prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Name:        metric,
+			Help:        help,
+			ConstLabels: prometheus.Labels{"cache": name, label: value},
+		}, func() float64 { return float64(read(c.Stats())) })
+	}
+	const (
+		requestsHelp  = "Cache lookups by result (hit or miss)."
+		evictionsHelp = "Entries removed from the cache by reason (capacity or expired)."
+	)
+
+	m.registry.MustRegister(
+		counter("cache_requests_total", requestsHelp, "result", "hit", func(s cache.Stats) uint64 { return s.Hits }),
+		counter("cache_requests_total", requestsHelp, "result", "miss", func(s cache.Stats) uint64 { return s.Misses }),
+		counter("cache_evictions_total", evictionsHelp, "reason", "capacity", func(s cache.Stats) uint64 { return s.Evictions }),
+		counter("cache_evictions_total", evictionsHelp, "reason", "expired", func(s cache.Stats) uint64 { return s.Expired }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Name:        "cache_entries",
+			Help:        "Number of entries currently in the cache.",
+			ConstLabels: prometheus.Labels{"cache": name},
+		}, func() float64 { return float64(c.Stats().Entries) }),
+	)
+}
+
+// Handler отдает метрики в формате Prometheus
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Middleware считает запросы и их длительность с меткой шаблона маршрута gin
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if m == nil {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		status := strconv.Itoa(c.Writer.Status())
+
+		m.httpRequests.WithLabelValues(c.Request.Method, route, status).Inc()
+		m.httpDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveQuery записывает длительность запроса операции op, начатого в start.
+// Удобно вызывать через defer в начале метода репозитория.
+func (m *Metrics) ObserveQuery(op string, start time.Time) {
+	if m == nil {
+		return
+	}
+	m.dbQueryDuration.WithLabelValues(op).Observe(time.Since(start).Seconds())
+}
+
+// TransactionFinished учитывает завершенную транзакцию
+func (m *Metrics) TransactionFinished(committed bool) {
+	if m == nil {
+		return
+	}
+	result := "rollback"
+	if committed {
+		result = "commit"
+	}
+	m.dbTransactions.WithLabelValues(result).Inc()
+}
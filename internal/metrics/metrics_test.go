@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"cinema/internal/cache"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewareLabelsRouteTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := New()
+
+	router := gin.New()
+	router.Use(m.Middleware())
+	router.GET("/api/movies/:movie_id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, path := range []string{"/api/movies/1", "/api/movies/2", "/unknown"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	// Разные ID попадают в один ряд с шаблоном маршрута
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", "/api/movies/:movie_id", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", unmatchedRoute, "404")))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.httpDuration))
+}
+
+func TestTransactionsAndQueries(t *testing.T) {
+	m := New()
+
+	m.TransactionFinished(true)
+	m.TransactionFinished(true)
+	m.TransactionFinished(false)
+	m.ObserveQuery("GetActor", time.Now())
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.dbTransactions.WithLabelValues("commit")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.dbTransactions.WithLabelValues("rollback")))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.dbQueryDuration))
+}
+
+func TestHandlerExposesMetrics(t *testing.T) {
+	m := New()
+	m.TransactionFinished(true)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, strings.Contains(rec.Body.String(), `cinema_db_transactions_total{result="commit"} 1`))
+}
+
+func TestRegisterCacheExposesStats(t *testing.T) {
+	m := New()
+	c := cache.New(10, time.Minute)
+	m.RegisterCache(c, "catalog")
+
+	c.Set("a", 1, c.Generation())
+	c.Get("a")
+	c.Get("b")
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `cinema_cache_requests_total{cache="catalog",result="hit"} 1`)
+	assert.Contains(t, body, `cinema_cache_requests_total{cache="catalog",result="miss"} 1`)
+	assert.Contains(t, body, `cinema_cache_entries{cache="catalog"} 1`)
+}
+
+func TestNilMetricsIsNoop(t *testing.T) {
+	var m *Metrics
+
+	assert.NotPanics(t, func() {
+		m.TransactionFinished(true)
+		m.ObserveQuery("GetActor", time.Now())
+		m.RegisterDB(nil, "test")
+		m.RegisterCache(cache.New(1, time.Minute), "test")
+	})
+}
@@ -1,20 +1,24 @@
 package middleware
 
 import (
+	"cinema/internal/audit"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
 )
 
-var SecretKey = []byte("your-secret-key") // Секретный ключ для проверки подписи токена
-
-const RoleKey = "role"
+const (
+	RoleKey    = "role"
+	SubjectKey = "sub"
+)
 
-// JWTAuthMiddleware для проверки токена и установки роли в контекст
-func JWTAuthMiddleware() gin.HandlerFunc {
+// JWTAuthMiddleware для проверки токена и установки роли и субъекта в контекст.
+// secret - секретный ключ для проверки подписи токена
+func JWTAuthMiddleware(secret []byte) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Извлекаем токен из заголовка Authorization
 		authHeader := c.GetHeader("Authorization")
@@ -38,7 +42,7 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 			}
-			return SecretKey, nil
+			return secret, nil
 		})
 
 		if err != nil || !token.Valid {
@@ -55,6 +59,20 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// Токен без срока действия не принимаем
+		if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token expired or has no expiration"})
+			c.Abort()
+			return
+		}
+
+		subject, ok := claims["sub"].(string)
+		if !ok || subject == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Subject not found in token"})
+			c.Abort()
+			return
+		}
+
 		role, ok := claims["role"].(string)
 		if !ok {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Role not found in token"})
@@ -62,8 +80,11 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// Устанавливаем роль в контексте
+		// Устанавливаем субъект и роль в контексте. В контекст запроса они попадают
+		// для журнала аудита, который пишут сервисы.
+		c.Set(SubjectKey, subject)
 		c.Set(RoleKey, role)
+		c.Request = c.Request.WithContext(audit.WithActor(c.Request.Context(), audit.Actor{Subject: subject, Role: role}))
 		c.Next()
 	}
 }
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/utils"
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// Ограничение на присланный клиентом ID, чтобы в лог не попадал произвольный мусор
+const maxRequestIDLength = 128
+
+// RequestID берет ID запроса из заголовка X-Request-ID или генерирует новый,
+// сохраняет его в контексте запроса и возвращает клиенту в том же заголовке
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		c.Request = c.Request.WithContext(logger.WithRequestID(c.Request.Context(), requestID))
+		c.Header(RequestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// Допускаются только печатные ASCII-символы без пробелов
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] <= ' ' || requestID[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// AccessLog пишет по записи на каждый запрос: маршрут, статус, длительность.
+// Уровень зависит от статуса: 5xx - error, 4xx - warn, остальное - info.
+func AccessLog(log *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int("bytes", c.Writer.Size()),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if err := c.Errors.Last(); err != nil {
+			attrs = append(attrs, logger.Err(err.Err))
+		}
+
+		log.LogAttrs(c.Request.Context(), level, "HTTP request", attrs...)
+	}
+}
+
+// Recovery перехватывает панику обработчика, пишет ее в лог со стеком и отвечает 500.
+// http.ErrAbortHandler пропускается дальше: обработчик сам прерывает ответ, который уже начал
+// отправлять, и сервер должен закрыть соединение, чтобы клиент не принял обрывок за весь ответ.
+func Recovery(log *slog.Logger) gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered interface{}) {
+		if recovered == http.ErrAbortHandler {
+			panic(recovered)
+		}
+		log.ErrorContext(c.Request.Context(), "Panic recovered",
+			"route", c.FullPath(),
+			"panic", recovered,
+			"stack", string(debug.Stack()),
+		)
+		utils.InternalServerErrorResponse(c, "Internal server error")
+		c.Abort()
+	})
+}
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"bytes"
+	"cinema/internal/logger"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLoggedRouter(buf *bytes.Buffer) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	log := logger.New(buf, slog.LevelInfo)
+
+	router := gin.New()
+	router.Use(RequestID(), AccessLog(log), Recovery(log))
+	return router
+}
+
+func TestRequestIDPropagated(t *testing.T) {
+	var buf bytes.Buffer
+	router := newLoggedRouter(&buf)
+
+	var seen string
+	router.GET("/items/:id", func(c *gin.Context) {
+		seen = logger.RequestID(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+	req.Header.Set(RequestIDHeader, "abc-123")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, "abc-123", seen)
+	assert.Equal(t, "abc-123", rec.Header().Get(RequestIDHeader))
+
+	var record map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "abc-123", record["request_id"])
+	assert.Equal(t, "/items/:id", record["route"])
+	assert.Equal(t, float64(http.StatusNoContent), record["status"])
+	assert.Equal(t, "INFO", record["level"])
+	assert.Contains(t, record, "latency_ms")
+}
+
+func TestRequestIDGeneratedForInvalidHeader(t *testing.T) {
+	var buf bytes.Buffer
+	router := newLoggedRouter(&buf)
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(RequestIDHeader, "bad id\nwith newline")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	requestID := rec.Header().Get(RequestIDHeader)
+	assert.NotEmpty(t, requestID)
+	assert.NotEqual(t, "bad id\nwith newline", requestID)
+}
+
+func TestAccessLogErrorChain(t *testing.T) {
+	var buf bytes.Buffer
+	router := newLoggedRouter(&buf)
+
+	cause := errors.New("connection reset")
+	router.GET("/", func(c *gin.Context) {
+		c.Error(fmt.Errorf("failed to get movie: %w", cause))
+		c.Status(http.StatusInternalServerError)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var record struct {
+		Level string `json:"level"`
+		Error struct {
+			Chain []string `json:"chain"`
+		} `json:"error"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "ERROR", record.Level)
+	assert.Equal(t, []string{"failed to get movie: connection reset", "connection reset"}, record.Error.Chain)
+}
+
+func TestRecoveryLogsPanic(t *testing.T) {
+	var buf bytes.Buffer
+	router := newLoggedRouter(&buf)
+	router.GET("/", func(c *gin.Context) { panic("boom") })
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, buf.String(), `"msg":"Panic recovered"`)
+	assert.Contains(t, buf.String(), `"status":500`)
+}
+
+func TestRecoveryPassesAbortHandler(t *testing.T) {
+	var buf bytes.Buffer
+	router := newLoggedRouter(&buf)
+	router.GET("/", func(c *gin.Context) { panic(http.ErrAbortHandler) })
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+	assert.NotContains(t, buf.String(), "Panic recovered")
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"cinema/internal/utils"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Как часто удаляются корзины клиентов, которые успели полностью восстановиться
+const rateLimitCleanupInterval = time.Minute
+
+// RateLimit ограничивает частоту запросов алгоритмом token bucket: у каждого клиента
+// корзина на burst запросов, которая пополняется со скоростью requestsPerSecond.
+// Клиент определяется по subject из JWT, если JWTAuthMiddleware уже отработал, иначе по IP.
+// В ответ добавляются заголовки RateLimit-Limit, RateLimit-Remaining и RateLimit-Reset,
+// а при превышении - 429 и Retry-After.
+func RateLimit(requestsPerSecond float64, burst int) gin.HandlerFunc {
+	limiter := newTokenBucket(requestsPerSecond, burst, time.Now)
+
+	return func(c *gin.Context) {
+		result := limiter.take(rateLimitKey(c))
+
+		c.Header("RateLimit-Limit", strconv.Itoa(burst))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.reset)))
+
+		if !result.allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(result.retryAfter)))
+			utils.TooManyRequestsResponse(c, "Rate limit exceeded, retry later")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func rateLimitKey(c *gin.Context) string {
+	if subject, ok := c.Get(SubjectKey); ok {
+		if subject, ok := subject.(string); ok && subject != "" {
+			return "sub:" + subject
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// Секунды для заголовков, округленные вверх: клиент не должен повторить запрос раньше времени
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type takeResult struct {
+	allowed    bool
+	remaining  int
+	reset      time.Duration // через сколько корзина наполнится полностью
+	retryAfter time.Duration // через сколько появится токен, если запрос отклонен
+}
+
+type tokenBucket struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu          sync.Mutex
+	buckets     map[string]*bucket
+	lastCleanup time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now func() time.Time) *tokenBucket {
+	return &tokenBucket{
+		rate:        rate,
+		burst:       float64(burst),
+		now:         now,
+		buckets:     make(map[string]*bucket),
+		lastCleanup: now(),
+	}
+}
+
+func (l *tokenBucket) take(key string) takeResult {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.cleanup(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	result := takeResult{allowed: b.tokens >= 1}
+	if result.allowed {
+		b.tokens--
+	} else {
+		result.retryAfter = l.duration(1 - b.tokens)
+	}
+	result.remaining = int(b.tokens)
+	result.reset = l.duration(l.burst - b.tokens)
+	return result
+}
+
+// Время, за которое накопится tokens токенов
+func (l *tokenBucket) duration(tokens float64) time.Duration {
+	return time.Duration(tokens / l.rate * float64(time.Second))
+}
+
+// Полностью восстановившиеся корзины ничем не отличаются от новых, их можно удалить
+func (l *tokenBucket) cleanup(now time.Time) {
+	if now.Sub(l.lastCleanup) < rateLimitCleanupInterval {
+		return
+	}
+	l.lastCleanup = now
+
+	refill := l.duration(l.burst)
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"cinema/internal/models"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucketRefill(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newTokenBucket(2, 3, func() time.Time { return now })
+
+	// Корзина на 3 запроса, дальше отказ
+	for i := 2; i >= 0; i-- {
+		result := limiter.take("client")
+		assert.True(t, result.allowed)
+		assert.Equal(t, i, result.remaining)
+	}
+	result := limiter.take("client")
+	assert.False(t, result.allowed)
+	assert.Equal(t, 500*time.Millisecond, result.retryAfter)
+	assert.Equal(t, 1500*time.Millisecond, result.reset)
+
+	// Другой клиент не затронут
+	assert.True(t, limiter.take("other").allowed)
+
+	// За полсекунды при 2 запросах в секунду появляется один токен
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, limiter.take("client").allowed)
+	assert.False(t, limiter.take("client").allowed)
+}
+
+func TestTokenBucketCleanup(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newTokenBucket(1, 1, func() time.Time { return now })
+
+	limiter.take("client")
+	assert.Len(t, limiter.buckets, 1)
+
+	now = now.Add(rateLimitCleanupInterval)
+	limiter.take("other")
+	assert.Len(t, limiter.buckets, 1)
+	assert.Contains(t, limiter.buckets, "other")
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RateLimit(1, 2))
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	request := func(ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = ip + ":1234"
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := request("10.0.0.1")
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "2", first.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+
+	assert.Equal(t, http.StatusOK, request("10.0.0.1").Code)
+
+	limited := request("10.0.0.1")
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "1", limited.Header().Get("Retry-After"))
+	assert.Equal(t, "0", limited.Header().Get("RateLimit-Remaining"))
+
+	var apiErr models.APIError
+	assert.NoError(t, json.Unmarshal(limited.Body.Bytes(), &apiErr))
+	assert.Equal(t, "TOO_MANY_REQUESTS", apiErr.Code)
+
+	// Лимит считается отдельно для каждого IP
+	assert.Equal(t, http.StatusOK, request("10.0.0.2").Code)
+}
+
+func TestRateLimitKeyPrefersSubject(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	c.Request.RemoteAddr = "10.0.0.1:1234"
+
+	assert.Equal(t, "ip:10.0.0.1", rateLimitKey(c))
+
+	c.Set(SubjectKey, "user-1")
+	assert.Equal(t, "sub:user-1", rateLimitKey(c))
+}
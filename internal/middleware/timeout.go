@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Timeout ограничивает время обработки запроса: контекст запроса отменяется через d,
+// и все запросы к БД, начатые с этим контекстом, прерываются. Ответ 504 формирует контроллер.
+// Для маршрутов из routes (шаблон пути gin) действует свое время, например для потоковой выгрузки.
+func Timeout(d time.Duration, routes map[string]time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := d
+		if routeTimeout, ok := routes[c.FullPath()]; ok {
+			timeout = routeTimeout
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
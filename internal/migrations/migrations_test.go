@@ -0,0 +1,99 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadEmbedded(t *testing.T) {
+	migrations, err := Load()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+
+	for i, m := range migrations {
+		assert.NotEmpty(t, m.Up)
+		assert.NotEmpty(t, m.Down)
+		if i > 0 {
+			assert.Greater(t, m.Version, migrations[i-1].Version)
+		}
+	}
+}
+
+func TestLoadRequiresUpAndDown(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/0001_init.up.sql": {Data: []byte("CREATE TABLE t (id INT);")},
+	}
+
+	_, err := load(fsys)
+	assert.Error(t, err)
+}
+
+func TestUpSkipsAppliedMigrations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	migrator := &Migrator{db: db, migrations: []Migration{
+		{Version: 1, Name: "init", Up: "CREATE TABLE a (id INT)", Down: "DROP TABLE a"},
+		{Version: 2, Name: "more", Up: "CREATE TABLE b (id INT)", Down: "DROP TABLE b"},
+	}}
+
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// Первая миграция уже применена
+	mock.ExpectBegin()
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	// Вторая применяется и записывается в schema_migrations
+	mock.ExpectBegin()
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs(int64(2)).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(`CREATE TABLE b`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO schema_migrations`).WithArgs(int64(2), "more").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	applied, err := migrator.Up()
+	assert.NoError(t, err)
+	assert.Len(t, applied, 1)
+	assert.Equal(t, int64(2), applied[0].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAppliedVersionWithoutTable(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	migrator := &Migrator{db: db}
+
+	// Таблицы нет - версия 0, и таблица не создается
+	mock.ExpectQuery(`SELECT to_regclass`).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	version, err := migrator.AppliedVersion(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAppliedVersion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	migrator := &Migrator{db: db}
+
+	mock.ExpectQuery(`SELECT to_regclass`).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`SELECT COALESCE\(MAX\(version\), 0\) FROM schema_migrations`).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(int64(5)))
+
+	version, err := migrator.AppliedVersion(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
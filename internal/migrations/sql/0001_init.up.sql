@@ -1,12 +1,21 @@
-CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
-
 CREATE TABLE IF NOT EXISTS actors (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     name VARCHAR(255) NOT NULL,
     gender VARCHAR(100),
-    birth_date DATE 
+    date_of_birth DATE
 );
 
+-- Базы, созданные старым create.sql, хранят дату рождения в birth_date
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'actors' AND column_name = 'birth_date'
+    ) THEN
+        ALTER TABLE actors RENAME COLUMN birth_date TO date_of_birth;
+    END IF;
+END $$;
+
 CREATE TABLE IF NOT EXISTS movies (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     title VARCHAR(150) NOT NULL,
@@ -21,3 +30,4 @@ CREATE TABLE IF NOT EXISTS movie_actors (
     PRIMARY KEY (movie_id, actor_id)
 );
 
+CREATE INDEX IF NOT EXISTS idx_movie_actors_actor_id ON movie_actors (actor_id);
@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS actors_search_vector_update ON actors;
+DROP TRIGGER IF EXISTS movie_actors_search_vector_update ON movie_actors;
+DROP TRIGGER IF EXISTS movies_search_vector_update ON movies;
+DROP FUNCTION IF EXISTS actors_search_vector_trigger();
+DROP FUNCTION IF EXISTS movie_actors_search_vector_trigger();
+DROP FUNCTION IF EXISTS movies_search_vector_trigger();
+DROP FUNCTION IF EXISTS movie_refresh_search_vector(UUID);
+DROP FUNCTION IF EXISTS movie_build_search_vector(TEXT, TEXT, TEXT);
+DROP FUNCTION IF EXISTS movie_cast_names(UUID);
+DROP INDEX IF EXISTS idx_movies_search_vector;
+ALTER TABLE movies DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,70 @@
+-- Полнотекстовый поиск по фильмам: название (вес A), описание (B) и имена актеров (C).
+-- Конфигурация russian стеммит кириллицу словарем russian_stem, а латиницу - english_stem,
+-- поэтому один вектор покрывает и русский, и английский текст.
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+CREATE OR REPLACE FUNCTION movie_cast_names(p_movie_id UUID) RETURNS TEXT AS $$
+    SELECT coalesce(string_agg(a.name, ' '), '')
+    FROM movie_actors ma
+    JOIN actors a ON a.id = ma.actor_id
+    WHERE ma.movie_id = p_movie_id
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION movie_build_search_vector(p_title TEXT, p_description TEXT, p_cast TEXT) RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('russian', coalesce(p_title, '')), 'A') ||
+           setweight(to_tsvector('russian', coalesce(p_description, '')), 'B') ||
+           setweight(to_tsvector('russian', coalesce(p_cast, '')), 'C')
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION movie_refresh_search_vector(p_movie_id UUID) RETURNS VOID AS $$
+    UPDATE movies
+    SET search_vector = movie_build_search_vector(title, description, movie_cast_names(id))
+    WHERE id = p_movie_id
+$$ LANGUAGE sql;
+
+-- Изменение названия или описания фильма
+CREATE OR REPLACE FUNCTION movies_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := movie_build_search_vector(NEW.title, NEW.description, movie_cast_names(NEW.id));
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER movies_search_vector_update
+    BEFORE INSERT OR UPDATE OF title, description ON movies
+    FOR EACH ROW EXECUTE FUNCTION movies_search_vector_trigger();
+
+-- Изменение состава актеров фильма
+CREATE OR REPLACE FUNCTION movie_actors_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        PERFORM movie_refresh_search_vector(NEW.movie_id);
+    END IF;
+    IF TG_OP IN ('DELETE', 'UPDATE') THEN
+        PERFORM movie_refresh_search_vector(OLD.movie_id);
+    END IF;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER movie_actors_search_vector_update
+    AFTER INSERT OR UPDATE OR DELETE ON movie_actors
+    FOR EACH ROW EXECUTE FUNCTION movie_actors_search_vector_trigger();
+
+-- Переименование актера
+CREATE OR REPLACE FUNCTION actors_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+    PERFORM movie_refresh_search_vector(ma.movie_id)
+    FROM movie_actors ma
+    WHERE ma.actor_id = NEW.id;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER actors_search_vector_update
+    AFTER UPDATE OF name ON actors
+    FOR EACH ROW EXECUTE FUNCTION actors_search_vector_trigger();
+
+UPDATE movies SET search_vector = movie_build_search_vector(title, description, movie_cast_names(id));
+
+CREATE INDEX IF NOT EXISTS idx_movies_search_vector ON movies USING GIN (search_vector);
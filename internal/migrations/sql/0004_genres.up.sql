@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS genres (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL
+);
+
+-- Названия жанров уникальны без учета регистра
+CREATE UNIQUE INDEX IF NOT EXISTS idx_genres_name_lower ON genres (lower(name));
+
+CREATE TABLE IF NOT EXISTS movie_genres (
+    movie_id UUID REFERENCES movies(id) ON DELETE CASCADE,
+    genre_id UUID REFERENCES genres(id) ON DELETE CASCADE,
+    PRIMARY KEY (movie_id, genre_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_movie_genres_genre_id ON movie_genres (genre_id);
@@ -0,0 +1,17 @@
+-- Откат оставляет по одной связи на пару фильм-человек, предпочитая актерскую роль
+DELETE FROM movie_actors ma
+USING movie_actors other
+WHERE ma.movie_id = other.movie_id
+  AND ma.actor_id = other.actor_id
+  AND ma.role <> 'actor'
+  AND (other.role = 'actor' OR other.role < ma.role);
+
+ALTER TABLE movie_actors DROP CONSTRAINT movie_actors_pkey;
+ALTER TABLE movie_actors ADD PRIMARY KEY (movie_id, actor_id);
+
+ALTER TABLE movie_actors
+    DROP CONSTRAINT IF EXISTS movie_actors_character_check,
+    DROP CONSTRAINT IF EXISTS movie_actors_role_check,
+    DROP COLUMN IF EXISTS billing_order,
+    DROP COLUMN IF EXISTS character_name,
+    DROP COLUMN IF EXISTS role;
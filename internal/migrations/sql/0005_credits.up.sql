@@ -0,0 +1,18 @@
+-- Связь фильма и человека становится записью титров: роль, персонаж и порядок в титрах.
+-- Один человек может иметь в фильме несколько ролей (например, режиссер и актер).
+ALTER TABLE movie_actors
+    ADD COLUMN IF NOT EXISTS role VARCHAR(30) NOT NULL DEFAULT 'actor',
+    ADD COLUMN IF NOT EXISTS character_name VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS billing_order INTEGER CHECK (billing_order >= 0);
+
+ALTER TABLE movie_actors
+    ADD CONSTRAINT movie_actors_role_check
+    CHECK (role IN ('actor', 'director', 'writer', 'producer', 'composer', 'cinematographer', 'editor'));
+
+-- Имя персонажа есть только у актерских ролей
+ALTER TABLE movie_actors
+    ADD CONSTRAINT movie_actors_character_check
+    CHECK (character_name IS NULL OR role = 'actor');
+
+ALTER TABLE movie_actors DROP CONSTRAINT movie_actors_pkey;
+ALTER TABLE movie_actors ADD PRIMARY KEY (movie_id, actor_id, role);
@@ -0,0 +1,7 @@
+ALTER TABLE actors
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS version;
+
+ALTER TABLE movies
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS version;
@@ -0,0 +1,9 @@
+-- Версия записи для ETag и оптимистичных блокировок: каждое изменение увеличивает version,
+-- а UPDATE/DELETE с If-Match выполняются только при совпадении версии
+ALTER TABLE movies
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+ALTER TABLE actors
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
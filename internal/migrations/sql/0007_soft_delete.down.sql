@@ -0,0 +1,21 @@
+-- Записи из корзины при откате удаляются окончательно, иначе они снова стали бы видны
+DELETE FROM movies WHERE deleted_at IS NOT NULL;
+DELETE FROM actors WHERE deleted_at IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION movie_cast_names(p_movie_id UUID) RETURNS TEXT AS $$
+    SELECT coalesce(string_agg(a.name, ' '), '')
+    FROM movie_actors ma
+    JOIN actors a ON a.id = ma.actor_id
+    WHERE ma.movie_id = p_movie_id
+$$ LANGUAGE sql STABLE;
+
+DROP TRIGGER IF EXISTS actors_search_vector_update ON actors;
+CREATE TRIGGER actors_search_vector_update
+    AFTER UPDATE OF name ON actors
+    FOR EACH ROW EXECUTE FUNCTION actors_search_vector_trigger();
+
+DROP INDEX IF EXISTS idx_actors_deleted_at;
+DROP INDEX IF EXISTS idx_movies_deleted_at;
+
+ALTER TABLE actors DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE movies DROP COLUMN IF EXISTS deleted_at;
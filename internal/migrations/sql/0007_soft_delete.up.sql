@@ -0,0 +1,22 @@
+-- Удаление фильмов и актеров переносит их в корзину: deleted_at заполнен, связи сохраняются.
+-- Записи старше срока хранения удаляются окончательно фоновой очисткой.
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE actors ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+-- Для списка корзины и очистки по сроку
+CREATE INDEX IF NOT EXISTS idx_movies_deleted_at ON movies (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_actors_deleted_at ON actors (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Актеры в корзине не видны в публичных запросах, поэтому их имена не участвуют и в поиске.
+-- Перенос актера в корзину и восстановление пересчитывают вектор его фильмов, как и переименование.
+CREATE OR REPLACE FUNCTION movie_cast_names(p_movie_id UUID) RETURNS TEXT AS $$
+    SELECT coalesce(string_agg(a.name, ' '), '')
+    FROM movie_actors ma
+    JOIN actors a ON a.id = ma.actor_id
+    WHERE ma.movie_id = p_movie_id AND a.deleted_at IS NULL
+$$ LANGUAGE sql STABLE;
+
+DROP TRIGGER IF EXISTS actors_search_vector_update ON actors;
+CREATE TRIGGER actors_search_vector_update
+    AFTER UPDATE OF name, deleted_at ON actors
+    FOR EACH ROW EXECUTE FUNCTION actors_search_vector_trigger();
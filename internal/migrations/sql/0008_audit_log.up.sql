@@ -0,0 +1,20 @@
+-- Журнал изменений, сделанных через админские маршруты. Запись добавляется в той же транзакции,
+-- что и само изменение: откаченное изменение в журнал не попадает, а закоммиченное всегда в нем есть.
+-- Внешних ключей нет, записи журнала переживают окончательное удаление сущности.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    subject VARCHAR(255) NOT NULL,
+    role VARCHAR(50) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    entity_type VARCHAR(30) NOT NULL,
+    entity_id UUID NOT NULL,
+    before JSONB,
+    after JSONB,
+    request_id VARCHAR(128),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- История одной сущности и действия одного пользователя, новые записи первыми
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log (entity_type, entity_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_subject ON audit_log (subject, id DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log (created_at);
@@ -0,0 +1,3 @@
+-- Файлы в хранилище остаются, удалять их нужно вручную
+ALTER TABLE actors DROP COLUMN IF EXISTS headshot_key;
+ALTER TABLE movies DROP COLUMN IF EXISTS poster_key;
@@ -0,0 +1,4 @@
+-- Ключи изображений в хранилище файлов: постер фильма и фотография актера.
+-- Сами файлы хранятся вне БД и удаляются вместе с записью при очистке корзины.
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS poster_key TEXT;
+ALTER TABLE actors ADD COLUMN IF NOT EXISTS headshot_key TEXT;
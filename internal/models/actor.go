@@ -11,6 +11,9 @@ type Actor struct {
 	Name        string    `json:"name"`
 	Gender      string    `json:"gender"`
 	DateOfBirth time.Time `json:"date_of_birth"`
+	HeadshotURL string    `json:"headshot_url,omitempty"` // пусто, если фотография не загружена; уменьшенные копии - с параметром size
+	Version     int64     `json:"version"`                // увеличивается при каждом изменении, из него строится ETag
+	UpdatedAt   time.Time `json:"updated_at"`             // время последнего изменения
 }
 
 type ActorWithMovies struct {
@@ -1,5 +1,10 @@
 package models
 
+import (
+	"errors"
+	"strings"
+)
+
 type APIError struct {
 	Code    string      `json:"code"`              // Код ошибки (например, VALIDATION_ERROR, INVALID_JSON, etc.)
 	Message string      `json:"message"`           // Общее описание ошибки
@@ -10,3 +15,23 @@ type ValidationError struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
 }
+
+// Запись с таким уникальным значением уже существует
+var ErrAlreadyExists = errors.New("already exists")
+
+// Записи нет или она в корзине
+var ErrNotFound = errors.New("not found")
+
+// Версия записи не совпала с ожидаемой (If-Match): запись изменили или удалили после чтения
+var ErrVersionMismatch = errors.New("version mismatch")
+
+// Ошибки проверки, возвращаемые сервисом как error, например для результата PATCH
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Field+": "+err.Message)
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Действия в журнале аудита
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+)
+
+// Типы сущностей в журнале аудита
+const (
+	AuditMovie        = "movie"
+	AuditMovieCredits = "movie_credits" // титры фильма, ID сущности - ID фильма
+	AuditActor        = "actor"
+	AuditGenre        = "genre"
+)
+
+// Запись журнала аудита. Before и After - состояние сущности до и после изменения,
+// null для создания и окончательного удаления соответственно.
+type AuditEntry struct {
+	ID         int64           `json:"id"`
+	Subject    string          `json:"subject"` // sub из JWT
+	Role       string          `json:"role"`
+	Action     string          `json:"action" enums:"create,update,delete,restore"`
+	EntityType string          `json:"entity_type" enums:"movie,movie_credits,actor,genre"`
+	EntityID   uuid.UUID       `json:"entity_id"`
+	Before     json.RawMessage `json:"before" swaggertype:"object"`
+	After      json.RawMessage `json:"after" swaggertype:"object"`
+	RequestID  string          `json:"request_id,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// Фильтр журнала аудита, пустые поля не ограничивают выборку
+type AuditFilter struct {
+	Subject    string
+	Action     string
+	EntityType string
+	EntityID   *uuid.UUID
+	From       *time.Time // включительно
+	To         *time.Time // не включительно
+}
+
+func (f AuditFilter) Validate() []ValidationError {
+	var errs []ValidationError
+
+	switch f.Action {
+	case "", AuditCreate, AuditUpdate, AuditDelete, AuditRestore:
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "action",
+			Message: "Action must be one of: create, update, delete, restore",
+		})
+	}
+
+	switch f.EntityType {
+	case "", AuditMovie, AuditMovieCredits, AuditActor, AuditGenre:
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "entity_type",
+			Message: "Entity type must be one of: movie, movie_credits, actor, genre",
+		})
+	}
+
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		errs = append(errs, ValidationError{
+			Field:   "from",
+			Message: "From must be earlier than to",
+		})
+	}
+
+	return errs
+}
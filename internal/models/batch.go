@@ -0,0 +1,21 @@
+package models
+
+import "github.com/google/uuid"
+
+// Максимальное число фильмов в одном пакетном запросе
+const MaxBatchSize = 100
+
+// Результат для одного элемента пакета: ID созданной записи или ошибки проверки.
+// Если в пакете без best_effort есть ошибки, у остальных элементов нет ни ID, ни ошибок.
+type BatchItemResult struct {
+	Index  int               `json:"index"`
+	ID     *uuid.UUID        `json:"id,omitempty"`
+	Errors []ValidationError `json:"errors,omitempty"`
+}
+
+// Итог пакетного создания, элементы Items идут в порядке запроса
+type BatchResult struct {
+	Created int               `json:"created"`
+	Failed  int               `json:"failed"`
+	Items   []BatchItemResult `json:"items"`
+}
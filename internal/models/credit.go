@@ -0,0 +1,135 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Роли в титрах фильма. Актер относится к касту, остальные роли - к съемочной группе.
+const (
+	RoleActor           = "actor"
+	RoleDirector        = "director"
+	RoleWriter          = "writer"
+	RoleProducer        = "producer"
+	RoleComposer        = "composer"
+	RoleCinematographer = "cinematographer"
+	RoleEditor          = "editor"
+)
+
+var creditRoles = map[string]struct{}{
+	RoleActor:           {},
+	RoleDirector:        {},
+	RoleWriter:          {},
+	RoleProducer:        {},
+	RoleComposer:        {},
+	RoleCinematographer: {},
+	RoleEditor:          {},
+}
+
+// Запись титров в запросах на добавление и замену связей фильма
+type CreditInput struct {
+	ActorID   uuid.UUID `json:"actor_id"`
+	Role      string    `json:"role" example:"actor"`
+	Character *string   `json:"character,omitempty"`
+	Order     *int      `json:"order,omitempty"`
+}
+
+// UnmarshalJSON принимает и объект, и просто ID актера строкой, как в старом формате API.
+// Роль по умолчанию - actor.
+func (c *CreditInput) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '"' {
+		var id uuid.UUID
+		if err := json.Unmarshal(trimmed, &id); err != nil {
+			return err
+		}
+		*c = CreditInput{ActorID: id, Role: RoleActor}
+		return nil
+	}
+
+	type plain CreditInput
+	var credit plain
+	if err := json.Unmarshal(data, &credit); err != nil {
+		return err
+	}
+	if credit.Role == "" {
+		credit.Role = RoleActor
+	}
+	*c = CreditInput(credit)
+	return nil
+}
+
+// Запись титров в ответе
+type Credit struct {
+	ActorID   uuid.UUID `json:"actor_id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	Character *string   `json:"character,omitempty"`
+	Order     *int      `json:"order,omitempty"`
+}
+
+// Титры фильма: актеры и съемочная группа, каждый список упорядочен по order, затем по имени
+type MovieCredits struct {
+	Cast []Credit `json:"cast"`
+	Crew []Credit `json:"crew"`
+}
+
+// Validate для списка титров
+func ValidateCredits(credits []CreditInput) []ValidationError {
+	var errs []ValidationError
+
+	type key struct {
+		actorID uuid.UUID
+		role    string
+	}
+	seen := make(map[key]struct{}, len(credits))
+
+	for i, credit := range credits {
+		field := fmt.Sprintf("credits[%d]", i)
+
+		if credit.ActorID == uuid.Nil {
+			errs = append(errs, ValidationError{Field: field + ".actor_id", Message: "Actor ID is required"})
+		}
+		if _, ok := creditRoles[credit.Role]; !ok {
+			errs = append(errs, ValidationError{Field: field + ".role", Message: fmt.Sprintf("Unknown role: %s", credit.Role)})
+		}
+
+		// Character max=255, только для актеров
+		if credit.Character != nil {
+			if credit.Role != RoleActor {
+				errs = append(errs, ValidationError{Field: field + ".character", Message: "Character can only be set for the actor role"})
+			} else if utf8.RuneCountInString(*credit.Character) > 255 {
+				errs = append(errs, ValidationError{Field: field + ".character", Message: "Character must not exceed 255 characters"})
+			}
+		}
+
+		if credit.Order != nil && *credit.Order < 0 {
+			errs = append(errs, ValidationError{Field: field + ".order", Message: "Order must not be negative"})
+		}
+
+		k := key{actorID: credit.ActorID, role: credit.Role}
+		if _, ok := seen[k]; ok {
+			errs = append(errs, ValidationError{Field: field, Message: "Duplicate actor and role"})
+		}
+		seen[k] = struct{}{}
+	}
+
+	return errs
+}
+
+// Титры с ролью actor для списка ID актеров, повторы отбрасываются
+func CastCredits(actorIDs []uuid.UUID) []CreditInput {
+	credits := make([]CreditInput, 0, len(actorIDs))
+	seen := make(map[uuid.UUID]struct{}, len(actorIDs))
+	for _, id := range actorIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		credits = append(credits, CreditInput{ActorID: id, Role: RoleActor})
+	}
+	return credits
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreditInputAcceptsObjectsAndPlainIDs(t *testing.T) {
+	actorID := uuid.New()
+	body := `["` + actorID.String() + `", {"actor_id": "` + actorID.String() + `", "role": "director", "order": 1}]`
+
+	var credits []CreditInput
+	assert.NoError(t, json.Unmarshal([]byte(body), &credits))
+	assert.Len(t, credits, 2)
+	assert.Equal(t, CreditInput{ActorID: actorID, Role: RoleActor}, credits[0])
+	assert.Equal(t, RoleDirector, credits[1].Role)
+	assert.Equal(t, 1, *credits[1].Order)
+	assert.Empty(t, ValidateCredits(credits))
+}
+
+func TestValidateCredits(t *testing.T) {
+	actorID := uuid.New()
+	character := "Cobb"
+	negative := -1
+	credits := []CreditInput{
+		{ActorID: actorID, Role: RoleActor},
+		{ActorID: actorID, Role: RoleActor},
+		{ActorID: actorID, Role: RoleDirector, Character: &character},
+		{ActorID: actorID, Role: "stuntman", Order: &negative},
+	}
+
+	errs := ValidateCredits(credits)
+	fields := make([]string, 0, len(errs))
+	for _, err := range errs {
+		fields = append(fields, err.Field)
+	}
+	assert.ElementsMatch(t, []string{"credits[1]", "credits[2].character", "credits[3].role", "credits[3].order"}, fields)
+}
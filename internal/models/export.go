@@ -0,0 +1,62 @@
+package models
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Что выгружается из каталога
+const (
+	ExportMovies  = "movies"
+	ExportActors  = "actors"
+	ExportCredits = "credits"
+)
+
+type ExportOptions struct {
+	Kind   string
+	Format string
+	// Фильтр фильмов для movies и credits: выгружаются титры только подходящих фильмов.
+	// Для actors фильтр должен быть пустым, ActorMatch при этом не учитывается.
+	Filter MovieFilter
+	// Только записи, измененные начиная с этого момента; для movies и actors
+	UpdatedSince *time.Time
+}
+
+// Validate проверяет вид, формат и их сочетание с фильтрами; сам фильтр проверяет Filter.Validate()
+func (o ExportOptions) Validate() []ValidationError {
+	var errs []ValidationError
+
+	switch o.Kind {
+	case ExportMovies, ExportActors, ExportCredits:
+	default:
+		errs = append(errs, ValidationError{Field: "kind", Message: "Kind must be one of: movies, actors, credits"})
+	}
+
+	switch o.Format {
+	case FormatJSON, FormatNDJSON, FormatCSV:
+	default:
+		errs = append(errs, ValidationError{Field: "format", Message: "Format must be one of: json, ndjson, csv"})
+	}
+
+	// Изменение титров не меняет версию фильма, поэтому отбор по времени для них неточен
+	if o.Kind == ExportCredits && o.UpdatedSince != nil {
+		errs = append(errs, ValidationError{Field: "updated_since", Message: "updated_since is not supported for credits"})
+	}
+
+	if o.Kind == ExportActors && !reflect.DeepEqual(o.Filter, MovieFilter{ActorMatch: o.Filter.ActorMatch}) {
+		errs = append(errs, ValidationError{Field: "kind", Message: "Movie filters are not supported for actors"})
+	}
+
+	return errs
+}
+
+// Запись титров в выгрузке: связь фильма и человека
+type ExportCredit struct {
+	MovieID   uuid.UUID `json:"movie_id"`
+	ActorID   uuid.UUID `json:"actor_id"`
+	Role      string    `json:"role"`
+	Character *string   `json:"character"`
+	Order     *int      `json:"order"`
+}
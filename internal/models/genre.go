@@ -0,0 +1,53 @@
+package models
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+type Genre struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+type CreateGenre struct {
+	Name string `json:"name"`
+}
+
+type UpdateGenre struct {
+	Name *string `json:"name"`
+}
+
+// Validate для CreateGenre
+func (cg CreateGenre) Validate() []ValidationError {
+	var errs []ValidationError
+
+	// Name min=1 max=100 символов (не байт)
+	if name := strings.TrimSpace(cg.Name); utf8.RuneCountInString(name) < 1 || utf8.RuneCountInString(name) > 100 {
+		errs = append(errs, ValidationError{
+			Field:   "name",
+			Message: "Name must be between 1 and 100 characters",
+		})
+	}
+
+	return errs
+}
+
+// Validate для UpdateGenre
+func (ug UpdateGenre) Validate() []ValidationError {
+	var errs []ValidationError
+
+	// Name min=1 max=100
+	if ug.Name != nil {
+		if name := strings.TrimSpace(*ug.Name); utf8.RuneCountInString(name) < 1 || utf8.RuneCountInString(name) > 100 {
+			errs = append(errs, ValidationError{
+				Field:   "name",
+				Message: "Name must be between 1 and 100 characters",
+			})
+		}
+	}
+
+	return errs
+}
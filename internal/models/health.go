@@ -0,0 +1,19 @@
+package models
+
+const (
+	HealthStatusOK   = "ok"
+	HealthStatusFail = "fail"
+)
+
+// Результат проверки одной зависимости
+type HealthCheck struct {
+	Status     string  `json:"status" example:"ok"`
+	Error      string  `json:"error,omitempty"`
+	DurationMs float64 `json:"duration_ms" example:"1.25"`
+}
+
+// Ответ /healthz и /readyz: общий статус и разбивка по проверкам
+type HealthReport struct {
+	Status string                 `json:"status" example:"ok"`
+	Checks map[string]HealthCheck `json:"checks,omitempty"`
+}
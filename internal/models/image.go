@@ -0,0 +1,24 @@
+package models
+
+import (
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// Адреса изображений в API. Изображения отдает сам сервис, поэтому адрес не зависит от хранилища.
+func MoviePosterURL(movieID uuid.UUID) string {
+	return "/api/movies/" + movieID.String() + "/poster"
+}
+
+func ActorHeadshotURL(actorID uuid.UUID) string {
+	return "/api/actors/" + actorID.String() + "/headshot"
+}
+
+// Изображение из хранилища для ответа. ETag меняется при каждой загрузке нового файла.
+// Body должен закрыть вызывающий код.
+type Image struct {
+	Body        io.ReadCloser
+	ContentType string
+	ETag        string
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Что импортируется из файла
+const (
+	ImportActors  = "actors"
+	ImportMovies  = "movies"
+	ImportCredits = "credits"
+)
+
+// Форматы файлов импорта и выгрузки
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	FormatJSON   = "json" // один JSON-массив, только для выгрузки
+)
+
+type ImportOptions struct {
+	Kind   string
+	Format string
+	DryRun bool // строки проверяются и записываются, но транзакция откатывается
+	Atomic bool // все или ничего: при ошибке хотя бы в одной строке не сохраняется ни одна
+}
+
+// Ссылка на актера в файле импорта: по ID или по имени и дате рождения
+type ActorRef struct {
+	ID          *uuid.UUID
+	Name        string
+	DateOfBirth *time.Time
+}
+
+// Ссылка на фильм в файле импорта: по ID или по названию и дате выхода
+type MovieRef struct {
+	ID          *uuid.UUID
+	Title       string
+	ReleaseDate *time.Time
+}
+
+// Строка импорта титров: фильм и человек задаются по ID или по естественному ключу
+type ImportCredit struct {
+	MovieID          *uuid.UUID `json:"movie_id"`
+	MovieTitle       string     `json:"movie_title"`
+	MovieReleaseDate *time.Time `json:"movie_release_date"`
+	ActorID          *uuid.UUID `json:"actor_id"`
+	ActorName        string     `json:"actor_name"`
+	ActorDateOfBirth *time.Time `json:"actor_date_of_birth"`
+	Role             string     `json:"role"`
+	Character        *string    `json:"character"`
+	Order            *int       `json:"order"`
+}
+
+func (c ImportCredit) Movie() MovieRef {
+	return MovieRef{ID: c.MovieID, Title: c.MovieTitle, ReleaseDate: c.MovieReleaseDate}
+}
+
+func (c ImportCredit) Actor() ActorRef {
+	return ActorRef{ID: c.ActorID, Name: c.ActorName, DateOfBirth: c.ActorDateOfBirth}
+}
+
+// Validate проверяет только ссылки; сама запись титров проверяется ValidateCredits
+// после того, как ссылка на актера разрешена в ID
+func (c ImportCredit) Validate() []ValidationError {
+	var errs []ValidationError
+
+	if c.MovieID == nil && (c.MovieTitle == "" || c.MovieReleaseDate == nil) {
+		errs = append(errs, ValidationError{
+			Field:   "movie_id",
+			Message: "Movie ID or both movie_title and movie_release_date are required",
+		})
+	}
+	if c.ActorID == nil && (c.ActorName == "" || c.ActorDateOfBirth == nil) {
+		errs = append(errs, ValidationError{
+			Field:   "actor_id",
+			Message: "Actor ID or both actor_name and actor_date_of_birth are required",
+		})
+	}
+
+	return errs
+}
+
+// Ошибки одной строки файла
+type ImportRowError struct {
+	Row    int               `json:"row"` // номер строки в файле, начиная с 1; заголовок CSV - строка 1
+	Errors []ValidationError `json:"errors"`
+}
+
+// Итог импорта. Imported - число строк без ошибок; они сохранены, только если Committed.
+type ImportResult struct {
+	Kind      string           `json:"kind" enums:"actors,movies,credits"`
+	DryRun    bool             `json:"dry_run"`
+	Atomic    bool             `json:"atomic"`
+	Rows      int              `json:"rows"`
+	Imported  int              `json:"imported"`
+	Committed bool             `json:"committed"`
+	Errors    []ImportRowError `json:"errors"`
+}
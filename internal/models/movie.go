@@ -11,15 +11,28 @@ type Movie struct {
 	Title       string    `json:"title"`
 	Description string    `json:"description"`
 	ReleaseDate time.Time `json:"release_date"`
-	Rating      float64   `json:"rating"`
+	Rating      *float64  `json:"rating" extensions:"x-nullable"` // null, если у фильма нет рейтинга
+	Genres      []Genre   `json:"genres"`
+	PosterURL   string    `json:"poster_url,omitempty"` // пусто, если постер не загружен; уменьшенные копии - с параметром size
+	Version     int64     `json:"version"`              // увеличивается при каждом изменении, из него строится ETag
+	UpdatedAt   time.Time `json:"updated_at"`           // время последнего изменения
+}
+
+// Результат полнотекстового поиска: найденные фрагменты обрамлены тегами <b></b>
+type MovieSearchResult struct {
+	Movie
+	Rank               float64 `json:"rank"`
+	TitleHighlight     string  `json:"title_highlight"`
+	DescriptionSnippet string  `json:"description_snippet"`
 }
 
 type CreateMovie struct {
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	ReleaseDate time.Time `json:"release_date"`
-	Rating      float64   `json:"rating"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	ReleaseDate time.Time   `json:"release_date"`
+	Rating      float64     `json:"rating"`
 	ActorIDs    []uuid.UUID `json:"actor_ids"`
+	GenreIDs    []uuid.UUID `json:"genre_ids"`
 }
 
 type MovieWithActors struct {
@@ -28,11 +41,12 @@ type MovieWithActors struct {
 }
 
 type UpdateMovie struct {
-	Title       *string    `json:"title"`
-	Description *string    `json:"description"`
-	ReleaseDate *time.Time `json:"release_date"`
-	Rating      *float64   `json:"rating"`
+	Title       *string      `json:"title"`
+	Description *string      `json:"description"`
+	ReleaseDate *time.Time   `json:"release_date"`
+	Rating      *float64     `json:"rating"`
 	ActorIDs    *[]uuid.UUID `json:"actor_ids"`
+	GenreIDs    *[]uuid.UUID `json:"genre_ids"`
 }
 
 // Validation struct
@@ -84,6 +98,14 @@ func (cm CreateMovie) Validate() []ValidationError {
 		})
 	}
 
+	// GenreIDs max_count = 20
+	if len(cm.GenreIDs) > 20 {
+		errs = append(errs, ValidationError{
+			Field:   "genre_ids",
+			Message: "Too many genres in the list, maximum allowed is 20",
+		})
+	}
+
 	return errs
 }
 
@@ -130,6 +152,14 @@ func (um UpdateMovie) Validate() []ValidationError {
 		}
 	}
 
+	// GenreIDs max_count = 20
+	if um.GenreIDs != nil && len(*um.GenreIDs) > 20 {
+		errs = append(errs, ValidationError{
+			Field:   "genre_ids",
+			Message: "Too many genres in the list, maximum allowed is 20",
+		})
+	}
+
 	// ActorIDs max_count = 100
 	// if len(*um.ActorIDs) > 100 {
 	// 	errs = append(errs, ValidationError{
@@ -140,3 +170,110 @@ func (um UpdateMovie) Validate() []ValidationError {
 
 	return errs
 }
+
+// Режимы фильтра по актерам: хотя бы один из списка или все сразу
+const (
+	ActorMatchAny = "any"
+	ActorMatchAll = "all"
+)
+
+// Фильтр списка фильмов, пустые поля не ограничивают выборку
+type MovieFilter struct {
+	MinRating      *float64
+	MaxRating      *float64
+	ReleasedFrom   *time.Time // включительно
+	ReleasedTo     *time.Time // включительно
+	YearFrom       *int
+	YearTo         *int
+	ActorIDs       []uuid.UUID
+	ActorMatch     string
+	GenreIDs       []uuid.UUID // фильм должен относиться хотя бы к одному из жанров
+	TitlePrefix    string
+	HasDescription *bool
+}
+
+func (mf MovieFilter) Validate() []ValidationError {
+	var errs []ValidationError
+
+	// Rating min=0 max=10, min_rating <= max_rating
+	if mf.MinRating != nil && (*mf.MinRating < 0 || *mf.MinRating > 10) {
+		errs = append(errs, ValidationError{
+			Field:   "min_rating",
+			Message: "Minimum rating must be between 0 and 10",
+		})
+	}
+	if mf.MaxRating != nil && (*mf.MaxRating < 0 || *mf.MaxRating > 10) {
+		errs = append(errs, ValidationError{
+			Field:   "max_rating",
+			Message: "Maximum rating must be between 0 and 10",
+		})
+	}
+	if mf.MinRating != nil && mf.MaxRating != nil && *mf.MinRating > *mf.MaxRating {
+		errs = append(errs, ValidationError{
+			Field:   "min_rating",
+			Message: "Minimum rating must not exceed maximum rating",
+		})
+	}
+
+	// release_from <= release_to
+	if mf.ReleasedFrom != nil && mf.ReleasedTo != nil && mf.ReleasedFrom.After(*mf.ReleasedTo) {
+		errs = append(errs, ValidationError{
+			Field:   "release_from",
+			Message: "Release date range start must not be after its end",
+		})
+	}
+
+	// Year min=1800 max=9999, year_from <= year_to
+	if mf.YearFrom != nil && (*mf.YearFrom < 1800 || *mf.YearFrom > 9999) {
+		errs = append(errs, ValidationError{
+			Field:   "year_from",
+			Message: "Year must be between 1800 and 9999",
+		})
+	}
+	if mf.YearTo != nil && (*mf.YearTo < 1800 || *mf.YearTo > 9999) {
+		errs = append(errs, ValidationError{
+			Field:   "year_to",
+			Message: "Year must be between 1800 and 9999",
+		})
+	}
+	if mf.YearFrom != nil && mf.YearTo != nil && *mf.YearFrom > *mf.YearTo {
+		errs = append(errs, ValidationError{
+			Field:   "year_from",
+			Message: "Year range start must not be after its end",
+		})
+	}
+
+	// ActorIDs max_count = 100
+	if len(mf.ActorIDs) > 100 {
+		errs = append(errs, ValidationError{
+			Field:   "actor_ids",
+			Message: "Too many actors in the filter, maximum allowed is 100",
+		})
+	}
+
+	// GenreIDs max_count = 20
+	if len(mf.GenreIDs) > 20 {
+		errs = append(errs, ValidationError{
+			Field:   "genre_ids",
+			Message: "Too many genres in the filter, maximum allowed is 20",
+		})
+	}
+
+	// ActorMatch must be "any" or "all"
+	if mf.ActorMatch != ActorMatchAny && mf.ActorMatch != ActorMatchAll {
+		errs = append(errs, ValidationError{
+			Field:   "actor_match",
+			Message: "Actor match must be 'any' or 'all'",
+		})
+	}
+
+	// TitlePrefix max=150
+	if len(mf.TitlePrefix) > 150 {
+		errs = append(errs, ValidationError{
+			Field:   "title_prefix",
+			Message: "Title prefix must not exceed 150 characters",
+		})
+	}
+
+	return errs
+}
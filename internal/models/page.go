@@ -0,0 +1,23 @@
+package models
+
+// Страница списка при курсорной пагинации.
+// NextCursor равен null на последней странице, Total заполняется только по запросу include_total=true.
+type Page[T any] struct {
+	Items      []T     `json:"items"`
+	NextCursor *string `json:"next_cursor"`
+	Total      *int64  `json:"total,omitempty"`
+}
+
+// Параметры запроса страницы
+type PageRequest struct {
+	Limit        int
+	Cursor       string
+	IncludeTotal bool
+}
+
+// Конкретные страницы для документации swagger, который не разбирает обобщенные типы
+type (
+	MoviePage           = Page[Movie]
+	ActorPage           = Page[Actor]
+	ActorWithMoviesPage = Page[ActorWithMovies]
+)
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Изменяемые поля фильма - документ, к которому PATCH применяет JSON Merge Patch (RFC 7396).
+// Необязательные поля без значения отсутствуют в документе, null в патче очищает поле.
+type MovieFields struct {
+	Title       string      `json:"title"`
+	Description *string     `json:"description,omitempty"`
+	ReleaseDate time.Time   `json:"release_date"`
+	Rating      *float64    `json:"rating,omitempty"`
+	GenreIDs    []uuid.UUID `json:"genre_ids"`
+}
+
+// Результат патча проверяется по тем же правилам, что и новый фильм
+func (f MovieFields) Validate() []ValidationError {
+	movie := CreateMovie{Title: f.Title, ReleaseDate: f.ReleaseDate, GenreIDs: f.GenreIDs}
+	if f.Description != nil {
+		movie.Description = *f.Description
+	}
+	if f.Rating != nil {
+		movie.Rating = *f.Rating
+	}
+	return movie.Validate()
+}
+
+// Изменяемые поля актера для PATCH, пол и дату рождения можно очистить
+type ActorFields struct {
+	Name        string     `json:"name"`
+	Gender      *string    `json:"gender,omitempty"`
+	DateOfBirth *time.Time `json:"date_of_birth,omitempty"`
+}
+
+// Имя обязательно, пол и дата рождения проверяются, только если заданы
+func (f ActorFields) Validate() []ValidationError {
+	return UpdateActor{Name: &f.Name, Gender: f.Gender, DateOfBirth: f.DateOfBirth}.Validate()
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Фильм в корзине: не виден в публичных запросах, пока его не восстановят
+type TrashedMovie struct {
+	Movie
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// Актер в корзине: не виден в публичных запросах и титрах, пока его не восстановят
+type TrashedActor struct {
+	Actor
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// Итог очистки корзины
+type PurgeResult struct {
+	Movies int64 `json:"movies"`
+	Actors int64 `json:"actors"`
+}
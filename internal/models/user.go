@@ -0,0 +1,46 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+type User struct {
+	ID           uuid.UUID `json:"id"`
+	Username     string    `json:"username"`
+	PasswordHash string    `json:"-"`
+	Role         string    `json:"role"`
+}
+
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type LoginResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"` // Время жизни токена в секундах
+}
+
+// Validate для LoginRequest
+func (lr LoginRequest) Validate() []ValidationError {
+	var errs []ValidationError
+
+	// Username min=1 max=100
+	if len(lr.Username) < 1 || len(lr.Username) > 100 {
+		errs = append(errs, ValidationError{
+			Field:   "username",
+			Message: "Username must be between 1 and 100 characters",
+		})
+	}
+
+	// Password required, bcrypt учитывает только первые 72 байта
+	if len(lr.Password) < 1 || len(lr.Password) > 72 {
+		errs = append(errs, ValidationError{
+			Field:   "password",
+			Message: "Password must be between 1 and 72 characters",
+		})
+	}
+
+	return errs
+}
@@ -0,0 +1,56 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor указывает на последнюю запись страницы: значение ключа сортировки и ID
+// (ID разрешает совпадения ключа). SortBy и Order фиксируют сортировку,
+// с которой курсор был выдан, чтобы его нельзя было применить к другой.
+// Null означает, что ключ сортировки последней записи NULL, Key в этом случае пуст.
+type Cursor struct {
+	SortBy string    `json:"s,omitempty"`
+	Order  string    `json:"o,omitempty"`
+	Key    string    `json:"k"`
+	Null   bool      `json:"n,omitempty"`
+	ID     uuid.UUID `json:"id"`
+}
+
+// Encode превращает курсор в непрозрачную для клиента строку
+func Encode(cursor Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode разбирает строку курсора и проверяет, что он выдан для той же сортировки.
+// Пустая строка означает первую страницу, в этом случае возвращается nil.
+func Decode(token, sortBy, order string) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if cursor.ID == uuid.Nil {
+		return nil, fmt.Errorf("%w: missing id", ErrInvalidCursor)
+	}
+	if cursor.SortBy != sortBy || cursor.Order != order {
+		return nil, fmt.Errorf("%w: cursor was issued for a different sort order", ErrInvalidCursor)
+	}
+
+	return &cursor, nil
+}
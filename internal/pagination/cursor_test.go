@@ -0,0 +1,32 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	cursor := Cursor{SortBy: "rating", Order: "DESC", Key: "8.8", ID: uuid.New()}
+
+	decoded, err := Decode(Encode(cursor), "rating", "DESC")
+	assert.NoError(t, err)
+	assert.Equal(t, &cursor, decoded)
+}
+
+func TestDecodeEmpty(t *testing.T) {
+	decoded, err := Decode("", "rating", "DESC")
+	assert.NoError(t, err)
+	assert.Nil(t, decoded)
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	_, err := Decode("not a cursor!", "", "")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	// Курсор другой сортировки не принимается
+	token := Encode(Cursor{SortBy: "title", Order: "ASC", Key: "Alien", ID: uuid.New()})
+	_, err = Decode(token, "rating", "DESC")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
@@ -1,27 +1,19 @@
 package postgres
 
 import (
+	"cinema/internal/config"
 	"database/sql"
-	"fmt"
 	"log"
 )
 
-const (
-	host     = "localhost"
-	port     = 5432
-	user     = "postgres"
-	password = "123456"
-	dbname   = "cinematheque"
-)
-
-func ConnectDB() (*sql.DB, error) {
-	psqlInfo := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable", host, port, user, password, dbname)
-	db, err := sql.Open("postgres", psqlInfo)
+func ConnectDB(cfg config.Database) (*sql.DB, error) {
+	db, err := sql.Open("postgres", cfg.DSN())
 	if err != nil {
 		return nil, err
 	}
 
 	if err = db.Ping(); err != nil {
+		db.Close()
 		return nil, err
 	}
 
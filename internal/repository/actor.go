@@ -1,10 +1,14 @@
 package repository
 
 import (
+	"cinema/internal/logger"
+	"cinema/internal/metrics"
 	"cinema/internal/models"
+	"cinema/internal/pagination"
+	"context"
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -13,15 +17,23 @@ import (
 )
 
 type actor struct {
-	db *sql.DB
+	db      *sql.DB
+	log     *slog.Logger
+	metrics *metrics.Metrics
 }
 
-func NewActor(db *sql.DB) *actor {
-	return &actor{db: db}
+func NewActor(db *sql.DB, log *slog.Logger, metrics *metrics.Metrics) *actor {
+	return &actor{db: db, log: log, metrics: metrics}
+}
+
+func (a *actor) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	return beginTransaction(ctx, a.db, a.log)
 }
 
 // Добавить актера
-func (a *actor) CreateActor(actor models.CreateActor) (uuid.UUID, error) {
+func (a *actor) CreateActor(ctx context.Context, tx *sql.Tx, actor models.CreateActor) (uuid.UUID, error) {
+	defer a.metrics.ObserveQuery("CreateActor", time.Now())
+
 	id := uuid.New()
 	query := sq.
 		Insert("actors").
@@ -32,257 +44,240 @@ func (a *actor) CreateActor(actor models.CreateActor) (uuid.UUID, error) {
 
 	sqlQuery, args, err := query.ToSql()
 	if err != nil {
-		log.Printf("[CreateActor] Error building query: %v", err)
+		a.log.ErrorContext(ctx, "Error building query", "op", "CreateActor", logger.Err(err))
 		return uuid.Nil, fmt.Errorf("failed to build query: %w", err)
 	}
 
-	err = a.db.QueryRow(sqlQuery, args...).Scan(&id)
+	err = tx.QueryRowContext(ctx, sqlQuery, args...).Scan(&id)
 	if err != nil {
-		log.Printf("[CreateActor] Error executing query: %v", err)
+		a.log.ErrorContext(ctx, "Error executing query", "op", "CreateActor", logger.Err(err))
 		return uuid.Nil, fmt.Errorf("failed to add actor: %w", err)
 	}
 	return id, nil
 }
 
-func (a *actor) GetActor(id uuid.UUID) (map[string]interface{}, error) {
+func (a *actor) GetActor(ctx context.Context, id uuid.UUID) (*models.Actor, error) {
+	defer a.metrics.ObserveQuery("GetActor", time.Now())
+
 	query := sq.
-		Select("id", "name", "gender", "date_of_birth").
+		Select(actorColumns...).
 		From("actors").
 		Where(sq.Eq{"id": id}).
+		Where(sq.Eq{"deleted_at": nil}).
 		PlaceholderFormat(sq.Dollar)
 
 	sqlQuery, args, err := query.ToSql()
 	if err != nil {
-		log.Printf("[GetActor] Error building query: %v", err)
+		a.log.ErrorContext(ctx, "Error building query", "op", "GetActor", logger.Err(err))
 		return nil, fmt.Errorf("failed to build query: %w", err)
 	}
 
-	rawData := make(map[string]interface{})
-	var actorID uuid.UUID
-	var name, gender string
-	var dateOfBirth time.Time
-
-	err = a.db.QueryRow(sqlQuery, args...).Scan(&actorID, &name, &gender, &dateOfBirth)
+	actor, err := scanActor(a.db.QueryRowContext(ctx, sqlQuery, args...))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil // Актёр не найден
 		}
-		log.Printf("[GetActor] Error executing query: %v", err)
+		a.log.ErrorContext(ctx, "Error executing query", "op", "GetActor", logger.Err(err))
 		return nil, fmt.Errorf("failed to get actor: %w", err)
 	}
 
-	rawData["id"] = actorID
-	rawData["name"] = name
-	rawData["gender"] = gender
-	rawData["date_of_birth"] = dateOfBirth
-
-	return rawData, nil
+	return &actor, nil
 }
 
-func (a *actor) GetAllActors(limit, offset int) ([]map[string]interface{}, error) {
+// Актеры по алфавиту, after - курсор последней записи предыдущей страницы
+func (a *actor) GetAllActors(ctx context.Context, after *pagination.Cursor, limit int) ([]models.Actor, error) {
+	defer a.metrics.ObserveQuery("GetAllActors", time.Now())
+
 	query := sq.
-		Select("id", "name", "gender", "date_of_birth").
+		Select(actorColumns...).
 		From("actors").
+		Where(sq.Eq{"deleted_at": nil}).
+		OrderBy("name ASC", "id ASC").
 		Limit(uint64(limit)).
-		Offset(uint64(offset)).
 		PlaceholderFormat(sq.Dollar)
 
+	if after != nil {
+		query = query.Where(keysetAfter("name", "id", "ASC", after))
+	}
+
 	sqlQuery, args, err := query.ToSql()
 	if err != nil {
-		log.Printf("[GetAllActors] Error building query: %v", err)
+		a.log.ErrorContext(ctx, "Error building query", "op", "GetAllActors", logger.Err(err))
 		return nil, fmt.Errorf("failed to build query: %w", err)
 	}
 
-	rows, err := a.db.Query(sqlQuery, args...)
+	rows, err := a.db.QueryContext(ctx, sqlQuery, args...)
 	if err != nil {
-		log.Printf("[GetAllActors] Error executing query: %v", err)
+		a.log.ErrorContext(ctx, "Error executing query", "op", "GetAllActors", logger.Err(err))
 		return nil, fmt.Errorf("failed to get actors: %w", err)
 	}
 	defer rows.Close()
 
-	var rawActors []map[string]interface{}
+	actors := make([]models.Actor, 0, limit)
 	for rows.Next() {
-		var id uuid.UUID
-		var name, gender string
-		var dateOfBirth time.Time
-
-		err := rows.Scan(&id, &name, &gender, &dateOfBirth)
+		actor, err := scanActor(rows)
 		if err != nil {
-			log.Printf("[GetAllActors] Error scanning row: %v", err)
+			a.log.ErrorContext(ctx, "Error scanning row", "op", "GetAllActors", logger.Err(err))
 			return nil, fmt.Errorf("failed to scan actor: %w", err)
 		}
-
-		rawActor := map[string]interface{}{
-			"id":            id,
-			"name":          name,
-			"gender":        gender,
-			"date_of_birth": dateOfBirth,
-		}
-		rawActors = append(rawActors, rawActor)
+		actors = append(actors, actor)
 	}
 
 	if err := rows.Err(); err != nil {
-		log.Printf("[GetAllActors] Error iterating rows: %v", err)
+		a.log.ErrorContext(ctx, "Error iterating rows", "op", "GetAllActors", logger.Err(err))
 		return nil, fmt.Errorf("error occurred while iterating rows: %w", err)
 	}
 
-	return rawActors, nil
+	return actors, nil
 }
 
-func (a *actor) GetActorsWithMovies(limit, offset int) ([]map[string]interface{}, error) {
+// Общее количество актеров
+func (a *actor) CountActors(ctx context.Context) (int64, error) {
+	defer a.metrics.ObserveQuery("CountActors", time.Now())
+
+	query := sq.
+		Select("COUNT(*)").
+		From("actors").
+		Where(sq.Eq{"deleted_at": nil}).
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		a.log.ErrorContext(ctx, "Error building query", "op", "CountActors", logger.Err(err))
+		return 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var count int64
+	if err := a.db.QueryRowContext(ctx, sqlQuery, args...).Scan(&count); err != nil {
+		a.log.ErrorContext(ctx, "Error executing query", "op", "CountActors", logger.Err(err))
+		return 0, fmt.Errorf("failed to count actors: %w", err)
+	}
+	return count, nil
+}
+
+// Актеры по алфавиту вместе с их фильмами, after - курсор последнего актера предыдущей страницы
+func (a *actor) GetActorsWithMovies(ctx context.Context, after *pagination.Cursor, limit int) ([]models.ActorWithMovies, error) {
+	defer a.metrics.ObserveQuery("GetActorsWithMovies", time.Now())
+
 	// Создаем подзапрос для пагинации актеров
 	actorsQuery := sq.
-		Select("id AS actor_id", "name AS actor_name", "gender AS actor_gender", "date_of_birth AS actor_birth_date").
+		Select(actorColumns...).
 		From("actors").
-		OrderBy("name ASC").
-		Limit(uint64(limit)).
-		Offset(uint64(offset))
+		Where(sq.Eq{"deleted_at": nil}).
+		OrderBy("name ASC", "id ASC").
+		Limit(uint64(limit))
 
-	// Основной запрос с соединением фильмов
+	if after != nil {
+		actorsQuery = actorsQuery.Where(keysetAfter("name", "id", "ASC", after))
+	}
+
+	// Основной запрос с соединением фильмов, колонки фильма NULL у актеров без фильмов
 	query := sq.
-		Select("pa.actor_id", "pa.actor_name", "pa.actor_gender", "pa.actor_birth_date", "m.id AS movie_id",
-			"m.title AS movie_title", "m.description AS movie_description", "m.release_date AS movie_release_date", "m.rating AS movie_rating").
+		Select(prefixColumns("pa", actorColumns)...).
+		Columns(movieColumns("m")...).
 		FromSelect(actorsQuery, "pa").
-		LeftJoin("movie_actors ma ON pa.actor_id = ma.actor_id").
-		LeftJoin("movies m ON ma.movie_id = m.id").
-		OrderBy("pa.actor_name ASC", "pa.actor_id").
+		// Один фильм на актера, даже если у него в фильме несколько ролей
+		LeftJoin("(SELECT DISTINCT movie_id, actor_id FROM movie_actors) ma ON pa.id = ma.actor_id").
+		LeftJoin("movies m ON ma.movie_id = m.id AND m.deleted_at IS NULL").
+		OrderBy("pa.name ASC", "pa.id ASC", "m.release_date DESC").
 		PlaceholderFormat(sq.Dollar)
 
 	sqlQuery, args, err := query.ToSql()
 	if err != nil {
-		log.Printf("[GetActorsWithMovies] Error building query: %v", err)
+		a.log.ErrorContext(ctx, "Error building query", "op", "GetActorsWithMovies", logger.Err(err))
 		return nil, fmt.Errorf("failed to build query: %w", err)
 	}
 
-	rows, err := a.db.Query(sqlQuery, args...)
+	rows, err := a.db.QueryContext(ctx, sqlQuery, args...)
 	if err != nil {
-		log.Printf("[GetActorsWithMovies] Error executing query: %v", err)
+		a.log.ErrorContext(ctx, "Error executing query", "op", "GetActorsWithMovies", logger.Err(err))
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
 	defer rows.Close()
 
-	// Срез для хранения актеров в порядке их получения
-	// var result []map[string]interface{}
-	actorsMap := make(map[uuid.UUID]map[string]interface{})
-
+	// Строки отсортированы по актеру, поэтому фильмы одного актера идут подряд
+	actors := make([]models.ActorWithMovies, 0, limit)
 	for rows.Next() {
-		var (
-			actorID        uuid.UUID
-			actorName      string
-			actorGender    string
-			actorBirthDate time.Time
-			movieID        *uuid.UUID
-			movieTitle     *string
-			movieDesc      *string
-			movieRelease   *time.Time
-			movieRating    *float64
-		)
-
-		if err := rows.Scan(
-			&actorID,
-			&actorName,
-			&actorGender,
-			&actorBirthDate,
-			&movieID,
-			&movieTitle,
-			&movieDesc,
-			&movieRelease,
-			&movieRating,
-		); err != nil {
-			log.Printf("[GetActorsWithMovies] Error scanning row: %v", err)
+		var actorData actorRow
+		var movieData movieRow
+		if err := rows.Scan(append(actorData.dest(), movieData.dest()...)...); err != nil {
+			a.log.ErrorContext(ctx, "Error scanning row", "op", "GetActorsWithMovies", logger.Err(err))
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
-		// Проверяем, есть ли актёр в словаре
-		if actor, exists := actorsMap[actorID]; exists {
-			// Если актёр уже есть, добавляем информацию о фильме
-			if movieID != nil {
-				actor["movies"] = append(actor["movies"].([]map[string]interface{}), map[string]interface{}{
-					"id":           *movieID,
-					"title":        *movieTitle,
-					"description":  *movieDesc,
-					"release_date": *movieRelease,
-					"rating":       *movieRating,
-				})
-			}
-		} else {
-			// Если актёра ещё нет, создаём его запись
-			newActor := map[string]interface{}{
-				"id":            actorID,
-				"name":          actorName,
-				"gender":        actorGender,
-				"date_of_birth": actorBirthDate,
-				"movies":        []map[string]interface{}{},
-			}
 
-			// Добавляем информацию о фильме (если она есть)
-			if movieID != nil {
-				newActor["movies"] = append(newActor["movies"].([]map[string]interface{}), map[string]interface{}{
-					"id":           *movieID,
-					"title":        *movieTitle,
-					"description":  *movieDesc,
-					"release_date": *movieRelease,
-					"rating":       *movieRating,
-				})
-			}
+		if n := len(actors); n == 0 || actors[n-1].ID != actorData.id {
+			actors = append(actors, models.ActorWithMovies{Actor: actorData.actor(), Movies: []models.Movie{}})
+		}
 
-			// Сохраняем актёра в словарь
-			actorsMap[actorID] = newActor
+		// Фильм актера (id NULL, если у актера нет фильмов или фильм в корзине)
+		if movieData.id.Valid {
+			movie, err := movieData.movie()
+			if err != nil {
+				a.log.ErrorContext(ctx, "Error parsing movie genres", "op", "GetActorsWithMovies", logger.Err(err))
+				return nil, err
+			}
+			current := &actors[len(actors)-1]
+			current.Movies = append(current.Movies, movie)
 		}
 	}
 
 	if err := rows.Err(); err != nil {
-		log.Printf("[GetActorsWithMovies] Error iterating over rows: %v", err)
+		a.log.ErrorContext(ctx, "Error iterating over rows", "op", "GetActorsWithMovies", logger.Err(err))
 		return nil, fmt.Errorf("error occurred while iterating rows: %w", err)
 	}
 
-	// Преобразуем карту в срез для возврата
-	var result []map[string]interface{}
-	for _, actor := range actorsMap {
-		result = append(result, actor)
-	}
-
-	return result, nil
+	return actors, nil
 }
 
-func (a *actor) UpdateActor(id uuid.UUID, actor models.UpdateActor) error {
+func (a *actor) UpdateActor(ctx context.Context, tx *sql.Tx, id uuid.UUID, actor models.UpdateActor, version *int64) error {
+	defer a.metrics.ObserveQuery("UpdateActor", time.Now())
+
 	query := sq.
 		Update("actors").
 		Set("name", sq.Expr("COALESCE(?, name)", actor.Name)).
 		Set("gender", sq.Expr("COALESCE(?, gender)", actor.Gender)).
 		Set("date_of_birth", sq.Expr("COALESCE(?, date_of_birth)", actor.DateOfBirth)).
-		Where(sq.Eq{"id": id}).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(whereVersion(id, version)).
 		PlaceholderFormat(sq.Dollar)
 
 	sqlQuery, args, err := query.ToSql()
 	if err != nil {
-		log.Printf("[UpdateActor] Error building query: %v", err)
+		a.log.ErrorContext(ctx, "Error building query", "op", "UpdateActor", logger.Err(err))
 		return fmt.Errorf("failed to build query: %w", err)
 	}
 
-	_, err = a.db.Exec(sqlQuery, args...)
+	result, err := tx.ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
-		log.Printf("[UpdateActor] Error executing query: %v", err)
+		a.log.ErrorContext(ctx, "Error executing query", "op", "UpdateActor", logger.Err(err))
 		return fmt.Errorf("failed to update actor: %w", err)
 	}
-	return nil
+	return checkVersion(result, version)
 }
 
-func (a *actor) DeleteActor(id uuid.UUID) error {
+// Переместить актера в корзину. Записи титров остаются, чтобы актер вернулся в фильмы при восстановлении.
+func (a *actor) DeleteActor(ctx context.Context, tx *sql.Tx, id uuid.UUID, version *int64) error {
+	defer a.metrics.ObserveQuery("DeleteActor", time.Now())
+
 	query := sq.
-		Delete("actors").
-		Where(sq.Eq{"id": id}).
+		Update("actors").
+		Set("deleted_at", sq.Expr("now()")).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(whereVersion(id, version)).
 		PlaceholderFormat(sq.Dollar)
 
 	sqlQuery, args, err := query.ToSql()
 	if err != nil {
-		log.Printf("[DeleteActor] Error building query: %v", err)
+		a.log.ErrorContext(ctx, "Error building query", "op", "DeleteActor", logger.Err(err))
 		return fmt.Errorf("failed to build query: %w", err)
 	}
 
-	_, err = a.db.Exec(sqlQuery, args...)
+	result, err := tx.ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
-		log.Printf("[DeleteActor] Error executing query: %v", err)
+		a.log.ErrorContext(ctx, "Error executing query", "op", "DeleteActor", logger.Err(err))
 		return fmt.Errorf("failed to delete actor: %w", err)
 	}
-	return nil
+	return checkVersion(result, version)
 }
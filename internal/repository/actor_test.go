@@ -1,7 +1,10 @@
 package repository
 
 import (
+	"cinema/internal/logger"
 	"cinema/internal/models"
+	"cinema/internal/pagination"
+	"context"
 	"testing"
 	"time"
 
@@ -16,7 +19,10 @@ func TestCreateActor(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewActor(db)
+	repo := NewActor(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
 
 	// Test input
 	actor := models.CreateActor{
@@ -32,7 +38,7 @@ func TestCreateActor(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(expectedID))
 
 	// Execute
-	resultID, err := repo.CreateActor(actor)
+	resultID, err := repo.CreateActor(context.Background(), tx, actor)
 
 	// Verify
 	assert.NoError(t, err)
@@ -46,7 +52,7 @@ func TestGetActor(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewActor(db)
+	repo := NewActor(db, logger.Discard(), nil)
 
 	actorID := uuid.New()
 	actor := models.Actor{
@@ -54,22 +60,42 @@ func TestGetActor(t *testing.T) {
 		Name:        "John Doe",
 		Gender:      "Male",
 		DateOfBirth: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		Version:     2,
+		UpdatedAt:   time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
 	}
 
-	mock.ExpectQuery(`SELECT id, name, gender, date_of_birth FROM actors`).
+	mock.ExpectQuery(`SELECT id, name, gender, date_of_birth, headshot_key, version, updated_at FROM actors`).
 		WithArgs(actorID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "gender", "date_of_birth"}).
-			AddRow(actor.ID, actor.Name, actor.Gender, actor.DateOfBirth))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "gender", "date_of_birth", "headshot_key", "version", "updated_at"}).
+			AddRow(actor.ID, actor.Name, actor.Gender, actor.DateOfBirth, nil, actor.Version, actor.UpdatedAt))
 
 	// Execute
-	result, err := repo.GetActor(actorID)
+	result, err := repo.GetActor(context.Background(), actorID)
 
 	// Verify
 	assert.NoError(t, err)
-	assert.Equal(t, actor.ID, result["id"])
-	assert.Equal(t, actor.Name, result["name"])
-	assert.Equal(t, actor.Gender, result["gender"])
-	assert.Equal(t, actor.DateOfBirth, result["date_of_birth"])
+	assert.Equal(t, &actor, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetActorNullColumns(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewActor(db, logger.Discard(), nil)
+
+	actorID := uuid.New()
+	mock.ExpectQuery(`SELECT id, name, gender, date_of_birth, headshot_key, version, updated_at FROM actors`).
+		WithArgs(actorID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "gender", "date_of_birth", "headshot_key", "version", "updated_at"}).
+			AddRow(actorID, "John Doe", nil, nil, nil, 1, time.Now()))
+
+	// Необязательные колонки не должны ломать сканирование
+	result, err := repo.GetActor(context.Background(), actorID)
+	assert.NoError(t, err)
+	assert.Equal(t, "", result.Gender)
+	assert.True(t, result.DateOfBirth.IsZero())
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -79,16 +105,20 @@ func TestDeleteActor(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewActor(db)
+	repo := NewActor(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
 
 	actorID := uuid.New()
 
-	mock.ExpectExec(`DELETE FROM actors`).
+	// Актер переносится в корзину, а не удаляется
+	mock.ExpectExec(`UPDATE actors SET deleted_at = now\(\), version = version \+ 1, updated_at = now\(\) WHERE \(id = \$1 AND deleted_at IS NULL\)`).
 		WithArgs(actorID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	// Execute
-	err = repo.DeleteActor(actorID)
+	err = repo.DeleteActor(context.Background(), tx, actorID, nil)
 
 	// Verify
 	assert.NoError(t, err)
@@ -101,7 +131,10 @@ func TestUpdateActor(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewActor(db)
+	repo := NewActor(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
 
 	actorID := uuid.New()
 	name := "John Doe Updated"
@@ -113,25 +146,90 @@ func TestUpdateActor(t *testing.T) {
 		DateOfBirth: &dateOfBirth,
 	}
 
-	mock.ExpectExec(`UPDATE actors`).
+	mock.ExpectExec(`UPDATE actors SET name = COALESCE\(\$1, name\), gender = COALESCE\(\$2, gender\), date_of_birth = COALESCE\(\$3, date_of_birth\), version = version \+ 1, updated_at = now\(\) WHERE \(id = \$4 AND deleted_at IS NULL\)`).
 		WithArgs(updateData.Name, updateData.Gender, updateData.DateOfBirth, actorID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	// Execute
-	err = repo.UpdateActor(actorID, updateData)
+	err = repo.UpdateActor(context.Background(), tx, actorID, updateData, nil)
 
 	// Verify
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestUpdateActorVersionMismatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewActor(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	actorID := uuid.New()
+	name := "John Doe Updated"
+	version := int64(3)
+
+	// Актера уже обновили до версии 4, условие по версии не находит строк
+	mock.ExpectExec(`UPDATE actors SET .+ WHERE \(id = \$4 AND deleted_at IS NULL AND version = \$5\)`).
+		WithArgs(&name, sqlmock.AnyArg(), sqlmock.AnyArg(), actorID, version).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.UpdateActor(context.Background(), tx, actorID, models.UpdateActor{Name: &name}, &version)
+	assert.ErrorIs(t, err, models.ErrVersionMismatch)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteActorWithVersion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewActor(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	actorID := uuid.New()
+	version := int64(2)
+	mock.ExpectExec(`UPDATE actors SET deleted_at = now\(\), .+ WHERE \(id = \$1 AND deleted_at IS NULL AND version = \$2\)`).
+		WithArgs(actorID, version).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.DeleteActor(context.Background(), tx, actorID, &version))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteActorNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewActor(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	// Без If-Match отсутствие актера - не конфликт версий, а ErrNotFound
+	actorID := uuid.New()
+	mock.ExpectExec(`UPDATE actors SET deleted_at = now\(\), .+ WHERE \(id = \$1 AND deleted_at IS NULL\)`).
+		WithArgs(actorID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.DeleteActor(context.Background(), tx, actorID, nil)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestGetAllActors(t *testing.T) {
 	// Setup
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewActor(db)
+	repo := NewActor(db, logger.Discard(), nil)
 
 	actors := []models.Actor{
 		{
@@ -148,20 +246,62 @@ func TestGetAllActors(t *testing.T) {
 		},
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "name", "gender", "date_of_birth"}).
-		AddRow(actors[0].ID, actors[0].Name, actors[0].Gender, actors[0].DateOfBirth).
-		AddRow(actors[1].ID, actors[1].Name, actors[1].Gender, actors[1].DateOfBirth)
+	rows := sqlmock.NewRows([]string{"id", "name", "gender", "date_of_birth", "headshot_key", "version", "updated_at"}).
+		AddRow(actors[0].ID, actors[0].Name, actors[0].Gender, actors[0].DateOfBirth, nil, actors[0].Version, actors[0].UpdatedAt).
+		AddRow(actors[1].ID, actors[1].Name, actors[1].Gender, actors[1].DateOfBirth, nil, actors[1].Version, actors[1].UpdatedAt)
 
-	mock.ExpectQuery(`SELECT id, name, gender, date_of_birth FROM actors LIMIT .* OFFSET .*`).
+	mock.ExpectQuery(`SELECT id, name, gender, date_of_birth, headshot_key, version, updated_at FROM actors WHERE deleted_at IS NULL ORDER BY name ASC, id ASC LIMIT 2`).
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.GetAllActors(2, 0)
+	result, err := repo.GetAllActors(context.Background(), nil, 2)
 
 	// Verify
 	assert.NoError(t, err)
 	assert.Len(t, result, 2)
-	assert.Equal(t, actors[0].Name, result[0]["name"])
-	assert.Equal(t, actors[1].Name, result[1]["name"])
+	assert.Equal(t, actors, result)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestGetAllActorsAfterCursor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewActor(db, logger.Discard(), nil)
+
+	cursor := &pagination.Cursor{Key: "Actor One", ID: uuid.New()}
+
+	mock.ExpectQuery(`SELECT id, name, gender, date_of_birth, headshot_key, version, updated_at FROM actors WHERE deleted_at IS NULL AND \(name, id\) > \(\$1, \$2\) ORDER BY name ASC, id ASC LIMIT 2`).
+		WithArgs(cursor.Key, cursor.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "gender", "date_of_birth", "headshot_key", "version", "updated_at"}))
+
+	result, err := repo.GetAllActors(context.Background(), cursor, 2)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetActorContextTimeout(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewActor(db, logger.Discard(), nil)
+
+	actorID := uuid.New()
+	mock.ExpectQuery(`SELECT id, name, gender, date_of_birth, headshot_key, version, updated_at FROM actors`).
+		WithArgs(actorID).
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "gender", "date_of_birth", "headshot_key", "version", "updated_at"}))
+
+	// Запрос прерывается по истечении контекста, не дожидаясь ответа БД
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = repo.GetActor(ctx, actorID)
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
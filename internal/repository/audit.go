@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/metrics"
+	"cinema/internal/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+type audit struct {
+	db      *sql.DB
+	log     *slog.Logger
+	metrics *metrics.Metrics
+}
+
+func NewAudit(db *sql.DB, log *slog.Logger, metrics *metrics.Metrics) *audit {
+	return &audit{db: db, log: log, metrics: metrics}
+}
+
+// Титры фильма m в виде JSON-массива в порядке вывода
+const creditsSnapshot = `(SELECT COALESCE(jsonb_agg(jsonb_build_object(
+	'actor_id', ma.actor_id, 'role', ma.role, 'character', ma.character_name, 'order', ma.billing_order)
+	ORDER BY ma.role, ma.billing_order NULLS LAST, ma.actor_id), '[]'::jsonb)
+	FROM movie_actors ma WHERE ma.movie_id = m.id)`
+
+// Снимок сущности для журнала: выражение и таблица с псевдонимом. Снимок фильма включает
+// его жанры и титры, потому что UpdateMovie может заменить их вместе с фильмом.
+var snapshots = map[string]struct{ expr, from, alias string }{
+	models.AuditMovie: {
+		expr: `(to_jsonb(m) - 'search_vector') || jsonb_build_object(
+			'genre_ids', (SELECT COALESCE(jsonb_agg(mg.genre_id ORDER BY mg.genre_id), '[]'::jsonb) FROM movie_genres mg WHERE mg.movie_id = m.id),
+			'credits', ` + creditsSnapshot + `)`,
+		from:  "movies m",
+		alias: "m",
+	},
+	models.AuditMovieCredits: {expr: creditsSnapshot, from: "movies m", alias: "m"},
+	models.AuditActor:        {expr: "to_jsonb(a)", from: "actors a", alias: "a"},
+	models.AuditGenre:        {expr: "to_jsonb(g)", from: "genres g", alias: "g"},
+}
+
+// Состояние сущности в транзакции tx в виде JSON, nil, если ее нет. С lock строка сущности
+// блокируется до конца транзакции, чтобы снимок "до" не устарел к моменту изменения.
+func (a *audit) SnapshotEntity(ctx context.Context, tx *sql.Tx, entityType string, id uuid.UUID, lock bool) (json.RawMessage, error) {
+	defer a.metrics.ObserveQuery("SnapshotEntity", time.Now())
+
+	snapshot, ok := snapshots[entityType]
+	if !ok {
+		return nil, fmt.Errorf("unknown audit entity type %q", entityType)
+	}
+
+	query := sq.
+		Select().
+		Column(snapshot.expr).
+		From(snapshot.from).
+		Where(sq.Eq{snapshot.alias + ".id": id}).
+		PlaceholderFormat(sq.Dollar)
+	if lock {
+		query = query.Suffix("FOR UPDATE OF " + snapshot.alias)
+	}
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		a.log.ErrorContext(ctx, "Error building query", "op", "SnapshotEntity", logger.Err(err))
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var data []byte
+	err = tx.QueryRowContext(ctx, sqlQuery, args...).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		a.log.ErrorContext(ctx, "Error executing query", "op", "SnapshotEntity", "entity_type", entityType, logger.Err(err))
+		return nil, fmt.Errorf("failed to snapshot %s: %w", entityType, err)
+	}
+	return data, nil
+}
+
+// Добавить запись в журнал в транзакции изменения
+func (a *audit) AddAuditEntry(ctx context.Context, tx *sql.Tx, entry models.AuditEntry) error {
+	defer a.metrics.ObserveQuery("AddAuditEntry", time.Now())
+
+	query := sq.
+		Insert("audit_log").
+		Columns("subject", "role", "action", "entity_type", "entity_id", "before", "after", "request_id").
+		Values(entry.Subject, entry.Role, entry.Action, entry.EntityType, entry.EntityID,
+			jsonbValue(entry.Before), jsonbValue(entry.After), sql.NullString{String: entry.RequestID, Valid: entry.RequestID != ""}).
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		a.log.ErrorContext(ctx, "Error building query", "op", "AddAuditEntry", logger.Err(err))
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, sqlQuery, args...); err != nil {
+		a.log.ErrorContext(ctx, "Error executing query", "op", "AddAuditEntry", logger.Err(err))
+		return fmt.Errorf("failed to add audit entry: %w", err)
+	}
+	return nil
+}
+
+// lib/pq передает []byte как bytea, поэтому JSON для колонки jsonb передается строкой
+func jsonbValue(data json.RawMessage) interface{} {
+	if data == nil {
+		return nil
+	}
+	return string(data)
+}
+
+// Записи журнала по фильтру, новые первыми
+func (a *audit) GetAuditLog(ctx context.Context, filter models.AuditFilter, limit, offset int) ([]models.AuditEntry, error) {
+	defer a.metrics.ObserveQuery("GetAuditLog", time.Now())
+
+	query := sq.
+		Select("id", "subject", "role", "action", "entity_type", "entity_id", "before", "after", "request_id", "created_at").
+		From("audit_log").
+		OrderBy("id DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		PlaceholderFormat(sq.Dollar)
+
+	if filter.Subject != "" {
+		query = query.Where(sq.Eq{"subject": filter.Subject})
+	}
+	if filter.Action != "" {
+		query = query.Where(sq.Eq{"action": filter.Action})
+	}
+	if filter.EntityType != "" {
+		query = query.Where(sq.Eq{"entity_type": filter.EntityType})
+	}
+	if filter.EntityID != nil {
+		query = query.Where(sq.Eq{"entity_id": *filter.EntityID})
+	}
+	if filter.From != nil {
+		query = query.Where(sq.GtOrEq{"created_at": *filter.From})
+	}
+	if filter.To != nil {
+		query = query.Where(sq.Lt{"created_at": *filter.To})
+	}
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		a.log.ErrorContext(ctx, "Error building query", "op", "GetAuditLog", logger.Err(err))
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := a.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		a.log.ErrorContext(ctx, "Error executing query", "op", "GetAuditLog", logger.Err(err))
+		return nil, fmt.Errorf("failed to get audit log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]models.AuditEntry, 0, limit)
+	for rows.Next() {
+		var entry models.AuditEntry
+		var before, after []byte
+		var requestID sql.NullString
+		err := rows.Scan(&entry.ID, &entry.Subject, &entry.Role, &entry.Action, &entry.EntityType, &entry.EntityID,
+			&before, &after, &requestID, &entry.CreatedAt)
+		if err != nil {
+			a.log.ErrorContext(ctx, "Error scanning row", "op", "GetAuditLog", logger.Err(err))
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		entry.Before, entry.After, entry.RequestID = before, after, requestID.String
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		a.log.ErrorContext(ctx, "Error iterating rows", "op", "GetAuditLog", logger.Err(err))
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return entries, nil
+}
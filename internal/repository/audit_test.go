@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotEntityLocksRow(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAudit(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	actorID := uuid.New()
+	mock.ExpectQuery(`SELECT to_jsonb\(a\) FROM actors a WHERE a.id = \$1 FOR UPDATE OF a`).
+		WithArgs(actorID).
+		WillReturnRows(sqlmock.NewRows([]string{"to_jsonb"}).AddRow([]byte(`{"name": "Tom Hardy"}`)))
+	mock.ExpectQuery(`SELECT to_jsonb\(g\) FROM genres g WHERE g.id = \$1$`).
+		WillReturnError(sql.ErrNoRows)
+
+	snapshot, err := repo.SnapshotEntity(context.Background(), tx, models.AuditActor, actorID, true)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name": "Tom Hardy"}`, string(snapshot))
+
+	// Отсутствующая сущность - не ошибка, а пустой снимок
+	snapshot, err = repo.SnapshotEntity(context.Background(), tx, models.AuditGenre, uuid.New(), false)
+	assert.NoError(t, err)
+	assert.Nil(t, snapshot)
+
+	_, err = repo.SnapshotEntity(context.Background(), tx, "user", uuid.New(), false)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddAuditEntry(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAudit(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	entry := models.AuditEntry{
+		Subject:    "admin",
+		Role:       "admin",
+		Action:     models.AuditCreate,
+		EntityType: models.AuditGenre,
+		EntityID:   uuid.New(),
+		After:      json.RawMessage(`{"name": "Драма"}`),
+	}
+	// JSON передается строкой, пустые before и request_id - NULL
+	mock.ExpectExec(`INSERT INTO audit_log \(subject,role,action,entity_type,entity_id,before,after,request_id\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8\)`).
+		WithArgs("admin", "admin", "create", "genre", entry.EntityID, nil, `{"name": "Драма"}`, sql.NullString{}).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	assert.NoError(t, repo.AddAuditEntry(context.Background(), tx, entry))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAuditLogWithFilter(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAudit(db, logger.Discard(), nil)
+
+	movieID := uuid.New()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	filter := models.AuditFilter{EntityType: models.AuditMovie, EntityID: &movieID, From: &from}
+
+	mock.ExpectQuery(`SELECT id, subject, role, action, entity_type, entity_id, before, after, request_id, created_at FROM audit_log `+
+		`WHERE entity_type = \$1 AND entity_id = \$2 AND created_at >= \$3 ORDER BY id DESC LIMIT 20 OFFSET 0`).
+		WithArgs(models.AuditMovie, movieID, from).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "subject", "role", "action", "entity_type", "entity_id", "before", "after", "request_id", "created_at"}).
+			AddRow(7, "admin", "admin", "delete", "movie", movieID, []byte(`{"deleted_at": null}`), []byte(`{"deleted_at": "2024-01-02T10:00:00Z"}`), nil, createdAt))
+
+	entries, err := repo.GetAuditLog(context.Background(), filter, 20, 0)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, int64(7), entries[0].ID)
+	assert.Equal(t, models.AuditDelete, entries[0].Action)
+	assert.JSONEq(t, `{"deleted_at": null}`, string(entries[0].Before))
+	assert.Empty(t, entries[0].RequestID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
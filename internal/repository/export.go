@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Транзакция для выгрузки каталога, см. beginSnapshot
+func (m *movie) BeginSnapshot(ctx context.Context) (*sql.Tx, error) {
+	return beginSnapshot(ctx, m.db, m.log)
+}
+
+// Выгрузка фильмов не из корзины по фильтру в порядке ID. Строки передаются в each по одной,
+// не накапливаясь в памяти; ошибка each прерывает выгрузку.
+func (m *movie) ExportMovies(ctx context.Context, tx *sql.Tx, filter models.MovieFilter, updatedSince *time.Time, each func(models.Movie) error) error {
+	defer m.metrics.ObserveQuery("ExportMovies", time.Now())
+
+	query := applyMovieFilter(sq.Select(movieColumns("movies")...).From("movies"), filter)
+	if updatedSince != nil {
+		query = query.Where(sq.GtOrEq{"updated_at": *updatedSince})
+	}
+
+	return streamRows(ctx, tx, m.log, "ExportMovies", query.OrderBy("id"), func(rows *sql.Rows) error {
+		movie, err := scanMovie(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan row: %w", err)
+		}
+		return each(movie)
+	})
+}
+
+// Выгрузка титров фильмов, подходящих под фильтр. Титры актеров из корзины не выгружаются.
+func (m *movie) ExportCredits(ctx context.Context, tx *sql.Tx, filter models.MovieFilter, each func(models.ExportCredit) error) error {
+	defer m.metrics.ObserveQuery("ExportCredits", time.Now())
+
+	movies := applyMovieFilter(sq.Select("id").From("movies"), filter)
+	query := sq.
+		Select("ma.movie_id", "ma.actor_id", "ma.role", "ma.character_name", "ma.billing_order").
+		From("movie_actors ma").
+		Join("actors a ON a.id = ma.actor_id").
+		Where(sq.Eq{"a.deleted_at": nil}).
+		Where(sq.Expr("ma.movie_id IN (?)", movies)).
+		OrderBy("ma.movie_id", "ma.billing_order ASC NULLS LAST", "ma.actor_id", "ma.role")
+
+	return streamRows(ctx, tx, m.log, "ExportCredits", query, func(rows *sql.Rows) error {
+		var credit models.ExportCredit
+		var character sql.NullString
+		var order sql.NullInt64
+		if err := rows.Scan(&credit.MovieID, &credit.ActorID, &credit.Role, &character, &order); err != nil {
+			return fmt.Errorf("failed to scan row: %w", err)
+		}
+		credit.Character = nullStringPtr(character)
+		credit.Order = nullIntPtr(order)
+		return each(credit)
+	})
+}
+
+// Выгрузка актеров не из корзины в порядке ID
+func (a *actor) ExportActors(ctx context.Context, tx *sql.Tx, updatedSince *time.Time, each func(models.Actor) error) error {
+	defer a.metrics.ObserveQuery("ExportActors", time.Now())
+
+	query := sq.Select(actorColumns...).From("actors").Where(sq.Eq{"deleted_at": nil})
+	if updatedSince != nil {
+		query = query.Where(sq.GtOrEq{"updated_at": *updatedSince})
+	}
+
+	return streamRows(ctx, tx, a.log, "ExportActors", query.OrderBy("id"), func(rows *sql.Rows) error {
+		actor, err := scanActor(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan row: %w", err)
+		}
+		return each(actor)
+	})
+}
+
+// Выполняет запрос в tx и вызывает scan для каждой строки прямо из rows.Next().
+// Ошибки scan возвращаются как есть: это может быть и ошибка записи в ответ.
+func streamRows(ctx context.Context, tx *sql.Tx, log *slog.Logger, op string, query sq.SelectBuilder, scan func(rows *sql.Rows) error) error {
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		log.ErrorContext(ctx, "Error building query", "op", op, logger.Err(err))
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		log.ErrorContext(ctx, "Error executing query", "op", op, logger.Err(err))
+		return fmt.Errorf("failed to export records: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		log.ErrorContext(ctx, "Error iterating rows", "op", op, logger.Err(err))
+		return fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportCreditsFiltersMovies(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMovie(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	movieID, actorID := uuid.New(), uuid.New()
+	minRating := 8.0
+
+	mock.ExpectQuery(`SELECT ma.movie_id, ma.actor_id, ma.role, ma.character_name, ma.billing_order FROM movie_actors ma ` +
+		`JOIN actors a ON a.id = ma.actor_id WHERE a.deleted_at IS NULL ` +
+		`AND ma.movie_id IN \(SELECT id FROM movies WHERE deleted_at IS NULL AND rating >= \$1\) ` +
+		`ORDER BY ma.movie_id, ma.billing_order ASC NULLS LAST, ma.actor_id, ma.role`).
+		WithArgs(minRating).
+		WillReturnRows(sqlmock.NewRows([]string{"movie_id", "actor_id", "role", "character_name", "billing_order"}).
+			AddRow(movieID, actorID, "actor", "Eames", 2).
+			AddRow(movieID, actorID, "director", nil, nil))
+
+	var credits []models.ExportCredit
+	err = repo.ExportCredits(context.Background(), tx, models.MovieFilter{MinRating: &minRating}, func(credit models.ExportCredit) error {
+		credits = append(credits, credit)
+		return nil
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, credits, 2) {
+		assert.Equal(t, "Eames", *credits[0].Character)
+		assert.Equal(t, 2, *credits[0].Order)
+		assert.Nil(t, credits[1].Character)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExportActorsStopsOnCallbackError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewActor(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	birth := time.Date(1977, 9, 15, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT id, name, gender, date_of_birth, headshot_key, version, updated_at FROM actors WHERE deleted_at IS NULL AND updated_at >= \$1 ORDER BY id`).
+		WithArgs(since).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "gender", "date_of_birth", "headshot_key", "version", "updated_at"}).
+			AddRow(uuid.New(), "Tom Hardy", "male", birth, nil, 1, since).
+			AddRow(uuid.New(), "Emily Blunt", "female", birth, nil, 1, since))
+
+	// Ошибка записи в ответ прерывает чтение строк
+	writeErr := errors.New("connection reset")
+	calls := 0
+	err = repo.ExportActors(context.Background(), tx, &since, func(models.Actor) error {
+		calls++
+		return writeErr
+	})
+	assert.ErrorIs(t, err, writeErr)
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/metrics"
+	"cinema/internal/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Код ошибки PostgreSQL unique_violation
+const uniqueViolation = "23505"
+
+type genre struct {
+	db      *sql.DB
+	log     *slog.Logger
+	metrics *metrics.Metrics
+}
+
+func NewGenre(db *sql.DB, log *slog.Logger, metrics *metrics.Metrics) *genre {
+	return &genre{db: db, log: log, metrics: metrics}
+}
+
+// Колонка с жанрами фильма в виде JSON-массива, alias - псевдоним таблицы movies в запросе
+func movieGenresColumn(alias string) string {
+	return fmt.Sprintf(`COALESCE((SELECT json_agg(json_build_object('id', g.id, 'name', g.name) ORDER BY g.name) `+
+		`FROM movie_genres mg JOIN genres g ON g.id = mg.genre_id WHERE mg.movie_id = %s.id), '[]') AS genres`, alias)
+}
+
+// Разбор колонки movieGenresColumn
+func parseMovieGenres(raw []byte) ([]models.Genre, error) {
+	genres := []models.Genre{}
+	if len(raw) == 0 {
+		return genres, nil
+	}
+	if err := json.Unmarshal(raw, &genres); err != nil {
+		return nil, fmt.Errorf("failed to parse movie genres: %w", err)
+	}
+	return genres, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
+
+func (g *genre) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	return beginTransaction(ctx, g.db, g.log)
+}
+
+// Добавить жанр
+func (g *genre) CreateGenre(ctx context.Context, tx *sql.Tx, genre models.CreateGenre) (uuid.UUID, error) {
+	defer g.metrics.ObserveQuery("CreateGenre", time.Now())
+
+	id := uuid.New()
+	query := sq.
+		Insert("genres").
+		Columns("id", "name").
+		Values(id, strings.TrimSpace(genre.Name)).
+		Suffix("RETURNING \"id\"").
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		g.log.ErrorContext(ctx, "Error building query", "op", "CreateGenre", logger.Err(err))
+		return uuid.Nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	err = tx.QueryRowContext(ctx, sqlQuery, args...).Scan(&id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return uuid.Nil, fmt.Errorf("genre %q: %w", genre.Name, models.ErrAlreadyExists)
+		}
+		g.log.ErrorContext(ctx, "Error executing query", "op", "CreateGenre", logger.Err(err))
+		return uuid.Nil, fmt.Errorf("failed to add genre: %w", err)
+	}
+	return id, nil
+}
+
+func (g *genre) GetGenre(ctx context.Context, id uuid.UUID) (*models.Genre, error) {
+	defer g.metrics.ObserveQuery("GetGenre", time.Now())
+
+	query := sq.
+		Select("id", "name").
+		From("genres").
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		g.log.ErrorContext(ctx, "Error building query", "op", "GetGenre", logger.Err(err))
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var genre models.Genre
+	err = g.db.QueryRowContext(ctx, sqlQuery, args...).Scan(&genre.ID, &genre.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Жанр не найден
+		}
+		g.log.ErrorContext(ctx, "Error executing query", "op", "GetGenre", logger.Err(err))
+		return nil, fmt.Errorf("failed to get genre: %w", err)
+	}
+
+	return &genre, nil
+}
+
+// Все жанры по алфавиту. Справочник небольшой, поэтому без пагинации
+func (g *genre) GetAllGenres(ctx context.Context) ([]models.Genre, error) {
+	defer g.metrics.ObserveQuery("GetAllGenres", time.Now())
+
+	query := sq.
+		Select("id", "name").
+		From("genres").
+		OrderBy("name ASC").
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		g.log.ErrorContext(ctx, "Error building query", "op", "GetAllGenres", logger.Err(err))
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := g.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		g.log.ErrorContext(ctx, "Error executing query", "op", "GetAllGenres", logger.Err(err))
+		return nil, fmt.Errorf("failed to get genres: %w", err)
+	}
+	defer rows.Close()
+
+	genres := []models.Genre{}
+	for rows.Next() {
+		var genre models.Genre
+		if err := rows.Scan(&genre.ID, &genre.Name); err != nil {
+			g.log.ErrorContext(ctx, "Error scanning row", "op", "GetAllGenres", logger.Err(err))
+			return nil, fmt.Errorf("failed to scan genre: %w", err)
+		}
+		genres = append(genres, genre)
+	}
+
+	if err := rows.Err(); err != nil {
+		g.log.ErrorContext(ctx, "Error iterating rows", "op", "GetAllGenres", logger.Err(err))
+		return nil, fmt.Errorf("error occurred while iterating rows: %w", err)
+	}
+
+	return genres, nil
+}
+
+func (g *genre) UpdateGenre(ctx context.Context, tx *sql.Tx, id uuid.UUID, genre models.UpdateGenre) error {
+	defer g.metrics.ObserveQuery("UpdateGenre", time.Now())
+
+	var name *string
+	if genre.Name != nil {
+		trimmed := strings.TrimSpace(*genre.Name)
+		name = &trimmed
+	}
+
+	// Название жанра входит в ответ по фильму, поэтому ETag фильмов жанра должен смениться
+	if name != nil {
+		if err := g.touchGenreMovies(ctx, tx, id, "UpdateGenre"); err != nil {
+			return err
+		}
+	}
+
+	query := sq.
+		Update("genres").
+		Set("name", sq.Expr("COALESCE(?, name)", name)).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		g.log.ErrorContext(ctx, "Error building query", "op", "UpdateGenre", logger.Err(err))
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("genre %q: %w", *name, models.ErrAlreadyExists)
+		}
+		g.log.ErrorContext(ctx, "Error executing query", "op", "UpdateGenre", logger.Err(err))
+		return fmt.Errorf("failed to update genre: %w", err)
+	}
+	return nil
+}
+
+// Удалить жанр, связи с фильмами удаляются каскадно
+func (g *genre) DeleteGenre(ctx context.Context, tx *sql.Tx, id uuid.UUID) error {
+	defer g.metrics.ObserveQuery("DeleteGenre", time.Now())
+
+	// Версии фильмов меняются до удаления, пока связи с жанром еще есть
+	if err := g.touchGenreMovies(ctx, tx, id, "DeleteGenre"); err != nil {
+		return err
+	}
+
+	query := sq.
+		Delete("genres").
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		g.log.ErrorContext(ctx, "Error building query", "op", "DeleteGenre", logger.Err(err))
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		g.log.ErrorContext(ctx, "Error executing query", "op", "DeleteGenre", logger.Err(err))
+		return fmt.Errorf("failed to delete genre: %w", err)
+	}
+	return nil
+}
+
+// Увеличивает версию фильмов жанра: их ответ, а значит и ETag, зависит от жанров
+func (g *genre) touchGenreMovies(ctx context.Context, tx *sql.Tx, id uuid.UUID, op string) error {
+	query := sq.
+		Update("movies").
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Expr("id IN (SELECT movie_id FROM movie_genres WHERE genre_id = ?)", id)).
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		g.log.ErrorContext(ctx, "Error building query", "op", op, logger.Err(err))
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, sqlQuery, args...); err != nil {
+		g.log.ErrorContext(ctx, "Error executing query", "op", op, logger.Err(err))
+		return fmt.Errorf("failed to update genre movies: %w", err)
+	}
+	return nil
+}
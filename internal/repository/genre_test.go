@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateGenre(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewGenre(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	expectedID := uuid.New()
+	mock.ExpectQuery(`INSERT INTO genres \(id,name\) VALUES \(\$1,\$2\) RETURNING "id"`).
+		WithArgs(sqlmock.AnyArg(), "Драма").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(expectedID))
+
+	id, err := repo.CreateGenre(context.Background(), tx, models.CreateGenre{Name: "  Драма "})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedID, id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateGenreDuplicate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewGenre(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(`INSERT INTO genres`).
+		WithArgs(sqlmock.AnyArg(), "Драма").
+		WillReturnError(&pq.Error{Code: uniqueViolation})
+
+	_, err = repo.CreateGenre(context.Background(), tx, models.CreateGenre{Name: "Драма"})
+	assert.ErrorIs(t, err, models.ErrAlreadyExists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAllGenres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewGenre(db, logger.Discard(), nil)
+
+	rows := sqlmock.NewRows([]string{"id", "name"}).
+		AddRow(uuid.New(), "Драма").
+		AddRow(uuid.New(), "Комедия")
+	mock.ExpectQuery(`SELECT id, name FROM genres ORDER BY name ASC`).
+		WillReturnRows(rows)
+
+	genres, err := repo.GetAllGenres(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, genres, 2)
+	assert.Equal(t, "Драма", genres[0].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateGenreBumpsMovieVersions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewGenre(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	id := uuid.New()
+	mock.ExpectExec(`UPDATE movies SET version = version \+ 1, updated_at = now\(\) WHERE id IN \(SELECT movie_id FROM movie_genres WHERE genre_id = \$1\)`).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`UPDATE genres SET name = COALESCE\(\$1, name\) WHERE id = \$2`).
+		WithArgs("Триллер", id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	name := "Триллер"
+	assert.NoError(t, repo.UpdateGenre(context.Background(), tx, id, models.UpdateGenre{Name: &name}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteGenreBumpsMovieVersions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewGenre(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	id := uuid.New()
+	mock.ExpectExec(`UPDATE movies SET version = version \+ 1`).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`DELETE FROM genres WHERE id = \$1`).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.DeleteGenre(context.Background(), tx, id))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
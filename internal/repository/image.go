@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"cinema/internal/logger"
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// Заменить ключ постера фильма, nil убирает постер. Возвращает прежний ключ, чтобы удалить файл
+// после фиксации транзакции; found равен false, если фильма нет или он в корзине.
+func (m *movie) SetMoviePoster(ctx context.Context, tx *sql.Tx, id uuid.UUID, key *string) (previous *string, found bool, err error) {
+	defer m.metrics.ObserveQuery("SetMoviePoster", time.Now())
+
+	return setImage(ctx, tx, m.log, "movies", "poster_key", "SetMoviePoster", id, key)
+}
+
+// Ключ постера фильма не из корзины или nil, если фильма или постера нет
+func (m *movie) GetMoviePoster(ctx context.Context, id uuid.UUID) (*string, error) {
+	defer m.metrics.ObserveQuery("GetMoviePoster", time.Now())
+
+	return getImage(ctx, m.db, m.log, "movies", "poster_key", "GetMoviePoster", id)
+}
+
+// Заменить ключ фотографии актера, см. SetMoviePoster
+func (a *actor) SetActorHeadshot(ctx context.Context, tx *sql.Tx, id uuid.UUID, key *string) (previous *string, found bool, err error) {
+	defer a.metrics.ObserveQuery("SetActorHeadshot", time.Now())
+
+	return setImage(ctx, tx, a.log, "actors", "headshot_key", "SetActorHeadshot", id, key)
+}
+
+// Ключ фотографии актера не из корзины или nil, если актера или фотографии нет
+func (a *actor) GetActorHeadshot(ctx context.Context, id uuid.UUID) (*string, error) {
+	defer a.metrics.ObserveQuery("GetActorHeadshot", time.Now())
+
+	return getImage(ctx, a.db, a.log, "actors", "headshot_key", "GetActorHeadshot", id)
+}
+
+// Изображение входит в представление записи, поэтому его замена увеличивает версию
+func setImage(ctx context.Context, tx *sql.Tx, log *slog.Logger, table, column, op string, id uuid.UUID, key *string) (*string, bool, error) {
+	// Прежний ключ читается с блокировкой строки: параллельная замена дождется этой транзакции
+	// и увидит уже новый ключ, поэтому каждый файл удаляется ровно один раз
+	current, currentArgs, err := sq.
+		Select(column).
+		From(table).
+		Where(sq.Eq{"id": id}).
+		Where(sq.Eq{"deleted_at": nil}).
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		log.ErrorContext(ctx, "Error building query", "op", op, logger.Err(err))
+		return nil, false, fmt.Errorf("failed to build query: %w", err)
+	}
+	update, updateArgs, err := sq.
+		Update(table).
+		Set(column, key).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		log.ErrorContext(ctx, "Error building query", "op", op, logger.Err(err))
+		return nil, false, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var previous sql.NullString
+	if err := tx.QueryRowContext(ctx, current, currentArgs...).Scan(&previous); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		log.ErrorContext(ctx, "Error scanning row", "op", op, logger.Err(err))
+		return nil, false, fmt.Errorf("failed to get current image: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, update, updateArgs...); err != nil {
+		log.ErrorContext(ctx, "Error executing query", "op", op, logger.Err(err))
+		return nil, false, fmt.Errorf("failed to update image: %w", err)
+	}
+	return nullStringPtr(previous), true, nil
+}
+
+func getImage(ctx context.Context, db *sql.DB, log *slog.Logger, table, column, op string, id uuid.UUID) (*string, error) {
+	query, args, err := sq.
+		Select(column).
+		From(table).
+		Where(sq.Eq{"id": id}).
+		Where(sq.Eq{"deleted_at": nil}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		log.ErrorContext(ctx, "Error building query", "op", op, logger.Err(err))
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var key sql.NullString
+	if err := db.QueryRowContext(ctx, query, args...).Scan(&key); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.ErrorContext(ctx, "Error scanning row", "op", op, logger.Err(err))
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+	return nullStringPtr(key), nil
+}
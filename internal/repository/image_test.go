@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"cinema/internal/logger"
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetMoviePosterReturnsPrevious(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMovie(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	movieID := uuid.New()
+	key := "posters/" + movieID.String() + "/new.jpg"
+
+	mock.ExpectQuery(`SELECT poster_key FROM movies WHERE id = \$1 AND deleted_at IS NULL FOR UPDATE`).
+		WithArgs(movieID).
+		WillReturnRows(sqlmock.NewRows([]string{"poster_key"}).AddRow("posters/old.jpg"))
+	mock.ExpectExec(`UPDATE movies SET poster_key = \$1, version = version \+ 1, updated_at = now\(\) WHERE id = \$2`).
+		WithArgs(key, movieID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	previous, found, err := repo.SetMoviePoster(context.Background(), tx, movieID, &key)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "posters/old.jpg", *previous)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetActorHeadshotNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewActor(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	actorID := uuid.New()
+
+	// Актер в корзине: ключ не меняется
+	mock.ExpectQuery(`SELECT headshot_key FROM actors WHERE id = \$1 AND deleted_at IS NULL FOR UPDATE`).
+		WithArgs(actorID).
+		WillReturnRows(sqlmock.NewRows([]string{"headshot_key"}))
+
+	previous, found, err := repo.SetActorHeadshot(context.Background(), tx, actorID, nil)
+	assert.NoError(t, err)
+	assert.False(t, found)
+	assert.Nil(t, previous)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
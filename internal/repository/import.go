@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// ID актеров не из корзины по ссылке из файла импорта, не больше двух:
+// второй ID означает, что ссылка неоднозначна. Запрос идет в транзакции импорта.
+func (a *actor) FindActors(ctx context.Context, tx *sql.Tx, ref models.ActorRef) ([]uuid.UUID, error) {
+	defer a.metrics.ObserveQuery("FindActors", time.Now())
+
+	query := sq.Select("id").From("actors").Where(sq.Eq{"deleted_at": nil})
+	if ref.ID != nil {
+		query = query.Where(sq.Eq{"id": *ref.ID})
+	} else {
+		query = query.Where(sq.Eq{"name": ref.Name}).Where(sq.Eq{"date_of_birth": ref.DateOfBirth})
+	}
+
+	return findIDs(ctx, tx, a.log, "FindActors", query)
+}
+
+// ID фильмов не из корзины по ссылке из файла импорта, не больше двух
+func (m *movie) FindMovies(ctx context.Context, tx *sql.Tx, ref models.MovieRef) ([]uuid.UUID, error) {
+	defer m.metrics.ObserveQuery("FindMovies", time.Now())
+
+	query := sq.Select("id").From("movies").Where(sq.Eq{"deleted_at": nil})
+	if ref.ID != nil {
+		query = query.Where(sq.Eq{"id": *ref.ID})
+	} else {
+		query = query.Where(sq.Eq{"title": ref.Title}).Where(sq.Eq{"release_date": ref.ReleaseDate})
+	}
+
+	return findIDs(ctx, tx, m.log, "FindMovies", query)
+}
+
+func findIDs(ctx context.Context, tx *sql.Tx, log *slog.Logger, op string, query sq.SelectBuilder) ([]uuid.UUID, error) {
+	sqlQuery, args, err := query.OrderBy("id").Limit(2).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		log.ErrorContext(ctx, "Error building query", "op", op, logger.Err(err))
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		log.ErrorContext(ctx, "Error executing query", "op", op, logger.Err(err))
+		return nil, fmt.Errorf("failed to find records: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			log.ErrorContext(ctx, "Error scanning row", "op", op, logger.Err(err))
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.ErrorContext(ctx, "Error iterating rows", "op", op, logger.Err(err))
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindActorsByNameAndBirthDate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewActor(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	birth := time.Date(1977, 9, 15, 0, 0, 0, 0, time.UTC)
+	first, second := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(`SELECT id FROM actors WHERE deleted_at IS NULL AND name = \$1 AND date_of_birth = \$2 ORDER BY id LIMIT 2`).
+		WithArgs("Tom Hardy", birth).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(first).AddRow(second))
+
+	ids, err := repo.FindActors(context.Background(), tx, models.ActorRef{Name: "Tom Hardy", DateOfBirth: &birth})
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{first, second}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindMoviesByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMovie(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	movieID := uuid.New()
+
+	mock.ExpectQuery(`SELECT id FROM movies WHERE deleted_at IS NULL AND id = \$1 ORDER BY id LIMIT 2`).
+		WithArgs(movieID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	ids, err := repo.FindMovies(context.Background(), tx, models.MovieRef{ID: &movieID})
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
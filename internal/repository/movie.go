@@ -1,12 +1,17 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
+	"strings"
 	"time"
 
+	"cinema/internal/logger"
+	"cinema/internal/metrics"
 	"cinema/internal/models"
+	"cinema/internal/pagination"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
@@ -14,125 +19,198 @@ import (
 )
 
 type movie struct {
-	db *sql.DB
+	db      *sql.DB
+	log     *slog.Logger
+	metrics *metrics.Metrics
 }
 
-func NewMovie(db *sql.DB) *movie {
-	return &movie{db: db}
+func NewMovie(db *sql.DB, log *slog.Logger, metrics *metrics.Metrics) *movie {
+	return &movie{db: db, log: log, metrics: metrics}
 }
 
-func (m *movie) BeginTransaction() (*sql.Tx, error) {
-	tx, err := m.db.Begin()
-	if err != nil {
-		log.Printf("[BeginTransaction] Failed to begin transaction: %v", err)
-		return nil, fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	return tx, nil
+func (m *movie) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	return beginTransaction(ctx, m.db, m.log)
 }
 
 // Функция для проверки, существует ли фильм по id
-func (m *movie) CheckMovieExists(movieID uuid.UUID) (bool, error) {
+func (m *movie) CheckMovieExists(ctx context.Context, movieID uuid.UUID) (bool, error) {
+	defer m.metrics.ObserveQuery("CheckMovieExists", time.Now())
+
+	// Удаленный фильм считается несуществующим
 	query := sq.
-		Select("EXISTS (SELECT 1 FROM movies WHERE id = $1)").
-		PlaceholderFormat(sq.Dollar).
-		From("movies").
-		Where(sq.Eq{"id": movieID})
+		Select().
+		Column(sq.Expr("EXISTS (SELECT 1 FROM movies WHERE id = ? AND deleted_at IS NULL)", movieID)).
+		PlaceholderFormat(sq.Dollar)
 
 	sqlQuery, args, err := query.ToSql()
 	if err != nil {
-		log.Printf("[CheckMovieExists] Error building query for checking movie existence: %v", err)
+		m.log.ErrorContext(ctx, "Error building query for checking movie existence", "op", "CheckMovieExists", logger.Err(err))
 		return false, fmt.Errorf("failed to build query: %w", err)
 	}
 
 	var exists bool
-	err = m.db.QueryRow(sqlQuery, args...).Scan(&exists)
+	err = m.db.QueryRowContext(ctx, sqlQuery, args...).Scan(&exists)
 	if err != nil {
-		log.Printf("[CheckMovieExists] Error checking movie existence: %v", err)
+		m.log.ErrorContext(ctx, "Error checking movie existence", "op", "CheckMovieExists", logger.Err(err))
 		return false, fmt.Errorf("error checking movie existence: %w", err)
 	}
 
 	return exists, nil
 }
 
-func (r *movie) CheckActorsExist(actorIDs []uuid.UUID) (bool, error) {
+// Блокирует фильм до конца транзакции tx, чтобы его не удалили, пока меняются связанные записи.
+// Фильма нет или он в корзине - models.ErrNotFound.
+func (m *movie) LockMovie(ctx context.Context, tx *sql.Tx, movieID uuid.UUID) error {
+	defer m.metrics.ObserveQuery("LockMovie", time.Now())
+
+	query := sq.
+		Select("id").
+		From("movies").
+		Where(sq.Eq{"id": movieID}).
+		Where(sq.Eq{"deleted_at": nil}).
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error building query", "op", "LockMovie", logger.Err(err))
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var id uuid.UUID
+	if err := tx.QueryRowContext(ctx, sqlQuery, args...).Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return models.ErrNotFound
+		}
+		m.log.ErrorContext(ctx, "Error locking movie", "op", "LockMovie", logger.Err(err))
+		return fmt.Errorf("failed to lock movie: %w", err)
+	}
+	return nil
+}
+
+func (r *movie) CheckActorsExist(ctx context.Context, actorIDs []uuid.UUID) (bool, error) {
+	defer r.metrics.ObserveQuery("CheckActorsExist", time.Now())
+
 	if len(actorIDs) == 0 {
 		return true, nil // Пустой список валиден
 	}
 
+	// Повторы в списке не должны влиять на сравнение количества
+	unique := make(map[uuid.UUID]struct{}, len(actorIDs))
+	for _, id := range actorIDs {
+		unique[id] = struct{}{}
+	}
+
 	query := sq.
 		Select("COUNT(*)").
 		From("actors").
 		Where(sq.Eq{"id": actorIDs}).
+		Where(sq.Eq{"deleted_at": nil}).
 		PlaceholderFormat(sq.Dollar)
 
 	sqlQuery, args, err := query.ToSql()
 	if err != nil {
-		log.Printf("[CheckActorsExist] Error building query: %v", err)
+		r.log.ErrorContext(ctx, "Error building query", "op", "CheckActorsExist", logger.Err(err))
 		return false, fmt.Errorf("failed to build query: %w", err)
 	}
 
 	var count int
-	err = r.db.QueryRow(sqlQuery, args...).Scan(&count)
+	err = r.db.QueryRowContext(ctx, sqlQuery, args...).Scan(&count)
 	if err != nil {
-		log.Printf("[CheckActorsExist] Error checking actors existence: %v", err)
+		r.log.ErrorContext(ctx, "Error checking actors existence", "op", "CheckActorsExist", logger.Err(err))
 		return false, fmt.Errorf("failed to check actors existence: %w", err)
 	}
 
-	// Проверяем, что количество найденных записей совпадает с количеством переданных ID
-	return count == len(actorIDs), nil
+	// Проверяем, что количество найденных записей совпадает с количеством разных переданных ID
+	return count == len(unique), nil
 }
 
-func (r *movie) AddMovieActorRelations(tx *sql.Tx, movieID uuid.UUID, actorIDs []uuid.UUID) error {
-	if len(actorIDs) == 0 {
+// Добавление записей титров. Если у человека уже есть эта роль в фильме,
+// обновляются имя персонажа и порядок в титрах.
+func (r *movie) AddMovieActorRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID, credits []models.CreditInput) error {
+	defer r.metrics.ObserveQuery("AddMovieActorRelations", time.Now())
+
+	if len(credits) == 0 {
 		return nil // Если нет актеров для добавления, ничего не делаем
 	}
 
-	// Строим запрос на добавление всех связей актера с фильмом
+	// Строим запрос на добавление всех записей титров фильма
 	queryBuilder := sq.Insert("movie_actors").
-		Columns("movie_id", "actor_id").
-		Suffix("ON CONFLICT (movie_id, actor_id) DO NOTHING") // Для предотвращения дублирования
+		Columns("movie_id", "actor_id", "role", "character_name", "billing_order").
+		Suffix("ON CONFLICT (movie_id, actor_id, role) DO UPDATE SET " +
+			"character_name = EXCLUDED.character_name, billing_order = EXCLUDED.billing_order")
 
-	// Добавляем каждую пару movie_id, actor_id
-	for _, actorID := range actorIDs {
-		queryBuilder = queryBuilder.Values(movieID, actorID)
+	for _, credit := range credits {
+		queryBuilder = queryBuilder.Values(movieID, credit.ActorID, credit.Role, credit.Character, credit.Order)
 	}
 
 	// Генерация SQL-запроса
 	sqlQuery, args, err := queryBuilder.PlaceholderFormat(sq.Dollar).ToSql()
 	if err != nil {
-		log.Printf("[AddMovieActorRelations] Error building query: %v", err)
+		r.log.ErrorContext(ctx, "Error building query", "op", "AddMovieActorRelations", logger.Err(err))
 		return fmt.Errorf("failed to build query: %w", err)
 	}
 
 	// Выполнение запроса
-	_, err = tx.Exec(sqlQuery, args...)
+	_, err = tx.ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
-		log.Printf("[AddMovieActorRelations] Error adding movie-actor relations: %v", err)
+		r.log.ErrorContext(ctx, "Error adding movie-actor relations", "op", "AddMovieActorRelations", logger.Err(err))
 		return fmt.Errorf("failed to add movie-actor relations: %w", err)
 	}
 
 	return nil
 }
 
-// Удаление связей по movieID
-func (m *movie) RemoveMovieActorRelations(tx *sql.Tx, movieID uuid.UUID) error {
-	query := sq.Delete("movie_actors").Where(sq.Eq{"movie_id": movieID})
+// Удаление связей по movieID. Связи с актерами в корзине сохраняются,
+// чтобы вернуться в фильм при восстановлении актера.
+func (m *movie) RemoveMovieActorRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID) error {
+	defer m.metrics.ObserveQuery("RemoveMovieActorRelations", time.Now())
+
+	query := sq.Delete("movie_actors").
+		Where(sq.Eq{"movie_id": movieID}).
+		Where("actor_id IN (SELECT id FROM actors WHERE deleted_at IS NULL)")
 
 	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
 	if err != nil {
-		log.Printf("[RemoveMovieActorRelations] Error building delete query: %v", err)
+		m.log.ErrorContext(ctx, "Error building delete query", "op", "RemoveMovieActorRelations", logger.Err(err))
 		return fmt.Errorf("failed to build delete query: %w", err)
 	}
 
-	_, err = tx.Exec(sqlQuery, args...)
+	_, err = tx.ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
-		log.Printf("[RemoveMovieActorRelations] Error deleting old relations: %v", err)
+		m.log.ErrorContext(ctx, "Error deleting old relations", "op", "RemoveMovieActorRelations", logger.Err(err))
 		return fmt.Errorf("failed to delete old relations: %w", err)
 	}
 	return nil
 }
 
-func (r *movie) RemoveSelectedMovieActorRelations(tx *sql.Tx, movieID uuid.UUID, actorIDs []uuid.UUID) error {
+// Удаление актерского состава фильма. Записи съемочной группы и связи с актерами
+// в корзине сохраняются.
+func (m *movie) RemoveMovieCastRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID) error {
+	defer m.metrics.ObserveQuery("RemoveMovieCastRelations", time.Now())
+
+	query := sq.Delete("movie_actors").
+		Where(sq.Eq{"movie_id": movieID, "role": models.RoleActor}).
+		Where("actor_id IN (SELECT id FROM actors WHERE deleted_at IS NULL)")
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error building delete query", "op", "RemoveMovieCastRelations", logger.Err(err))
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error deleting old cast", "op", "RemoveMovieCastRelations", logger.Err(err))
+		return fmt.Errorf("failed to delete old cast: %w", err)
+	}
+	return nil
+}
+
+// Удаление всех записей титров указанных людей в фильме
+func (r *movie) RemoveSelectedMovieActorRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID, actorIDs []uuid.UUID) error {
+	defer r.metrics.ObserveQuery("RemoveSelectedMovieActorRelations", time.Now())
+
 	if len(actorIDs) == 0 {
 		return nil // Если нет актеров, которых нужно удалить, ничего не делаем
 	}
@@ -145,21 +223,156 @@ func (r *movie) RemoveSelectedMovieActorRelations(tx *sql.Tx, movieID uuid.UUID,
 
 	sqlQuery, args, err := query.ToSql()
 	if err != nil {
-		log.Printf("[RemoveSelectedMovieActorRelations] Error building delete query: %v", err)
+		r.log.ErrorContext(ctx, "Error building delete query", "op", "RemoveSelectedMovieActorRelations", logger.Err(err))
 		return fmt.Errorf("failed to build query: %w", err)
 	}
 
 	// Выполнение запроса
-	_, err = tx.Exec(sqlQuery, args...)
+	_, err = tx.ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
-		log.Printf("[RemoveSelectedMovieActorRelations] Error removing movie-actor relations: %v", err)
+		r.log.ErrorContext(ctx, "Error removing movie-actor relations", "op", "RemoveSelectedMovieActorRelations", logger.Err(err))
 		return fmt.Errorf("failed to remove movie-actor relations: %w", err)
 	}
 
 	return nil
 }
 
-func (m *movie) CreateMovie(tx *sql.Tx, movie models.CreateMovie) (uuid.UUID, error) {
+// Титры фильма, упорядоченные по порядку в титрах, затем по имени
+func (m *movie) GetMovieCredits(ctx context.Context, movieID uuid.UUID) ([]models.Credit, error) {
+	defer m.metrics.ObserveQuery("GetMovieCredits", time.Now())
+
+	query := sq.
+		Select("ma.actor_id", "a.name", "ma.role", "ma.character_name", "ma.billing_order").
+		From("movie_actors ma").
+		Join("actors a ON a.id = ma.actor_id").
+		Where(sq.Eq{"ma.movie_id": movieID}).
+		Where(sq.Eq{"a.deleted_at": nil}).
+		OrderBy("ma.billing_order ASC NULLS LAST", "a.name ASC", "ma.actor_id ASC").
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error building query", "op", "GetMovieCredits", logger.Err(err))
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := m.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error executing query", "op", "GetMovieCredits", logger.Err(err))
+		return nil, fmt.Errorf("failed to get movie credits: %w", err)
+	}
+	defer rows.Close()
+
+	credits := []models.Credit{}
+	for rows.Next() {
+		var credit models.Credit
+		var character sql.NullString
+		var order sql.NullInt64
+		if err := rows.Scan(&credit.ActorID, &credit.Name, &credit.Role, &character, &order); err != nil {
+			m.log.ErrorContext(ctx, "Error scanning row", "op", "GetMovieCredits", logger.Err(err))
+			return nil, fmt.Errorf("failed to scan credit: %w", err)
+		}
+		credit.Character = nullStringPtr(character)
+		credit.Order = nullIntPtr(order)
+		credits = append(credits, credit)
+	}
+
+	if err := rows.Err(); err != nil {
+		m.log.ErrorContext(ctx, "Error iterating rows", "op", "GetMovieCredits", logger.Err(err))
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return credits, nil
+}
+
+func (m *movie) CheckGenresExist(ctx context.Context, genreIDs []uuid.UUID) (bool, error) {
+	defer m.metrics.ObserveQuery("CheckGenresExist", time.Now())
+
+	if len(genreIDs) == 0 {
+		return true, nil // Пустой список валиден
+	}
+
+	// Повторы в списке не должны влиять на сравнение количества
+	unique := make(map[uuid.UUID]struct{}, len(genreIDs))
+	for _, id := range genreIDs {
+		unique[id] = struct{}{}
+	}
+
+	query := sq.
+		Select("COUNT(*)").
+		From("genres").
+		Where(sq.Eq{"id": genreIDs}).
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error building query", "op", "CheckGenresExist", logger.Err(err))
+		return false, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var count int
+	err = m.db.QueryRowContext(ctx, sqlQuery, args...).Scan(&count)
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error checking genres existence", "op", "CheckGenresExist", logger.Err(err))
+		return false, fmt.Errorf("failed to check genres existence: %w", err)
+	}
+
+	return count == len(unique), nil
+}
+
+func (m *movie) AddMovieGenreRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID, genreIDs []uuid.UUID) error {
+	defer m.metrics.ObserveQuery("AddMovieGenreRelations", time.Now())
+
+	if len(genreIDs) == 0 {
+		return nil
+	}
+
+	queryBuilder := sq.Insert("movie_genres").
+		Columns("movie_id", "genre_id").
+		Suffix("ON CONFLICT (movie_id, genre_id) DO NOTHING")
+
+	for _, genreID := range genreIDs {
+		queryBuilder = queryBuilder.Values(movieID, genreID)
+	}
+
+	sqlQuery, args, err := queryBuilder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error building query", "op", "AddMovieGenreRelations", logger.Err(err))
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error adding movie-genre relations", "op", "AddMovieGenreRelations", logger.Err(err))
+		return fmt.Errorf("failed to add movie-genre relations: %w", err)
+	}
+
+	return nil
+}
+
+// Удаление всех жанров фильма, используется при замене списка жанров
+func (m *movie) RemoveMovieGenreRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID) error {
+	defer m.metrics.ObserveQuery("RemoveMovieGenreRelations", time.Now())
+
+	query := sq.Delete("movie_genres").Where(sq.Eq{"movie_id": movieID})
+
+	sqlQuery, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error building delete query", "op", "RemoveMovieGenreRelations", logger.Err(err))
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error deleting movie genres", "op", "RemoveMovieGenreRelations", logger.Err(err))
+		return fmt.Errorf("failed to delete movie genres: %w", err)
+	}
+	return nil
+}
+
+func (m *movie) CreateMovie(ctx context.Context, tx *sql.Tx, movie models.CreateMovie) (uuid.UUID, error) {
+	defer m.metrics.ObserveQuery("CreateMovie", time.Now())
+
 	id := uuid.New()
 	query := sq.
 		Insert("movies").
@@ -170,258 +383,405 @@ func (m *movie) CreateMovie(tx *sql.Tx, movie models.CreateMovie) (uuid.UUID, er
 
 	sqlQuery, args, err := query.ToSql()
 	if err != nil {
-		log.Printf("[CreateMovie] Error building query for adding movie: %v", err)
+		m.log.ErrorContext(ctx, "Error building query for adding movie", "op", "CreateMovie", logger.Err(err))
 		return uuid.Nil, fmt.Errorf("failed to build query: %w", err)
 	}
 
-	err = tx.QueryRow(sqlQuery, args...).Scan(&id)
+	err = tx.QueryRowContext(ctx, sqlQuery, args...).Scan(&id)
 	if err != nil {
-		log.Printf("[CreateMovie] Error adding movie: %v", err)
+		m.log.ErrorContext(ctx, "Error adding movie", "op", "CreateMovie", logger.Err(err))
 		return uuid.Nil, fmt.Errorf("failed to add movie: %w", err)
 	}
 
 	return id, nil
 }
 
-func (m *movie) GetMovieByID(id uuid.UUID) (map[string]interface{}, error) {
+func (m *movie) GetMovieByID(ctx context.Context, id uuid.UUID) (*models.Movie, error) {
+	defer m.metrics.ObserveQuery("GetMovieByID", time.Now())
+
 	query := sq.
-		Select("id", "title", "description", "release_date", "rating").
+		Select(movieColumns("movies")...).
 		From("movies").
 		Where(sq.Eq{"id": id}).
+		Where(sq.Eq{"deleted_at": nil}).
 		PlaceholderFormat(sq.Dollar)
 
 	sqlQuery, args, err := query.ToSql()
 	if err != nil {
-		log.Printf("[GetMovieByID] Error building query: %v", err)
+		m.log.ErrorContext(ctx, "Error building query", "op", "GetMovieByID", logger.Err(err))
 		return nil, fmt.Errorf("failed to build query: %w", err)
 	}
-	var idRaw uuid.UUID
-	var title, description string
-	var releaseDate time.Time
-	var rating float64
-	err = m.db.QueryRow(sqlQuery, args...).Scan(&idRaw, &title, &description, &releaseDate, &rating)
+
+	movie, err := scanMovie(m.db.QueryRowContext(ctx, sqlQuery, args...))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil // Фильм не найден
 		}
-		log.Printf("[GetMovieByID] Error scanning row: %v", err)
+		m.log.ErrorContext(ctx, "Error scanning row", "op", "GetMovieByID", logger.Err(err))
 		return nil, fmt.Errorf("failed to get movie: %w", err)
 	}
 
-	rawData := map[string]interface{}{
-		"id":           idRaw,
-		"title":        title,
-		"description":  description,
-		"release_date": releaseDate,
-		"rating":       rating,
-	}
-
-	return rawData, nil
+	return &movie, nil
 }
 
-func (m *movie) GetMoviesByActorID(actorID uuid.UUID, limit, offset int) ([]map[string]interface{}, error) {
+// Фильмы актера от новых к старым, after - курсор последней записи предыдущей страницы
+func (m *movie) GetMoviesByActorID(ctx context.Context, actorID uuid.UUID, after *pagination.Cursor, limit int) ([]models.Movie, error) {
+	defer m.metrics.ObserveQuery("GetMoviesByActorID", time.Now())
+
 	query := sq.
-		Select("m.id", "m.title", "m.description", "m.release_date", "m.rating").
+		Select(movieColumns("m")...).
 		From("movies m").
-		Join("movie_actors ma ON m.id = ma.movie_id").
-		Where(sq.Eq{"ma.actor_id": actorID}).
+		// EXISTS, а не JOIN: у человека может быть несколько ролей в одном фильме.
+		// У актера в корзине фильмов нет, как нет и его самого.
+		Where(sq.Expr("EXISTS (SELECT 1 FROM movie_actors ma JOIN actors a ON a.id = ma.actor_id "+
+			"WHERE ma.movie_id = m.id AND ma.actor_id = ? AND a.deleted_at IS NULL)", actorID)).
+		Where(sq.Eq{"m.deleted_at": nil}).
+		OrderBy(orderNullsLast("m.release_date", "m.id", "DESC")...).
 		Limit(uint64(limit)).
-		Offset(uint64(offset)).
 		PlaceholderFormat(sq.Dollar)
 
+	if after != nil {
+		query = query.Where(keysetAfterNullsLast("m.release_date", "m.id", "DESC", after))
+	}
+
 	sqlQuery, args, err := query.ToSql()
 	if err != nil {
-		log.Printf("[GetMoviesByActorID] Error building query: %v", err)
+		m.log.ErrorContext(ctx, "Error building query", "op", "GetMoviesByActorID", logger.Err(err))
 		return nil, fmt.Errorf("failed to build query: %w", err)
 	}
 
-	rows, err := m.db.Query(sqlQuery, args...)
+	rows, err := m.db.QueryContext(ctx, sqlQuery, args...)
 	if err != nil {
-		log.Printf("[GetMoviesByActorID] Error executing query: %v", err)
+		m.log.ErrorContext(ctx, "Error executing query", "op", "GetMoviesByActorID", logger.Err(err))
 		return nil, fmt.Errorf("failed to get movie by actorID: %w", err)
 	}
 	defer rows.Close()
 
-	var rawData []map[string]interface{}
+	movies := make([]models.Movie, 0, limit)
 	for rows.Next() {
-		var id uuid.UUID
-		var title, description string
-		var releaseDate time.Time
-		var rating float64
-		if err := rows.Scan(&id, &title, &description, &releaseDate, &rating); err != nil {
-			log.Printf("[GetMoviesByActorID] Error scanning row: %v", err)
+		movie, err := scanMovie(rows)
+		if err != nil {
+			m.log.ErrorContext(ctx, "Error scanning row", "op", "GetMoviesByActorID", logger.Err(err))
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
-		movieData := map[string]interface{}{
-			"id":           id,
-			"title":        title,
-			"description":  description,
-			"release_date": releaseDate,
-			"rating":       rating,
+		movies = append(movies, movie)
+	}
+
+	if err := rows.Err(); err != nil {
+		m.log.ErrorContext(ctx, "Error iterating rows", "op", "GetMoviesByActorID", logger.Err(err))
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return movies, nil
+}
+
+// Количество фильмов актера
+func (m *movie) CountMoviesByActorID(ctx context.Context, actorID uuid.UUID) (int64, error) {
+	defer m.metrics.ObserveQuery("CountMoviesByActorID", time.Now())
+
+	query := sq.
+		Select("COUNT(DISTINCT ma.movie_id)").
+		From("movie_actors ma").
+		Join("movies m ON m.id = ma.movie_id").
+		Join("actors a ON a.id = ma.actor_id").
+		Where(sq.Eq{"ma.actor_id": actorID}).
+		Where(sq.Eq{"m.deleted_at": nil}).
+		Where(sq.Eq{"a.deleted_at": nil}).
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error building query", "op", "CountMoviesByActorID", logger.Err(err))
+		return 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var count int64
+	if err := m.db.QueryRowContext(ctx, sqlQuery, args...).Scan(&count); err != nil {
+		m.log.ErrorContext(ctx, "Error executing query", "op", "CountMoviesByActorID", logger.Err(err))
+		return 0, fmt.Errorf("failed to count movies by actorID: %w", err)
+	}
+	return count, nil
+}
+
+// Колонки, по которым разрешена сортировка списка фильмов
+var movieSortColumns = map[string]struct{}{
+	"title":        {},
+	"release_date": {},
+	"rating":       {},
+}
+
+// Экранирование спецсимволов LIKE, чтобы префикс искался буквально
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Условия фильтра для запроса к таблице movies. Все значения передаются параметрами.
+// Фильмы в корзине в выборку не попадают.
+func applyMovieFilter(query sq.SelectBuilder, filter models.MovieFilter) sq.SelectBuilder {
+	query = query.Where(sq.Eq{"deleted_at": nil})
+
+	if filter.MinRating != nil {
+		query = query.Where(sq.GtOrEq{"rating": *filter.MinRating})
+	}
+	if filter.MaxRating != nil {
+		query = query.Where(sq.LtOrEq{"rating": *filter.MaxRating})
+	}
+	if filter.ReleasedFrom != nil {
+		query = query.Where(sq.GtOrEq{"release_date": *filter.ReleasedFrom})
+	}
+	if filter.ReleasedTo != nil {
+		query = query.Where(sq.LtOrEq{"release_date": *filter.ReleasedTo})
+	}
+	// Годы превращаются в диапазон дат, чтобы условие могло использовать индекс по release_date
+	if filter.YearFrom != nil {
+		query = query.Where(sq.GtOrEq{"release_date": time.Date(*filter.YearFrom, 1, 1, 0, 0, 0, 0, time.UTC)})
+	}
+	if filter.YearTo != nil {
+		query = query.Where(sq.Lt{"release_date": time.Date(*filter.YearTo+1, 1, 1, 0, 0, 0, 0, time.UTC)})
+	}
+
+	if len(filter.ActorIDs) > 0 {
+		// Убираем повторы, иначе режим all никогда не совпадет по количеству
+		unique := make(map[uuid.UUID]struct{}, len(filter.ActorIDs))
+		actorIDs := make([]uuid.UUID, 0, len(filter.ActorIDs))
+		for _, id := range filter.ActorIDs {
+			if _, ok := unique[id]; !ok {
+				unique[id] = struct{}{}
+				actorIDs = append(actorIDs, id)
+			}
+		}
+
+		// Актеры в корзине не совпадают ни с одним фильмом
+		if filter.ActorMatch == models.ActorMatchAll {
+			matched := sq.
+				Select("COUNT(DISTINCT ma.actor_id)").
+				From("movie_actors ma").
+				Join("actors a ON a.id = ma.actor_id").
+				Where("ma.movie_id = movies.id").
+				Where(sq.Eq{"ma.actor_id": actorIDs}).
+				Where(sq.Eq{"a.deleted_at": nil})
+			query = query.Where(sq.Expr("(?) = ?", matched, len(actorIDs)))
+		} else {
+			matched := sq.
+				Select("1").
+				From("movie_actors ma").
+				Join("actors a ON a.id = ma.actor_id").
+				Where("ma.movie_id = movies.id").
+				Where(sq.Eq{"ma.actor_id": actorIDs}).
+				Where(sq.Eq{"a.deleted_at": nil})
+			query = query.Where(sq.Expr("EXISTS (?)", matched))
+		}
+	}
+
+	if len(filter.GenreIDs) > 0 {
+		// Фильм подходит, если у него есть хотя бы один из жанров
+		matched := sq.
+			Select("1").
+			From("movie_genres mg").
+			Where("mg.movie_id = movies.id").
+			Where(sq.Eq{"mg.genre_id": filter.GenreIDs})
+		query = query.Where(sq.Expr("EXISTS (?)", matched))
+	}
+
+	if filter.TitlePrefix != "" {
+		query = query.Where(sq.ILike{"title": likeEscaper.Replace(filter.TitlePrefix) + "%"})
+	}
+
+	if filter.HasDescription != nil {
+		if *filter.HasDescription {
+			query = query.Where("description IS NOT NULL AND description <> ''")
+		} else {
+			query = query.Where("(description IS NULL OR description = '')")
 		}
-		rawData = append(rawData, movieData)
 	}
-	return rawData, nil
+
+	return query
 }
 
-func (m *movie) GetMoviesWithFilters(sortBy string, order string, limit, offset int) ([]map[string]interface{}, error) {
+// Список фильмов по фильтру с сортировкой по sortBy, after - курсор последней записи предыдущей страницы
+func (m *movie) GetMoviesWithFilters(ctx context.Context, filter models.MovieFilter, sortBy string, order string, after *pagination.Cursor, limit int) ([]models.Movie, error) {
+	defer m.metrics.ObserveQuery("GetMoviesWithFilters", time.Now())
+
+	// sortBy и order попадают в SQL как текст, поэтому проверяем их здесь еще раз
+	if _, ok := movieSortColumns[sortBy]; !ok {
+		return nil, fmt.Errorf("invalid sort column: %q", sortBy)
+	}
+	if order != "ASC" && order != "DESC" {
+		return nil, fmt.Errorf("invalid sort order: %q", order)
+	}
+
 	// Строим SQL запрос
 	query := sq.
-		Select("id", "title", "description", "release_date", "rating").
+		Select(movieColumns("movies")...).
 		From("movies").
-		OrderBy(fmt.Sprintf("%s %s", sortBy, order)).
+		OrderBy(orderNullsLast(sortBy, "id", order)...).
 		Limit(uint64(limit)).
-		Offset(uint64(offset)).
 		PlaceholderFormat(sq.Dollar)
 
+	query = applyMovieFilter(query, filter)
+	if after != nil {
+		query = query.Where(keysetAfterNullsLast(sortBy, "id", order, after))
+	}
+
 	// Преобразуем запрос в SQL
 	sqlQuery, args, err := query.ToSql()
 	if err != nil {
-		log.Printf("[GetMoviesWithFilters] Error building query: %v", err)
+		m.log.ErrorContext(ctx, "Error building query", "op", "GetMoviesWithFilters", logger.Err(err))
 		return nil, fmt.Errorf("failed to build query: %w", err)
 	}
 
 	// Выполняем запрос
-	rows, err := m.db.Query(sqlQuery, args...)
+	rows, err := m.db.QueryContext(ctx, sqlQuery, args...)
 	if err != nil {
-		log.Printf("[GetMoviesWithFilters] Error executing query: %v", err)
+		m.log.ErrorContext(ctx, "Error executing query", "op", "GetMoviesWithFilters", logger.Err(err))
 		return nil, fmt.Errorf("failed to get movies with filtration: %w", err)
 	}
 	defer rows.Close()
 
-	var rawMovies []map[string]interface{}
-	// Считываем результаты
+	movies := make([]models.Movie, 0, limit)
 	for rows.Next() {
-		var id uuid.UUID
-		var title, description string
-		var releaseDate time.Time
-		var rating float64
-		err := rows.Scan(&id, &title, &description, &releaseDate, &rating)
+		movie, err := scanMovie(rows)
 		if err != nil {
-			log.Printf("[GetMoviesWithFilters] Error scanning row: %v", err)
+			m.log.ErrorContext(ctx, "Error scanning row", "op", "GetMoviesWithFilters", logger.Err(err))
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
-
-		// Добавляем сырые данные в срез
-		rawMovie := map[string]interface{}{
-			"id":           id,
-			"title":        title,
-			"description":  description,
-			"release_date": releaseDate,
-			"rating":       rating,
-		}
-		rawMovies = append(rawMovies, rawMovie)
+		movies = append(movies, movie)
 	}
 
 	if err := rows.Err(); err != nil {
-		log.Printf("[GetMoviesWithFilters] Error iterating rows: %v", err)
+		m.log.ErrorContext(ctx, "Error iterating rows", "op", "GetMoviesWithFilters", logger.Err(err))
 		return nil, fmt.Errorf("failed to iterate rows: %w", err)
 	}
 
-	return rawMovies, nil
+	return movies, nil
 }
 
-func (m *movie) SearchMoviesByTitleAndActor(filterTitle, filterActor string, limit, offset int) ([]map[string]interface{}, error) {
+// Количество фильмов, подходящих под фильтр
+func (m *movie) CountMovies(ctx context.Context, filter models.MovieFilter) (int64, error) {
+	defer m.metrics.ObserveQuery("CountMovies", time.Now())
+
 	query := sq.
-		Select("DISTINCT m.id", "m.title", "m.description", "m.release_date", "m.rating").
-		From("movies m").
-		LeftJoin("movie_actors ma ON m.id = ma.movie_id").
-		LeftJoin("actors a ON ma.actor_id = a.id").
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
+		Select("COUNT(*)").
+		From("movies").
 		PlaceholderFormat(sq.Dollar)
+	query = applyMovieFilter(query, filter)
 
-	// Добавляем условия только при наличии фильтров
-	if filterTitle != "" {
-		query = query.Where(sq.ILike{"m.title": fmt.Sprintf("%%%s%%", filterTitle)})
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error building query", "op", "CountMovies", logger.Err(err))
+		return 0, fmt.Errorf("failed to build query: %w", err)
 	}
-	if filterActor != "" {
-		query = query.Where(sq.ILike{"a.name": fmt.Sprintf("%%%s%%", filterActor)})
+
+	var count int64
+	if err := m.db.QueryRowContext(ctx, sqlQuery, args...).Scan(&count); err != nil {
+		m.log.ErrorContext(ctx, "Error executing query", "op", "CountMovies", logger.Err(err))
+		return 0, fmt.Errorf("failed to count movies: %w", err)
 	}
+	return count, nil
+}
+
+// Полнотекстовый поиск по названию, описанию и актерам с ранжированием по релевантности
+func (m *movie) SearchMovies(ctx context.Context, searchQuery string, limit, offset int) ([]models.MovieSearchResult, error) {
+	defer m.metrics.ObserveQuery("SearchMovies", time.Now())
+
+	query := sq.
+		Select(movieColumns("m")...).
+		Column("ts_rank_cd(m.search_vector, q) AS rank").
+		Column("ts_headline('russian', m.title, q, 'HighlightAll=true') AS title_highlight").
+		Column("ts_headline('russian', COALESCE(m.description, ''), q, 'MaxFragments=2, MinWords=5, MaxWords=25') AS description_snippet").
+		From("movies m").
+		CrossJoin("websearch_to_tsquery('russian', ?) AS q", searchQuery).
+		Where("m.search_vector @@ q").
+		Where(sq.Eq{"m.deleted_at": nil}).
+		OrderBy("rank DESC", "m.id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		PlaceholderFormat(sq.Dollar)
 
 	// Конвертация в SQL
 	sqlQuery, args, err := query.ToSql()
 	if err != nil {
-		log.Printf("[SearchMoviesByTitleAndActor] Error building query: %v", err)
+		m.log.ErrorContext(ctx, "Error building query", "op", "SearchMovies", logger.Err(err))
 		return nil, fmt.Errorf("failed to build query: %w", err)
 	}
 
 	// Выполнение запроса
-	rows, err := m.db.Query(sqlQuery, args...)
+	rows, err := m.db.QueryContext(ctx, sqlQuery, args...)
 	if err != nil {
-		log.Printf("[SearchMoviesByTitleAndActor] Error executing query: %v", err)
+		m.log.ErrorContext(ctx, "Error executing query", "op", "SearchMovies", logger.Err(err))
 		return nil, fmt.Errorf("failed to search movies: %w", err)
 	}
 	defer rows.Close()
 
 	// Обработка результатов
-	var rawMovies []map[string]interface{}
+	results := []models.MovieSearchResult{}
 	for rows.Next() {
-		var id uuid.UUID
-		var title, description string
-		var releaseDate time.Time
-		var rating float64
-		err := rows.Scan(&id, &title, &description, &releaseDate, &rating)
+		var result models.MovieSearchResult
+		result.Movie, err = scanMovie(rows, &result.Rank, &result.TitleHighlight, &result.DescriptionSnippet)
 		if err != nil {
-			log.Printf("[SearchMoviesByTitleAndActor] Error scanning row: %v", err)
+			m.log.ErrorContext(ctx, "Error scanning row", "op", "SearchMovies", logger.Err(err))
 			return nil, fmt.Errorf("failed to scan movie: %w", err)
 		}
+		results = append(results, result)
+	}
 
-		rawMovie := map[string]interface{}{
-			"id":           id,
-			"title":        title,
-			"description":  description,
-			"release_date": releaseDate,
-			"rating":       rating,
-		}
-		rawMovies = append(rawMovies, rawMovie)
+	if err := rows.Err(); err != nil {
+		m.log.ErrorContext(ctx, "Error iterating rows", "op", "SearchMovies", logger.Err(err))
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
 	}
 
-	return rawMovies, nil
+	return results, nil
 }
 
 // Обновить фильм
-func (m *movie) UpdateMovie(tx *sql.Tx, id uuid.UUID, movie models.UpdateMovie) error {
+func (m *movie) UpdateMovie(ctx context.Context, tx *sql.Tx, id uuid.UUID, movie models.UpdateMovie, version *int64) error {
+	defer m.metrics.ObserveQuery("UpdateMovie", time.Now())
+
 	query := sq.
 		Update("movies").
 		Set("title", sq.Expr("COALESCE(?, title)", movie.Title)).
 		Set("description", sq.Expr("COALESCE(?, description)", movie.Description)).
 		Set("release_date", sq.Expr("COALESCE(?, release_date)", movie.ReleaseDate)).
 		Set("rating", sq.Expr("COALESCE(?, rating)", movie.Rating)).
-		Where(sq.Eq{"id": id}).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(whereVersion(id, version)).
 		PlaceholderFormat(sq.Dollar)
 
 	sqlQuery, args, err := query.ToSql()
 	if err != nil {
-		log.Printf("[UpdateMovie] Error building query: %v", err)
+		m.log.ErrorContext(ctx, "Error building query", "op", "UpdateMovie", logger.Err(err))
 		return fmt.Errorf("failed to build query: %w", err)
 	}
 
-	_, err = tx.Exec(sqlQuery, args...)
+	result, err := tx.ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
-		log.Printf("[UpdateMovie] Error executing query: %v", err)
+		m.log.ErrorContext(ctx, "Error executing query", "op", "UpdateMovie", logger.Err(err))
 		return fmt.Errorf("failed to update movie: %w", err)
 	}
-	return nil
+	return checkVersion(result, version)
 }
 
-// Удалить фильм по id
-func (m *movie) DeleteMovie(id uuid.UUID) error {
+// Переместить фильм в корзину. Связи с актерами и жанрами остаются, чтобы фильм можно было восстановить.
+func (m *movie) DeleteMovie(ctx context.Context, tx *sql.Tx, id uuid.UUID, version *int64) error {
+	defer m.metrics.ObserveQuery("DeleteMovie", time.Now())
+
 	query := sq.
-		Delete("movies").
-		Where(sq.Eq{"id": id}).
+		Update("movies").
+		Set("deleted_at", sq.Expr("now()")).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(whereVersion(id, version)).
 		PlaceholderFormat(sq.Dollar)
 
 	sqlQuery, args, err := query.ToSql()
 	if err != nil {
-		log.Printf("[DeleteMovie] Error building query: %v", err)
+		m.log.ErrorContext(ctx, "Error building query", "op", "DeleteMovie", logger.Err(err))
 		return fmt.Errorf("failed to build query: %w", err)
 	}
 
-	_, err = m.db.Exec(sqlQuery, args...)
+	result, err := tx.ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
-		log.Printf("]DeleteMovie] Error executing query: %v", err)
+		m.log.ErrorContext(ctx, "Error executing query", "op", "DeleteMovie", logger.Err(err))
 		return fmt.Errorf("failed to delete movie: %w", err)
 	}
-	return nil
+	return checkVersion(result, version)
 }
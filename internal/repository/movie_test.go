@@ -1,7 +1,11 @@
 package repository
 
 import (
+	"cinema/internal/logger"
 	"cinema/internal/models"
+	"cinema/internal/pagination"
+	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -16,11 +20,11 @@ func TestBeginTransaction(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewMovie(db)
+	repo := NewMovie(db, logger.Discard(), nil)
 
 	mock.ExpectBegin()
 
-	tx, err := repo.BeginTransaction()
+	tx, err := repo.BeginTransaction(context.Background())
 	assert.NoError(t, err)
 	assert.NotNil(t, tx)
 
@@ -33,47 +37,88 @@ func TestCheckMovieExists(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewMovie(db)
+	repo := NewMovie(db, logger.Discard(), nil)
 
 	movieID := uuid.New()
 
-	mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM movies WHERE id = \$1\)`).
+	mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM movies WHERE id = \$1 AND deleted_at IS NULL\)`).
 		WithArgs(movieID).
 		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true)) // Фильм существует
 
-	exists, err := repo.CheckMovieExists(movieID)
+	exists, err := repo.CheckMovieExists(context.Background(), movieID)
 	assert.NoError(t, err)
 	assert.True(t, exists)
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestLockMovieNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMovie(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	// Фильм в корзине или удален: строка не блокируется, возвращается ErrNotFound
+	movieID := uuid.New()
+	mock.ExpectQuery(`SELECT id FROM movies WHERE id = \$1 AND deleted_at IS NULL FOR UPDATE`).
+		WithArgs(movieID).
+		WillReturnError(sql.ErrNoRows)
+
+	err = repo.LockMovie(context.Background(), tx, movieID)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestCheckActorsExist(t *testing.T) {
 
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewMovie(db)
+	repo := NewMovie(db, logger.Discard(), nil)
 
 	actorIDs := []uuid.UUID{uuid.New(), uuid.New()}
 
-	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM actors WHERE id IN \(\$1,\$2\)`).
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM actors WHERE id IN \(\$1,\$2\) AND deleted_at IS NULL`).
 		WithArgs(actorIDs[0], actorIDs[1]).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
 
-	exists, err := repo.CheckActorsExist(actorIDs)
+	exists, err := repo.CheckActorsExist(context.Background(), actorIDs)
 	assert.NoError(t, err)
 	assert.True(t, exists)
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestCheckActorsExistIgnoresDuplicates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMovie(db, logger.Discard(), nil)
+	actorID := uuid.New()
+
+	// Один актер, указанный дважды (например, в двух ролях), находится одной строкой
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM actors WHERE id IN \(\$1,\$2\) AND deleted_at IS NULL`).
+		WithArgs(actorID, actorID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	exists, err := repo.CheckActorsExist(context.Background(), []uuid.UUID{actorID, actorID})
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestCreateMovie(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewMovie(db)
+	repo := NewMovie(db, logger.Discard(), nil)
 
 	movie := models.CreateMovie{
 		Title:       "Inception",
@@ -96,7 +141,7 @@ func TestCreateMovie(t *testing.T) {
 	tx, err := db.Begin()
 	assert.NoError(t, err)
 
-	resultID, err := repo.CreateMovie(tx, movie)
+	resultID, err := repo.CreateMovie(context.Background(), tx, movie)
 
 	assert.NoError(t, err)
 	assert.Equal(t, movieID, resultID)
@@ -106,3 +151,222 @@ func TestCreateMovie(t *testing.T) {
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestAddMovieActorRelations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMovie(db, logger.Discard(), nil)
+
+	movieID := uuid.New()
+	actorID := uuid.New()
+	character := "Cobb"
+	order := 0
+	credits := []models.CreditInput{
+		{ActorID: actorID, Role: models.RoleActor, Character: &character, Order: &order},
+		{ActorID: actorID, Role: models.RoleDirector},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO movie_actors \(movie_id,actor_id,role,character_name,billing_order\) `+
+		`VALUES \(\$1,\$2,\$3,\$4,\$5\),\(\$6,\$7,\$8,\$9,\$10\) `+
+		`ON CONFLICT \(movie_id, actor_id, role\) DO UPDATE SET`).
+		WithArgs(movieID, actorID, models.RoleActor, &character, &order, movieID, actorID, models.RoleDirector, nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	assert.NoError(t, repo.AddMovieActorRelations(context.Background(), tx, movieID, credits))
+	assert.NoError(t, tx.Commit())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRemoveMovieCastRelationsKeepsCrew(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMovie(db, logger.Discard(), nil)
+	movieID := uuid.New()
+
+	// Удаляются только роли actor: записи режиссера, сценариста и остальной группы не попадают под условие
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM movie_actors WHERE movie_id = \$1 AND role = \$2 `+
+		`AND actor_id IN \(SELECT id FROM actors WHERE deleted_at IS NULL\)`).
+		WithArgs(movieID, models.RoleActor).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	assert.NoError(t, repo.RemoveMovieCastRelations(context.Background(), tx, movieID))
+	assert.NoError(t, tx.Commit())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMovieCredits(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMovie(db, logger.Discard(), nil)
+
+	movieID := uuid.New()
+	actorID := uuid.New()
+	rows := sqlmock.NewRows([]string{"actor_id", "name", "role", "character_name", "billing_order"}).
+		AddRow(actorID, "Leonardo DiCaprio", models.RoleActor, "Cobb", 0).
+		AddRow(actorID, "Leonardo DiCaprio", models.RoleProducer, nil, nil)
+
+	mock.ExpectQuery(`SELECT ma.actor_id, a.name, ma.role, ma.character_name, ma.billing_order FROM movie_actors ma ` +
+		`JOIN actors a ON a.id = ma.actor_id WHERE ma.movie_id = \$1 AND a.deleted_at IS NULL ` +
+		`ORDER BY ma.billing_order ASC NULLS LAST, a.name ASC, ma.actor_id ASC`).
+		WithArgs(movieID).
+		WillReturnRows(rows)
+
+	credits, err := repo.GetMovieCredits(context.Background(), movieID)
+	assert.NoError(t, err)
+	assert.Len(t, credits, 2)
+	assert.Equal(t, "Cobb", *credits[0].Character)
+	assert.Equal(t, 0, *credits[0].Order)
+	assert.Nil(t, credits[1].Character)
+	assert.Nil(t, credits[1].Order)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMovieByIDNullColumns(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMovie(db, logger.Discard(), nil)
+
+	movieID := uuid.New()
+	updatedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT movies.id, .+ FROM movies WHERE id = \$1 AND deleted_at IS NULL`).
+		WithArgs(movieID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "genres", "poster_key", "version", "updated_at"}).
+			AddRow(movieID, "Untitled", nil, nil, nil, []byte(`[]`), nil, 1, updatedAt))
+
+	// Фильм без описания, даты и рейтинга раньше ронял сервис на приведении типов
+	movie, err := repo.GetMovieByID(context.Background(), movieID)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Movie{ID: movieID, Title: "Untitled", Genres: []models.Genre{}, Version: 1, UpdatedAt: updatedAt}, movie)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchMovies(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMovie(db, logger.Discard(), nil)
+
+	movieID := uuid.New()
+	releaseDate := time.Date(2010, 7, 16, 0, 0, 0, 0, time.UTC)
+
+	genreID := uuid.New()
+	genres := `[{"id": "` + genreID.String() + `", "name": "Фантастика"}]`
+
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "genres", "poster_key", "version", "updated_at", "rank", "title_highlight", "description_snippet"}).
+		AddRow(movieID, "Inception", "A mind-bending thriller.", releaseDate, 8.8, []byte(genres), nil, 3, releaseDate, 0.5, "<b>Inception</b>", "A mind-bending thriller.")
+
+	mock.ExpectQuery(`SELECT .* FROM movies m CROSS JOIN websearch_to_tsquery\('russian', \$1\) AS q WHERE m.search_vector @@ q AND m.deleted_at IS NULL ORDER BY rank DESC, m.id LIMIT 10 OFFSET 0`).
+		WithArgs("inception").
+		WillReturnRows(rows)
+
+	result, err := repo.SearchMovies(context.Background(), "inception", 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, movieID, result[0].ID)
+	assert.Equal(t, 0.5, result[0].Rank)
+	assert.Equal(t, "<b>Inception</b>", result[0].TitleHighlight)
+	assert.Equal(t, []models.Genre{{ID: genreID, Name: "Фантастика"}}, result[0].Genres)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMoviesWithFilters(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMovie(db, logger.Discard(), nil)
+
+	minRating := 7.5
+	yearTo := 2010
+	hasDescription := true
+	actorID := uuid.New()
+	genreIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	filter := models.MovieFilter{
+		MinRating:      &minRating,
+		YearTo:         &yearTo,
+		ActorIDs:       []uuid.UUID{actorID, actorID},
+		ActorMatch:     models.ActorMatchAll,
+		GenreIDs:       genreIDs,
+		TitlePrefix:    "100%_",
+		HasDescription: &hasDescription,
+	}
+
+	mock.ExpectQuery(`SELECT movies.id, movies.title, movies.description, movies.release_date, movies.rating, COALESCE\(.+WHERE mg.movie_id = movies.id\), '\[\]'\) AS genres, movies.poster_key, movies.version, movies.updated_at FROM movies `+
+		`WHERE deleted_at IS NULL AND rating >= \$1 AND release_date < \$2 `+
+		`AND \(SELECT COUNT\(DISTINCT ma.actor_id\) FROM movie_actors ma JOIN actors a ON a.id = ma.actor_id WHERE ma.movie_id = movies.id AND ma.actor_id IN \(\$3\) AND a.deleted_at IS NULL\) = \$4 `+
+		`AND EXISTS \(SELECT 1 FROM movie_genres mg WHERE mg.movie_id = movies.id AND mg.genre_id IN \(\$5,\$6\)\) `+
+		`AND title ILIKE \$7 AND description IS NOT NULL AND description <> '' `+
+		`ORDER BY rating DESC NULLS LAST, id DESC LIMIT 11`).
+		WithArgs(minRating, time.Date(2011, 1, 1, 0, 0, 0, 0, time.UTC), actorID, 1, genreIDs[0], genreIDs[1], `100\%\_%`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "genres", "poster_key", "version", "updated_at"}))
+
+	result, err := repo.GetMoviesWithFilters(context.Background(), filter, "rating", "DESC", nil, 11)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMoviesByActorIDNullsCrossPage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMovie(db, logger.Discard(), nil)
+	actorID := uuid.New()
+	columns := []string{"id", "title", "description", "release_date", "rating", "genres", "poster_key", "version", "updated_at"}
+	query := `SELECT m.id, .+ FROM movies m WHERE EXISTS \(SELECT 1 FROM movie_actors ma JOIN actors a ON a.id = ma.actor_id WHERE ma.movie_id = m.id AND ma.actor_id = \$1 AND a.deleted_at IS NULL\) AND m.deleted_at IS NULL AND `
+
+	// Страница после фильма с датой: фильмы без даты идут после всех датированных и тоже попадают в выборку
+	keyed := &pagination.Cursor{Key: "2010-07-16", ID: uuid.New()}
+	undated := uuid.New()
+	mock.ExpectQuery(query+`\(\(m.release_date, m.id\) < \(\$2, \$3\) OR m.release_date IS NULL\) `+
+		`ORDER BY m.release_date DESC NULLS LAST, m.id DESC LIMIT 2`).
+		WithArgs(actorID, keyed.Key, keyed.ID).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(undated, "Undated", nil, nil, nil, []byte("[]"), nil, 1, time.Now()))
+
+	movies, err := repo.GetMoviesByActorID(context.Background(), actorID, keyed, 2)
+	assert.NoError(t, err)
+	assert.Len(t, movies, 1)
+	assert.True(t, movies[0].ReleaseDate.IsZero())
+	assert.Nil(t, movies[0].Rating)
+
+	// Страница после фильма без даты: только оставшиеся фильмы без даты
+	null := &pagination.Cursor{Null: true, ID: undated}
+	mock.ExpectQuery(query+`\(m.release_date IS NULL AND m.id < \$2\) `+
+		`ORDER BY m.release_date DESC NULLS LAST, m.id DESC LIMIT 2`).
+		WithArgs(actorID, undated).
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	movies, err = repo.GetMoviesByActorID(context.Background(), actorID, null, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, movies)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMoviesWithFiltersRejectsUnknownSort(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMovie(db, logger.Discard(), nil)
+
+	_, err = repo.GetMoviesWithFilters(context.Background(), models.MovieFilter{}, "rating; DROP TABLE movies", "DESC", nil, 10)
+	assert.Error(t, err)
+}
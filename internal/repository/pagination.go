@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"cinema/internal/pagination"
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Условие keyset-пагинации: строки, идущие после курсора при сортировке (column, idColumn) в порядке order
+func keysetAfter(column, idColumn, order string, cursor *pagination.Cursor) sq.Sqlizer {
+	op := ">"
+	if strings.EqualFold(order, "DESC") {
+		op = "<"
+	}
+	return sq.Expr(fmt.Sprintf("(%s, %s) %s (?, ?)", column, idColumn, op), cursor.Key, cursor.ID)
+}
+
+// Сортировка по необязательной колонке: строки с NULL идут в конце при любом направлении,
+// как ожидает keysetAfterNullsLast
+func orderNullsLast(column, idColumn, order string) []string {
+	return []string{fmt.Sprintf("%s %s NULLS LAST", column, order), fmt.Sprintf("%s %s", idColumn, order)}
+}
+
+// Условие keyset-пагинации для сортировки orderNullsLast. Сравнение строк с NULL дает NULL,
+// поэтому строки с NULL после курсора с ключом выбираются отдельным условием, а после курсора
+// на строке с NULL остаются только строки с NULL и следующим ID.
+func keysetAfterNullsLast(column, idColumn, order string, cursor *pagination.Cursor) sq.Sqlizer {
+	op := ">"
+	if strings.EqualFold(order, "DESC") {
+		op = "<"
+	}
+	if cursor.Null {
+		return sq.Expr(fmt.Sprintf("(%s IS NULL AND %s %s ?)", column, idColumn, op), cursor.ID)
+	}
+	return sq.Expr(fmt.Sprintf("((%s, %s) %s (?, ?) OR %s IS NULL)", column, idColumn, op, column), cursor.Key, cursor.ID)
+}
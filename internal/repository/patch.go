@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// Изменяемые поля фильма не из корзины. Строка блокируется до конца транзакции,
+// чтобы патч применялся к тому, что будет перезаписано. Если фильма нет, возвращает nil.
+func (m *movie) GetMovieFields(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*models.MovieFields, error) {
+	defer m.metrics.ObserveQuery("GetMovieFields", time.Now())
+
+	query := sq.
+		Select("title", "description", "release_date", "rating",
+			"COALESCE((SELECT json_agg(mg.genre_id ORDER BY mg.genre_id) FROM movie_genres mg WHERE mg.movie_id = movies.id), '[]')").
+		From("movies").
+		Where(sq.Eq{"id": id}).
+		Where(sq.Eq{"deleted_at": nil}).
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error building query", "op", "GetMovieFields", logger.Err(err))
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var fields models.MovieFields
+	var description sql.NullString
+	var releaseDate sql.NullTime
+	var rating sql.NullFloat64
+	var genreIDs []byte
+	err = tx.QueryRowContext(ctx, sqlQuery, args...).Scan(&fields.Title, &description, &releaseDate, &rating, &genreIDs)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Фильм не найден
+		}
+		m.log.ErrorContext(ctx, "Error scanning row", "op", "GetMovieFields", logger.Err(err))
+		return nil, fmt.Errorf("failed to get movie: %w", err)
+	}
+	if err := json.Unmarshal(genreIDs, &fields.GenreIDs); err != nil {
+		return nil, fmt.Errorf("failed to parse movie genres: %w", err)
+	}
+
+	fields.Description = nullStringPtr(description)
+	fields.ReleaseDate = releaseDate.Time
+	if rating.Valid {
+		fields.Rating = &rating.Float64
+	}
+	return &fields, nil
+}
+
+// Перезаписать изменяемые поля фильма, кроме жанров. Поля без значения очищаются.
+func (m *movie) ReplaceMovie(ctx context.Context, tx *sql.Tx, id uuid.UUID, fields models.MovieFields, version *int64) error {
+	defer m.metrics.ObserveQuery("ReplaceMovie", time.Now())
+
+	query := sq.
+		Update("movies").
+		Set("title", fields.Title).
+		Set("description", fields.Description).
+		Set("release_date", fields.ReleaseDate).
+		Set("rating", fields.Rating).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(whereVersion(id, version)).
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error building query", "op", "ReplaceMovie", logger.Err(err))
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error executing query", "op", "ReplaceMovie", logger.Err(err))
+		return fmt.Errorf("failed to update movie: %w", err)
+	}
+	return checkVersion(result, version)
+}
+
+// Изменяемые поля актера не из корзины, строка блокируется до конца транзакции.
+// Если актера нет, возвращает nil.
+func (a *actor) GetActorFields(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*models.ActorFields, error) {
+	defer a.metrics.ObserveQuery("GetActorFields", time.Now())
+
+	query := sq.
+		Select("name", "gender", "date_of_birth").
+		From("actors").
+		Where(sq.Eq{"id": id}).
+		Where(sq.Eq{"deleted_at": nil}).
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		a.log.ErrorContext(ctx, "Error building query", "op", "GetActorFields", logger.Err(err))
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var fields models.ActorFields
+	var gender sql.NullString
+	var dateOfBirth sql.NullTime
+	err = tx.QueryRowContext(ctx, sqlQuery, args...).Scan(&fields.Name, &gender, &dateOfBirth)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Актер не найден
+		}
+		a.log.ErrorContext(ctx, "Error scanning row", "op", "GetActorFields", logger.Err(err))
+		return nil, fmt.Errorf("failed to get actor: %w", err)
+	}
+
+	fields.Gender = nullStringPtr(gender)
+	if dateOfBirth.Valid {
+		fields.DateOfBirth = &dateOfBirth.Time
+	}
+	return &fields, nil
+}
+
+// Перезаписать изменяемые поля актера. Поля без значения очищаются.
+func (a *actor) ReplaceActor(ctx context.Context, tx *sql.Tx, id uuid.UUID, fields models.ActorFields, version *int64) error {
+	defer a.metrics.ObserveQuery("ReplaceActor", time.Now())
+
+	query := sq.
+		Update("actors").
+		Set("name", fields.Name).
+		Set("gender", fields.Gender).
+		Set("date_of_birth", fields.DateOfBirth).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(whereVersion(id, version)).
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		a.log.ErrorContext(ctx, "Error building query", "op", "ReplaceActor", logger.Err(err))
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		a.log.ErrorContext(ctx, "Error executing query", "op", "ReplaceActor", logger.Err(err))
+		return fmt.Errorf("failed to update actor: %w", err)
+	}
+	return checkVersion(result, version)
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMovieFieldsKeepsNulls(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMovie(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	movieID, genreID := uuid.New(), uuid.New()
+	release := time.Date(2010, 7, 16, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT title, description, release_date, rating, .+ FROM movies WHERE id = \$1 AND deleted_at IS NULL FOR UPDATE`).
+		WithArgs(movieID).
+		WillReturnRows(sqlmock.NewRows([]string{"title", "description", "release_date", "rating", "genre_ids"}).
+			AddRow("Inception", nil, release, nil, []byte(`["`+genreID.String()+`"]`)))
+
+	fields, err := repo.GetMovieFields(context.Background(), tx, movieID)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.MovieFields{Title: "Inception", ReleaseDate: release, GenreIDs: []uuid.UUID{genreID}}, fields)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReplaceActorClearsFields(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewActor(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	actorID := uuid.New()
+	version := int64(2)
+
+	mock.ExpectExec(`UPDATE actors SET name = \$1, gender = \$2, date_of_birth = \$3, version = version \+ 1, updated_at = now\(\) WHERE \(id = \$4 AND deleted_at IS NULL AND version = \$5\)`).
+		WithArgs("Tom Hardy", nil, nil, actorID, version).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.ReplaceActor(context.Background(), tx, actorID, models.ActorFields{Name: "Tom Hardy"}, &version)
+	assert.ErrorIs(t, err, models.ErrVersionMismatch)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"cinema/internal/models"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Общий интерфейс *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// Колонки фильма в порядке, который ожидает movieRow, alias - псевдоним таблицы movies
+func movieColumns(alias string) []string {
+	return []string{
+		alias + ".id",
+		alias + ".title",
+		alias + ".description",
+		alias + ".release_date",
+		alias + ".rating",
+		movieGenresColumn(alias),
+		alias + ".poster_key",
+		alias + ".version",
+		alias + ".updated_at",
+	}
+}
+
+// Приемник колонок movieColumns. Все поля допускают NULL: description, release_date и rating
+// необязательны в схеме, а при LEFT JOIN пустым может оказаться весь фильм.
+type movieRow struct {
+	id          uuid.NullUUID
+	title       sql.NullString
+	description sql.NullString
+	releaseDate sql.NullTime
+	rating      sql.NullFloat64
+	genres      []byte
+	posterKey   sql.NullString
+	version     sql.NullInt64
+	updatedAt   sql.NullTime
+}
+
+func (r *movieRow) dest() []interface{} {
+	return []interface{}{&r.id, &r.title, &r.description, &r.releaseDate, &r.rating, &r.genres, &r.posterKey, &r.version, &r.updatedAt}
+}
+
+func (r *movieRow) movie() (models.Movie, error) {
+	genres, err := parseMovieGenres(r.genres)
+	if err != nil {
+		return models.Movie{}, err
+	}
+	movie := models.Movie{
+		ID:          r.id.UUID,
+		Title:       r.title.String,
+		Description: r.description.String,
+		ReleaseDate: r.releaseDate.Time,
+		Rating:      nullFloatPtr(r.rating),
+		Genres:      genres,
+		Version:     r.version.Int64,
+		UpdatedAt:   r.updatedAt.Time,
+	}
+	if r.posterKey.Valid {
+		movie.PosterURL = models.MoviePosterURL(movie.ID)
+	}
+	return movie, nil
+}
+
+// Сканирует колонки movieColumns, за которыми следуют колонки extra
+func scanMovie(row rowScanner, extra ...interface{}) (models.Movie, error) {
+	var r movieRow
+	if err := row.Scan(append(r.dest(), extra...)...); err != nil {
+		return models.Movie{}, err
+	}
+	return r.movie()
+}
+
+// Колонки актера в порядке, который ожидает actorRow
+var actorColumns = []string{"id", "name", "gender", "date_of_birth", "headshot_key", "version", "updated_at"}
+
+// Приемник колонок actorColumns, gender, date_of_birth и headshot_key необязательны в схеме
+type actorRow struct {
+	id          uuid.UUID
+	name        string
+	gender      sql.NullString
+	dateOfBirth sql.NullTime
+	headshotKey sql.NullString
+	version     int64
+	updatedAt   time.Time
+}
+
+func (r *actorRow) dest() []interface{} {
+	return []interface{}{&r.id, &r.name, &r.gender, &r.dateOfBirth, &r.headshotKey, &r.version, &r.updatedAt}
+}
+
+func (r *actorRow) actor() models.Actor {
+	actor := models.Actor{
+		ID:          r.id,
+		Name:        r.name,
+		Gender:      r.gender.String,
+		DateOfBirth: r.dateOfBirth.Time,
+		Version:     r.version,
+		UpdatedAt:   r.updatedAt,
+	}
+	if r.headshotKey.Valid {
+		actor.HeadshotURL = models.ActorHeadshotURL(actor.ID)
+	}
+	return actor
+}
+
+// Колонки с псевдонимом таблицы, например pa.id
+func prefixColumns(alias string, columns []string) []string {
+	result := make([]string, len(columns))
+	for i, column := range columns {
+		result[i] = alias + "." + column
+	}
+	return result
+}
+
+func scanActor(row rowScanner) (models.Actor, error) {
+	var r actorRow
+	if err := row.Scan(r.dest()...); err != nil {
+		return models.Actor{}, err
+	}
+	return r.actor(), nil
+}
+
+// Необязательное целое значение колонки
+func nullIntPtr(value sql.NullInt64) *int {
+	if !value.Valid {
+		return nil
+	}
+	result := int(value.Int64)
+	return &result
+}
+
+// Необязательное строковое значение колонки
+func nullStringPtr(value sql.NullString) *string {
+	if !value.Valid {
+		return nil
+	}
+	return &value.String
+}
+
+// Необязательное дробное значение колонки
+func nullFloatPtr(value sql.NullFloat64) *float64 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Float64
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// Фильмы в корзине, недавно удаленные первыми
+func (m *movie) GetDeletedMovies(ctx context.Context, limit, offset int) ([]models.TrashedMovie, error) {
+	defer m.metrics.ObserveQuery("GetDeletedMovies", time.Now())
+
+	query := sq.
+		Select(movieColumns("movies")...).
+		Column("movies.deleted_at").
+		From("movies").
+		Where(sq.NotEq{"deleted_at": nil}).
+		OrderBy("deleted_at DESC", "id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error building query", "op", "GetDeletedMovies", logger.Err(err))
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := m.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error executing query", "op", "GetDeletedMovies", logger.Err(err))
+		return nil, fmt.Errorf("failed to get deleted movies: %w", err)
+	}
+	defer rows.Close()
+
+	movies := make([]models.TrashedMovie, 0, limit)
+	for rows.Next() {
+		var trashed models.TrashedMovie
+		trashed.Movie, err = scanMovie(rows, &trashed.DeletedAt)
+		if err != nil {
+			m.log.ErrorContext(ctx, "Error scanning row", "op", "GetDeletedMovies", logger.Err(err))
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		movies = append(movies, trashed)
+	}
+
+	if err := rows.Err(); err != nil {
+		m.log.ErrorContext(ctx, "Error iterating rows", "op", "GetDeletedMovies", logger.Err(err))
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return movies, nil
+}
+
+// Вернуть фильм из корзины. false, если фильма в корзине нет.
+func (m *movie) RestoreMovie(ctx context.Context, tx *sql.Tx, id uuid.UUID) (bool, error) {
+	defer m.metrics.ObserveQuery("RestoreMovie", time.Now())
+
+	query := sq.
+		Update("movies").
+		Set("deleted_at", nil).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": id}).
+		Where(sq.NotEq{"deleted_at": nil}).
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error building query", "op", "RestoreMovie", logger.Err(err))
+		return false, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		m.log.ErrorContext(ctx, "Error executing query", "op", "RestoreMovie", logger.Err(err))
+		return false, fmt.Errorf("failed to restore movie: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// Окончательно удалить фильмы, попавшие в корзину раньше before. Связи удаляются каскадно.
+// Возвращает число удаленных фильмов и ключи их постеров.
+func (m *movie) PurgeMovies(ctx context.Context, before time.Time) (int64, []string, error) {
+	defer m.metrics.ObserveQuery("PurgeMovies", time.Now())
+
+	return purge(ctx, m.db, m.log, "movies", "poster_key", "PurgeMovies", before)
+}
+
+// Актеры в корзине, недавно удаленные первыми
+func (a *actor) GetDeletedActors(ctx context.Context, limit, offset int) ([]models.TrashedActor, error) {
+	defer a.metrics.ObserveQuery("GetDeletedActors", time.Now())
+
+	query := sq.
+		Select(actorColumns...).
+		Column("deleted_at").
+		From("actors").
+		Where(sq.NotEq{"deleted_at": nil}).
+		OrderBy("deleted_at DESC", "id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		a.log.ErrorContext(ctx, "Error building query", "op", "GetDeletedActors", logger.Err(err))
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := a.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		a.log.ErrorContext(ctx, "Error executing query", "op", "GetDeletedActors", logger.Err(err))
+		return nil, fmt.Errorf("failed to get deleted actors: %w", err)
+	}
+	defer rows.Close()
+
+	actors := make([]models.TrashedActor, 0, limit)
+	for rows.Next() {
+		var r actorRow
+		var trashed models.TrashedActor
+		if err := rows.Scan(append(r.dest(), &trashed.DeletedAt)...); err != nil {
+			a.log.ErrorContext(ctx, "Error scanning row", "op", "GetDeletedActors", logger.Err(err))
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		trashed.Actor = r.actor()
+		actors = append(actors, trashed)
+	}
+
+	if err := rows.Err(); err != nil {
+		a.log.ErrorContext(ctx, "Error iterating rows", "op", "GetDeletedActors", logger.Err(err))
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return actors, nil
+}
+
+// Вернуть актера из корзины вместе с его титрами. false, если актера в корзине нет.
+func (a *actor) RestoreActor(ctx context.Context, tx *sql.Tx, id uuid.UUID) (bool, error) {
+	defer a.metrics.ObserveQuery("RestoreActor", time.Now())
+
+	query := sq.
+		Update("actors").
+		Set("deleted_at", nil).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": id}).
+		Where(sq.NotEq{"deleted_at": nil}).
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		a.log.ErrorContext(ctx, "Error building query", "op", "RestoreActor", logger.Err(err))
+		return false, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		a.log.ErrorContext(ctx, "Error executing query", "op", "RestoreActor", logger.Err(err))
+		return false, fmt.Errorf("failed to restore actor: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// Окончательно удалить актеров, попавших в корзину раньше before. Титры удаляются каскадно.
+// Возвращает число удаленных актеров и ключи их фотографий.
+func (a *actor) PurgeActors(ctx context.Context, before time.Time) (int64, []string, error) {
+	defer a.metrics.ObserveQuery("PurgeActors", time.Now())
+
+	return purge(ctx, a.db, a.log, "actors", "headshot_key", "PurgeActors", before)
+}
+
+// Удаляет записи и возвращает их число и ключи изображений из keyColumn, чтобы удалить файлы
+func purge(ctx context.Context, db *sql.DB, log *slog.Logger, table, keyColumn, op string, before time.Time) (int64, []string, error) {
+	query := sq.
+		Delete(table).
+		Where(sq.Lt{"deleted_at": before}).
+		Suffix("RETURNING " + keyColumn).
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		log.ErrorContext(ctx, "Error building query", "op", op, logger.Err(err))
+		return 0, nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		log.ErrorContext(ctx, "Error executing query", "op", op, logger.Err(err))
+		return 0, nil, fmt.Errorf("failed to purge %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var count int64
+	var keys []string
+	for rows.Next() {
+		var key sql.NullString
+		if err := rows.Scan(&key); err != nil {
+			log.ErrorContext(ctx, "Error scanning row", "op", op, logger.Err(err))
+			return 0, nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		count++
+		if key.Valid {
+			keys = append(keys, key.String)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.ErrorContext(ctx, "Error iterating rows", "op", op, logger.Err(err))
+		return 0, nil, fmt.Errorf("failed to purge %s: %w", table, err)
+	}
+	return count, keys, nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"cinema/internal/logger"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestoreMovieNotInTrash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMovie(db, logger.Discard(), nil)
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	movieID := uuid.New()
+
+	mock.ExpectExec(`UPDATE movies SET deleted_at = \$1, version = version \+ 1, updated_at = now\(\) WHERE id = \$2 AND deleted_at IS NOT NULL`).
+		WithArgs(nil, movieID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	restored, err := repo.RestoreMovie(context.Background(), tx, movieID)
+	assert.NoError(t, err)
+	assert.False(t, restored)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeMovies(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMovie(db, logger.Discard(), nil)
+	before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Ключи постеров возвращаются, чтобы удалить файлы; у одного фильма постера нет
+	mock.ExpectQuery(`DELETE FROM movies WHERE deleted_at < \$1 RETURNING poster_key`).
+		WithArgs(before).
+		WillReturnRows(sqlmock.NewRows([]string{"poster_key"}).AddRow("posters/a.jpg").AddRow(nil).AddRow("posters/b.png"))
+
+	purged, keys, err := repo.PurgeMovies(context.Background(), before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+	assert.Equal(t, []string{"posters/a.jpg", "posters/b.png"}, keys)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDeletedActors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewActor(db, logger.Discard(), nil)
+	actorID := uuid.New()
+	birth := time.Date(1974, 11, 11, 0, 0, 0, 0, time.UTC)
+	deletedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT id, name, gender, date_of_birth, headshot_key, version, updated_at, deleted_at FROM actors WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id LIMIT 10 OFFSET 0`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "gender", "date_of_birth", "headshot_key", "version", "updated_at", "deleted_at"}).
+			AddRow(actorID, "Leonardo DiCaprio", "male", birth, nil, 3, deletedAt, deletedAt))
+
+	actors, err := repo.GetDeletedActors(context.Background(), 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, actors, 1)
+	assert.Equal(t, actorID, actors[0].ID)
+	assert.Equal(t, int64(3), actors[0].Version)
+	assert.Equal(t, deletedAt, actors[0].DeletedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
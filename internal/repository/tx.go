@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"cinema/internal/logger"
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+)
+
+// Начало транзакции, общее для всех хранилищ
+func beginTransaction(ctx context.Context, db *sql.DB, log *slog.Logger) (*sql.Tx, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		log.ErrorContext(ctx, "Failed to begin transaction", "op", "BeginTransaction", logger.Err(err))
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// Транзакция только для чтения с уровнем изоляции repeatable read: все запросы в ней
+// видят один снимок базы, даже если каталог меняется во время долгой выгрузки
+func beginSnapshot(ctx context.Context, db *sql.DB, log *slog.Logger) (*sql.Tx, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		log.ErrorContext(ctx, "Failed to begin snapshot transaction", "op", "BeginSnapshot", logger.Err(err))
+		return nil, fmt.Errorf("failed to begin snapshot transaction: %w", err)
+	}
+	return tx, nil
+}
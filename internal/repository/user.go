@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/metrics"
+	"cinema/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	_ "github.com/lib/pq"
+)
+
+type user struct {
+	db      *sql.DB
+	log     *slog.Logger
+	metrics *metrics.Metrics
+}
+
+func NewUser(db *sql.DB, log *slog.Logger, metrics *metrics.Metrics) *user {
+	return &user{db: db, log: log, metrics: metrics}
+}
+
+// Получить пользователя по имени
+func (u *user) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	defer u.metrics.ObserveQuery("GetUserByUsername", time.Now())
+
+	query := sq.
+		Select("id", "username", "password_hash", "role").
+		From("users").
+		Where(sq.Eq{"username": username}).
+		PlaceholderFormat(sq.Dollar)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		u.log.ErrorContext(ctx, "Error building query", "op", "GetUserByUsername", logger.Err(err))
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var user models.User
+	err = u.db.QueryRowContext(ctx, sqlQuery, args...).Scan(&user.ID, &user.Username, &user.PasswordHash, &user.Role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Пользователь не найден
+		}
+		u.log.ErrorContext(ctx, "Error executing query", "op", "GetUserByUsername", logger.Err(err))
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return &user, nil
+}
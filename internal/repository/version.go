@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"cinema/internal/models"
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// Условие на запись по id, не находящуюся в корзине. Если version задана,
+// запись должна быть этой версии (If-Match).
+func whereVersion(id uuid.UUID, version *int64) sq.Sqlizer {
+	where := sq.And{sq.Eq{"id": id}, sq.Eq{"deleted_at": nil}}
+	if version != nil {
+		where = append(where, sq.Eq{"version": *version})
+	}
+	return where
+}
+
+// Изменение, не затронувшее ни одной строки: без ожидаемой версии записи нет (models.ErrNotFound),
+// с ожидаемой версией запись успели изменить или удалить (models.ErrVersionMismatch).
+func checkVersion(result sql.Result, version *int64) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected > 0 {
+		return nil
+	}
+	if version == nil {
+		return models.ErrNotFound
+	}
+	return fmt.Errorf("expected version %d: %w", *version, models.ErrVersionMismatch)
+}
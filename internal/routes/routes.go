@@ -1,8 +1,12 @@
 package routes
 
 import (
+	"cinema/internal/config"
 	"cinema/internal/controller"
+	"cinema/internal/metrics"
 	"cinema/internal/middleware"
+	"log/slog"
+	"time"
 
 	_ "cinema/docs"
 
@@ -11,32 +15,73 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func SetupRoutes(router *gin.Engine, cinemaController *controller.Cinema) {
+func SetupRoutes(router *gin.Engine, cfg *config.Config, log *slog.Logger, appMetrics *metrics.Metrics, cinemaController *controller.Cinema, authController *controller.Auth, healthController *controller.Health, trashController *controller.Trash, auditController *controller.Audit, importController *controller.Import, exportController *controller.Export, imageController *controller.Images) {
+	jwtSecret := []byte(cfg.Auth.JWTSecret)
+	limit := rateLimiter(cfg.RateLimit)
+
+	// ID запроса назначается первым, чтобы попасть во все записи лога, включая запись о панике
+	router.Use(
+		middleware.RequestID(),
+		middleware.AccessLog(log),
+		appMetrics.Middleware(),
+		middleware.Recovery(log),
+		middleware.Timeout(cfg.HTTP.RequestTimeout, map[string]time.Duration{
+			"/api/export/:kind": cfg.HTTP.ExportTimeout,
+			"/api/import/:kind": cfg.HTTP.ImportTimeout,
+		}),
+	)
 
 	router.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	router.GET("/metrics", gin.WrapH(appMetrics.Handler()))
+
+	// Проверки для оркестратора, без аутентификации
+	router.GET("/healthz", healthController.Liveness) // Процесс жив
+	router.GET("/readyz", healthController.Readiness) // Готов принимать запросы
+
+	// Маршруты аутентификации
+	authGroup := router.Group("/api/auth")
+	{
+		authGroup.POST("/login", limit("auth"), authController.Login) // Получить access-токен
+	}
 
 	// Маршруты для актеров
 	actorGroup := router.Group("/api/actors")
 	{
-		actorGroup.GET("/:actor_id", cinemaController.GetActor)                  // Получить актера по ID
-		actorGroup.GET("/:actor_id/movies", cinemaController.GetMoviesByActorID) // Получить фильмы по ID актера
-		actorGroup.GET("/", cinemaController.GetAllActors)                       // Получить всех актеров
-		actorGroup.GET("/with-movies", cinemaController.GetActorsWithMovies)     // Актеры с фильмами
+		actorGroup.Use(limit("public"))
+
+		actorGroup.GET("/:actor_id", cinemaController.GetActor)                              // Получить актера по ID
+		actorGroup.GET("/:actor_id/movies", cinemaController.GetMoviesByActorID)             // Получить фильмы по ID актера
+		actorGroup.GET("/:actor_id/headshot", imageController.GetActorHeadshot)              // Фотография актера
+		actorGroup.GET("/", cinemaController.GetAllActors)                                   // Получить всех актеров
+		actorGroup.GET("/with-movies", limit("heavy"), cinemaController.GetActorsWithMovies) // Актеры с фильмами
 	}
 
 	// Маршруты для фильмов
 	movieGroup := router.Group("/api/movies")
 	{
-		movieGroup.GET("/:movie_id", cinemaController.GetMovieByID)             // Получить фильм по ID
-		movieGroup.GET("/", cinemaController.GetMoviesWithFilters)              // Фильтрация фильмов
-		movieGroup.GET("/search", cinemaController.SearchMoviesByTitleAndActor) // Поиск по актеру и названию
+		movieGroup.Use(limit("public"))
+
+		movieGroup.GET("/:movie_id", cinemaController.GetMovieByID)              // Получить фильм по ID
+		movieGroup.GET("/:movie_id/credits", cinemaController.GetMovieCredits)   // Актеры и съемочная группа фильма
+		movieGroup.GET("/:movie_id/poster", imageController.GetMoviePoster)      // Постер фильма
+		movieGroup.GET("/", cinemaController.GetMoviesWithFilters)               // Фильтрация фильмов
+		movieGroup.GET("/search", limit("heavy"), cinemaController.SearchMovies) // Полнотекстовый поиск
+	}
+
+	// Маршруты для жанров
+	genreGroup := router.Group("/api/genres")
+	{
+		genreGroup.Use(limit("public"))
+
+		genreGroup.GET("/", cinemaController.GetAllGenres)      // Получить все жанры
+		genreGroup.GET("/:genre_id", cinemaController.GetGenre) // Получить жанр по ID
 	}
 
 	// Маршруты для управления связями
-	// Создание, удаление и обновление связей между фильмами и актерами
+	// Создание, удаление и обновление титров фильма (актеры и съемочная группа)
 	relationGroup := router.Group("/api/movies/:movie_id/actors")
 	{
-		relationGroup.Use(middleware.JWTAuthMiddleware(), middleware.RoleMiddleware([]string{"admin"}))
+		relationGroup.Use(middleware.JWTAuthMiddleware(jwtSecret), middleware.RoleMiddleware([]string{"admin"}), limit("admin"))
 
 		relationGroup.POST("/", cinemaController.AddMovieActorRelations)              // Добавить актеров в фильм
 		relationGroup.DELETE("/", cinemaController.RemoveSelectedMovieActorRelations) // Удалить актеров из фильма
@@ -46,16 +91,56 @@ func SetupRoutes(router *gin.Engine, cinemaController *controller.Cinema) {
 	// Административные маршруты
 	adminGroup := router.Group("/api")
 	{
-		adminGroup.Use(middleware.JWTAuthMiddleware(), middleware.RoleMiddleware([]string{"admin"}))
+		adminGroup.Use(middleware.JWTAuthMiddleware(jwtSecret), middleware.RoleMiddleware([]string{"admin"}), limit("admin"))
 
 		// Фильмы
-		adminGroup.POST("/movies", cinemaController.CreateMovie)             // Добавить фильм (admin)
-		adminGroup.PUT("/movies/:movie_id", cinemaController.UpdateMovie)    // Обновить фильм (admin) // DONT WORK не работает на уровне сервиса нужно доделать!
-		adminGroup.DELETE("/movies/:movie_id", cinemaController.DeleteMovie) // Удалить фильм (admin)
+		adminGroup.POST("/movies", cinemaController.CreateMovie)                         // Добавить фильм (admin)
+		adminGroup.POST("/movies/batch", cinemaController.CreateMovies)                  // Добавить несколько фильмов (admin)
+		adminGroup.PUT("/movies/:movie_id", cinemaController.UpdateMovie)                // Обновить фильм (admin)
+		adminGroup.PATCH("/movies/:movie_id", cinemaController.PatchMovie)               // Изменить фильм патчем (admin)
+		adminGroup.DELETE("/movies/:movie_id", cinemaController.DeleteMovie)             // Удалить фильм (admin)
+		adminGroup.PUT("/movies/:movie_id/poster", imageController.SetMoviePoster)       // Загрузить постер (admin)
+		adminGroup.DELETE("/movies/:movie_id/poster", imageController.DeleteMoviePoster) // Убрать постер (admin)
 
 		// Актеры
-		adminGroup.POST("/actors", cinemaController.CreateActor)             // Добавить актера (admin)
-		adminGroup.PUT("/actors/:actor_id", cinemaController.UpdateActor)    // Обновить актера (admin)
-		adminGroup.DELETE("/actors/:actor_id", cinemaController.DeleteActor) // Удалить актера (admin)
+		adminGroup.POST("/actors", cinemaController.CreateActor)                             // Добавить актера (admin)
+		adminGroup.PUT("/actors/:actor_id", cinemaController.UpdateActor)                    // Обновить актера (admin)
+		adminGroup.PATCH("/actors/:actor_id", cinemaController.PatchActor)                   // Изменить актера патчем (admin)
+		adminGroup.DELETE("/actors/:actor_id", cinemaController.DeleteActor)                 // Удалить актера (admin)
+		adminGroup.PUT("/actors/:actor_id/headshot", imageController.SetActorHeadshot)       // Загрузить фотографию (admin)
+		adminGroup.DELETE("/actors/:actor_id/headshot", imageController.DeleteActorHeadshot) // Убрать фотографию (admin)
+
+		// Жанры
+		adminGroup.POST("/genres", cinemaController.CreateGenre)             // Добавить жанр (admin)
+		adminGroup.PUT("/genres/:genre_id", cinemaController.UpdateGenre)    // Обновить жанр (admin)
+		adminGroup.DELETE("/genres/:genre_id", cinemaController.DeleteGenre) // Удалить жанр (admin)
+
+		// Корзина
+		adminGroup.GET("/trash/movies", trashController.ListMovies)                      // Удаленные фильмы (admin)
+		adminGroup.GET("/trash/actors", trashController.ListActors)                      // Удаленные актеры (admin)
+		adminGroup.POST("/trash/movies/:movie_id/restore", trashController.RestoreMovie) // Восстановить фильм (admin)
+		adminGroup.POST("/trash/actors/:actor_id/restore", trashController.RestoreActor) // Восстановить актера (admin)
+
+		// Журнал аудита
+		adminGroup.GET("/audit", auditController.List) // Изменения, сделанные администраторами (admin)
+
+		// Импорт
+		adminGroup.POST("/import/:kind", importController.Import) // Импорт актеров, фильмов или титров из CSV/NDJSON (admin)
+
+		// Выгрузка
+		adminGroup.GET("/export/:kind", exportController.Export) // Потоковая выгрузка каталога в JSON/NDJSON/CSV (admin)
+	}
+}
+
+// Возвращает конструктор ограничителей по имени группы из конфигурации.
+// Если ограничение выключено или группа не описана, запросы пропускаются без проверки.
+// Каждый вызов создает отдельный набор корзин, поэтому лимиты групп не суммируются.
+func rateLimiter(cfg config.RateLimit) func(group string) gin.HandlerFunc {
+	return func(group string) gin.HandlerFunc {
+		rule, ok := cfg.Groups[group]
+		if !cfg.Enabled || !ok {
+			return func(c *gin.Context) { c.Next() }
+		}
+		return middleware.RateLimit(rule.RequestsPerSecond, rule.Burst)
 	}
 }
@@ -1,145 +1,138 @@
 package service
 
 import (
+	"cinema/internal/logger"
+	"cinema/internal/metrics"
 	"cinema/internal/models"
+	"cinema/internal/pagination"
+	"context"
+	"database/sql"
 	"fmt"
-	"time"
+	"log/slog"
 
 	"github.com/google/uuid"
 )
 
 type storeActor interface {
-	CreateActor(actor models.CreateActor) (uuid.UUID, error)
-	GetActor(id uuid.UUID) (map[string]interface{}, error)
-	GetAllActors(limit, offset int) ([]map[string]interface{}, error)
-	GetActorsWithMovies(limit, offset int) ([]map[string]interface{}, error)
-	UpdateActor(id uuid.UUID, actor models.UpdateActor) error
-	DeleteActor(id uuid.UUID) error
+	BeginTransaction(ctx context.Context) (*sql.Tx, error)
+	CreateActor(ctx context.Context, tx *sql.Tx, actor models.CreateActor) (uuid.UUID, error)
+	GetActor(ctx context.Context, id uuid.UUID) (*models.Actor, error)
+	GetAllActors(ctx context.Context, after *pagination.Cursor, limit int) ([]models.Actor, error)
+	GetActorsWithMovies(ctx context.Context, after *pagination.Cursor, limit int) ([]models.ActorWithMovies, error)
+	CountActors(ctx context.Context) (int64, error)
+	UpdateActor(ctx context.Context, tx *sql.Tx, id uuid.UUID, actor models.UpdateActor, version *int64) error
+	GetActorFields(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*models.ActorFields, error)
+	ReplaceActor(ctx context.Context, tx *sql.Tx, id uuid.UUID, fields models.ActorFields, version *int64) error
+	DeleteActor(ctx context.Context, tx *sql.Tx, id uuid.UUID, version *int64) error
 }
 
 type actor struct {
-	store storeActor
+	store   storeActor
+	audit   *auditLog
+	log     *slog.Logger
+	metrics *metrics.Metrics
 }
 
-func NewActor(store storeActor) *actor {
-	return &actor{store: store}
+// NewActor создает сервис актеров. Изменения записываются в audit, если он не nil.
+func NewActor(store storeActor, audit *auditLog, log *slog.Logger, metrics *metrics.Metrics) *actor {
+	return &actor{store: store, audit: audit, log: log, metrics: metrics}
 }
 
 // Добавление актера
-func (a *actor) CreateActor(actor models.CreateActor) (uuid.UUID, error) {
-	return a.store.CreateActor(actor)
+func (a *actor) CreateActor(ctx context.Context, actor models.CreateActor) (uuid.UUID, error) {
+	return withTransactionUUID(ctx, a.store.BeginTransaction, a.metrics, func(tx *sql.Tx) (uuid.UUID, error) {
+		return a.audit.RecordCreate(ctx, tx, models.AuditActor, func() (uuid.UUID, error) {
+			return a.store.CreateActor(ctx, tx, actor)
+		})
+	})
 }
 
 // Получение актера по ID
-func (a *actor) GetActor(id uuid.UUID) (*models.Actor, error) {
-	rawData, err := a.store.GetActor(id)
+func (a *actor) GetActor(ctx context.Context, id uuid.UUID) (*models.Actor, error) {
+	return a.store.GetActor(ctx, id) // nil, если актёр не найден
+}
+
+// Получение актеров с курсорной пагинацией
+func (a *actor) GetAllActors(ctx context.Context, page models.PageRequest) (*models.Page[models.Actor], error) {
+	after, err := pagination.Decode(page.Cursor, "", "")
 	if err != nil {
 		return nil, err
 	}
-	if rawData == nil {
-		return nil, nil // Актёр не найден
-	}
-
-	actor := &models.Actor{
-		ID:          rawData["id"].(uuid.UUID),
-		Name:        rawData["name"].(string),
-		Gender:      rawData["gender"].(string),
-		DateOfBirth: rawData["date_of_birth"].(time.Time),
-	}
-
-	return actor, nil
-}
 
-// Получение актеров с пагинацией
-func (a *actor) GetAllActors(limit, offset int) ([]*models.Actor, error) {
-	// Получаем сырые данные от репозитория
-	rawActors, err := a.store.GetAllActors(limit, offset)
+	// На одну запись больше лимита, чтобы узнать, есть ли следующая страница
+	actors, err := a.store.GetAllActors(ctx, after, page.Limit+1)
 	if err != nil {
+		a.log.ErrorContext(ctx, "Failed to retrieve actors", "op", "GetAllActors", logger.Err(err))
 		return nil, err
 	}
 
-	// Маппинг сырых данных в структуру Actor
-	var actors []*models.Actor
-	for _, rawActor := range rawActors {
-		actor := &models.Actor{
-			ID:          rawActor["id"].(uuid.UUID),
-			Name:        rawActor["name"].(string),
-			Gender:      rawActor["gender"].(string),
-			DateOfBirth: rawActor["date_of_birth"].(time.Time),
+	result := &models.Page[models.Actor]{Items: actors}
+	if len(actors) > page.Limit {
+		result.Items = actors[:page.Limit]
+		last := result.Items[page.Limit-1]
+		next := pagination.Encode(pagination.Cursor{Key: last.Name, ID: last.ID})
+		result.NextCursor = &next
+	}
+
+	if page.IncludeTotal {
+		total, err := a.store.CountActors(ctx)
+		if err != nil {
+			a.log.ErrorContext(ctx, "Failed to count actors", "op", "GetAllActors", logger.Err(err))
+			return nil, err
 		}
-		actors = append(actors, actor)
+		result.Total = &total
 	}
 
-	return actors, nil
+	return result, nil
 }
 
-func (s *actor) GetActorsWithMovies(limit, offset int) ([]*models.ActorWithMovies, error) {
-	// Получаем сырые данные от репозитория
-	data, err := s.store.GetActorsWithMovies(limit, offset)
+func (s *actor) GetActorsWithMovies(ctx context.Context, page models.PageRequest) (*models.Page[models.ActorWithMovies], error) {
+	after, err := pagination.Decode(page.Cursor, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	// На одного актера больше лимита, чтобы узнать, есть ли следующая страница
+	actors, err := s.store.GetActorsWithMovies(ctx, after, page.Limit+1)
 	if err != nil {
+		s.log.ErrorContext(ctx, "Failed to retrieve actors with movies", "op", "GetActorsWithMovies", logger.Err(err))
 		return nil, fmt.Errorf("failed to get actors with movies: %w", err)
 	}
 
-	// Маппинг данных
-	var actors []*models.ActorWithMovies
-	// Создаем словарь для фильмов, чтобы избежать повторного маппинга
-	movieMap := make(map[uuid.UUID]models.Movie)
-
-	// Перебираем полученные данные
-	for _, row := range data {
-		// Маппинг актера
-		actorID := row["id"].(uuid.UUID)
-		actorName := row["name"].(string)
-		actorGender := row["gender"].(string)
-		actorBirthDate := row["date_of_birth"].(time.Time)
-
-		// Маппинг фильмов
-		moviesData := row["movies"].([]map[string]interface{})
-		var movies []models.Movie
-		for _, movieData := range moviesData {
-			// Проверяем, есть ли фильм в словаре
-			movieID := movieData["id"].(uuid.UUID)
-			if movie, exists := movieMap[movieID]; exists {
-				// Если фильм уже в словаре, добавляем его в список
-				movies = append(movies, movie)
-			} else {
-				// Если фильма нет в словаре, маппируем его и добавляем в список и словарь
-				newMovie := models.Movie{
-					ID:          movieID,
-					Title:       movieData["title"].(string),
-					Description: movieData["description"].(string),
-					ReleaseDate: movieData["release_date"].(time.Time),
-					Rating:      movieData["rating"].(float64),
-				}
-				movies = append(movies, newMovie)
-				movieMap[movieID] = newMovie // Сохраняем фильм в словарь
-			}
-		}
+	result := &models.Page[models.ActorWithMovies]{Items: actors}
+	if len(actors) > page.Limit {
+		result.Items = actors[:page.Limit]
+		last := result.Items[page.Limit-1]
+		next := pagination.Encode(pagination.Cursor{Key: last.Name, ID: last.ID})
+		result.NextCursor = &next
+	}
 
-		// Создаем объект актера с фильмами
-		actor := &models.ActorWithMovies{
-			Actor: models.Actor{
-				ID:          actorID,
-				Name:        actorName,
-				Gender:      actorGender,
-				DateOfBirth: actorBirthDate,
-			},
-			Movies: movies,
+	if page.IncludeTotal {
+		total, err := s.store.CountActors(ctx)
+		if err != nil {
+			s.log.ErrorContext(ctx, "Failed to count actors", "op", "GetActorsWithMovies", logger.Err(err))
+			return nil, fmt.Errorf("failed to count actors: %w", err)
 		}
-
-		// Добавляем актера в срез
-		actors = append(actors, actor)
+		result.Total = &total
 	}
 
-	return actors, nil
+	return result, nil
 }
 
-// Обновление актера по ID
-func (a *actor) UpdateActor(id uuid.UUID, actor models.UpdateActor) error {
-	return a.store.UpdateActor(id, actor)
+// Обновление актера по ID, с проверкой версии, если она задана
+func (a *actor) UpdateActor(ctx context.Context, id uuid.UUID, actor models.UpdateActor, version *int64) error {
+	return withTransactionError(ctx, a.store.BeginTransaction, a.metrics, func(tx *sql.Tx) error {
+		return a.audit.Record(ctx, tx, models.AuditUpdate, models.AuditActor, id, func() error {
+			return a.store.UpdateActor(ctx, tx, id, actor, version)
+		})
+	})
 }
 
-// Удаление актера
-func (a *actor) DeleteActor(id uuid.UUID) error {
-	return a.store.DeleteActor(id)
+// Удаление актера, с проверкой версии, если она задана
+func (a *actor) DeleteActor(ctx context.Context, id uuid.UUID, version *int64) error {
+	return withTransactionError(ctx, a.store.BeginTransaction, a.metrics, func(tx *sql.Tx) error {
+		return a.audit.Record(ctx, tx, models.AuditDelete, models.AuditActor, id, func() error {
+			return a.store.DeleteActor(ctx, tx, id, version)
+		})
+	})
 }
@@ -1,8 +1,11 @@
 package service
 
 import (
+	"cinema/internal/logger"
 	"cinema/internal/models"
+	"cinema/internal/pagination"
 	"cinema/mocks"
+	"context"
 	"testing"
 	"time"
 
@@ -28,15 +31,52 @@ func TestCreateActor(t *testing.T) {
 	expectedID := uuid.New()
 
 	// Настройка мока
-	mockStore.EXPECT().CreateActor(actor).Return(expectedID, nil)
+	tx := testTx(t, true)
+	mockStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil)
+	mockStore.EXPECT().CreateActor(gomock.Any(), tx, actor).Return(expectedID, nil)
 
 	// Создаём сервис с использованием мока
-	actorService := NewActor(mockStore)
+	actorService := NewActor(mockStore, nil, logger.Discard(), nil)
 
 	// Вызов тестируемого метода
-	resultID, err := actorService.CreateActor(actor)
+	resultID, err := actorService.CreateActor(context.Background(), actor)
 
 	// Проверки
 	assert.NoError(t, err)
 	assert.Equal(t, expectedID, resultID)
 }
+
+func TestGetAllActorsPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockstoreActor(ctrl)
+
+	newActor := func(name string) models.Actor {
+		return models.Actor{
+			ID:          uuid.New(),
+			Name:        name,
+			Gender:      "female",
+			DateOfBirth: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+	}
+	rows := []models.Actor{newActor("Anna"), newActor("Bella"), newActor("Clara")}
+
+	// Сервис запрашивает на одну запись больше лимита, чтобы определить наличие следующей страницы
+	mockStore.EXPECT().GetAllActors(gomock.Any(), nil, 3).Return(rows, nil)
+	mockStore.EXPECT().CountActors(gomock.Any()).Return(int64(5), nil)
+
+	actorService := NewActor(mockStore, nil, logger.Discard(), nil)
+
+	page, err := actorService.GetAllActors(context.Background(), models.PageRequest{Limit: 2, IncludeTotal: true})
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 2)
+	assert.Equal(t, int64(5), *page.Total)
+
+	// Курсор указывает на последнюю запись страницы
+	assert.NotNil(t, page.NextCursor)
+	cursor, err := pagination.Decode(*page.NextCursor, "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Bella", cursor.Key)
+	assert.Equal(t, rows[1].ID, cursor.ID)
+}
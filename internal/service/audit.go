@@ -0,0 +1,95 @@
+package service
+
+import (
+	"bytes"
+	"cinema/internal/audit"
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+)
+
+type storeAudit interface {
+	SnapshotEntity(ctx context.Context, tx *sql.Tx, entityType string, id uuid.UUID, lock bool) (json.RawMessage, error)
+	AddAuditEntry(ctx context.Context, tx *sql.Tx, entry models.AuditEntry) error
+	GetAuditLog(ctx context.Context, filter models.AuditFilter, limit, offset int) ([]models.AuditEntry, error)
+}
+
+// Журнал аудита изменений. Nil-журнал ничего не записывает, изменение выполняется как есть.
+type auditLog struct {
+	store storeAudit
+	log   *slog.Logger
+}
+
+func NewAuditLog(store storeAudit, log *slog.Logger) *auditLog {
+	return &auditLog{store: store, log: log}
+}
+
+// Record выполняет change в транзакции tx и записывает в журнал состояние сущности до и после него.
+// Если change вернул ошибку или ничего не изменил, запись не добавляется.
+func (a *auditLog) Record(ctx context.Context, tx *sql.Tx, action, entityType string, id uuid.UUID, change func() error) error {
+	if a == nil {
+		return change()
+	}
+
+	before, err := a.store.SnapshotEntity(ctx, tx, entityType, id, true)
+	if err != nil {
+		return err
+	}
+	if err := change(); err != nil {
+		return err
+	}
+	return a.add(ctx, tx, action, entityType, id, before)
+}
+
+// RecordCreate выполняет create в транзакции tx и записывает в журнал созданную сущность
+func (a *auditLog) RecordCreate(ctx context.Context, tx *sql.Tx, entityType string, create func() (uuid.UUID, error)) (uuid.UUID, error) {
+	id, err := create()
+	if err != nil || a == nil {
+		return id, err
+	}
+	return id, a.add(ctx, tx, models.AuditCreate, entityType, id, nil)
+}
+
+func (a *auditLog) add(ctx context.Context, tx *sql.Tx, action, entityType string, id uuid.UUID, before json.RawMessage) error {
+	after, err := a.store.SnapshotEntity(ctx, tx, entityType, id, false)
+	if err != nil {
+		return err
+	}
+	// Изменение не затронуло сущность: например, ее нет или она уже в нужном состоянии
+	if bytes.Equal(before, after) {
+		return nil
+	}
+
+	actor := audit.ActorFrom(ctx)
+	entry := models.AuditEntry{
+		Subject:    actor.Subject,
+		Role:       actor.Role,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   id,
+		Before:     before,
+		After:      after,
+		RequestID:  logger.RequestID(ctx),
+	}
+	if err := a.store.AddAuditEntry(ctx, tx, entry); err != nil {
+		a.log.ErrorContext(ctx, "Failed to write audit entry", "op", "Record", "entity_type", entityType, "entity_id", id, logger.Err(err))
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
+
+// Записи журнала по фильтру, новые первыми
+func (a *auditLog) GetAuditLog(ctx context.Context, filter models.AuditFilter, limit, offset int) ([]models.AuditEntry, error) {
+	entries, err := a.store.GetAuditLog(ctx, filter, limit, offset)
+	if err != nil {
+		a.log.ErrorContext(ctx, "Failed to retrieve audit log", "op", "GetAuditLog", logger.Err(err))
+		return nil, err
+	}
+	return entries, nil
+}
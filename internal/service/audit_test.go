@@ -0,0 +1,147 @@
+package service
+
+import (
+	"cinema/internal/audit"
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"cinema/mocks"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Транзакция sqlmock для сервисов с мок-хранилищем. После теста проверяется,
+// что она завершилась коммитом (commit) или откатом.
+func testTx(t *testing.T, commit bool) *sql.Tx {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, mock.ExpectationsWereMet())
+		db.Close()
+	})
+
+	mock.ExpectBegin()
+	if commit {
+		mock.ExpectCommit()
+	} else {
+		mock.ExpectRollback()
+	}
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	return tx
+}
+
+func TestUpdateActorWritesAuditEntry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorStore := mocks.NewMockstoreActor(ctrl)
+	auditStore := mocks.NewMockstoreAudit(ctrl)
+	svc := NewActor(actorStore, NewAuditLog(auditStore, logger.Discard()), logger.Discard(), nil)
+
+	ctx := logger.WithRequestID(audit.WithActor(context.Background(), audit.Actor{Subject: "alice", Role: "admin"}), "req-1")
+	tx := testTx(t, true)
+	actorID := uuid.New()
+	name := "Tom Hardy"
+	before := json.RawMessage(`{"name": "Tom Hardly", "version": 1}`)
+	after := json.RawMessage(`{"name": "Tom Hardy", "version": 2}`)
+
+	gomock.InOrder(
+		actorStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil),
+		auditStore.EXPECT().SnapshotEntity(gomock.Any(), tx, models.AuditActor, actorID, true).Return(before, nil),
+		actorStore.EXPECT().UpdateActor(gomock.Any(), tx, actorID, gomock.Any(), nil).Return(nil),
+		auditStore.EXPECT().SnapshotEntity(gomock.Any(), tx, models.AuditActor, actorID, false).Return(after, nil),
+		auditStore.EXPECT().AddAuditEntry(gomock.Any(), tx, models.AuditEntry{
+			Subject:    "alice",
+			Role:       "admin",
+			Action:     models.AuditUpdate,
+			EntityType: models.AuditActor,
+			EntityID:   actorID,
+			Before:     before,
+			After:      after,
+			RequestID:  "req-1",
+		}).Return(nil),
+	)
+
+	assert.NoError(t, svc.UpdateActor(ctx, actorID, models.UpdateActor{Name: &name}, nil))
+}
+
+func TestFailedChangeIsNotAudited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	movieStore := mocks.NewMockstoreMovie(ctrl)
+	auditStore := mocks.NewMockstoreAudit(ctrl)
+	svc := NewMovie(movieStore, NewAuditLog(auditStore, logger.Discard()), logger.Discard(), nil)
+
+	tx := testTx(t, false)
+	movieID := uuid.New()
+	version := int64(3)
+
+	// Изменение откатывается вместе с транзакцией, записи в журнале нет
+	gomock.InOrder(
+		movieStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil),
+		auditStore.EXPECT().SnapshotEntity(gomock.Any(), tx, models.AuditMovie, movieID, true).Return(json.RawMessage(`{"version": 4}`), nil),
+		movieStore.EXPECT().DeleteMovie(gomock.Any(), tx, movieID, &version).Return(models.ErrVersionMismatch),
+	)
+
+	err := svc.DeleteMovie(context.Background(), movieID, &version)
+	assert.ErrorIs(t, err, models.ErrVersionMismatch)
+}
+
+func TestUnchangedEntityIsNotAudited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	movieStore := mocks.NewMockstoreMovieTrash(ctrl)
+	auditStore := mocks.NewMockstoreAudit(ctrl)
+	svc := NewTrash(movieStore, mocks.NewMockstoreActorTrash(ctrl), nil, NewAuditLog(auditStore, logger.Discard()), 0, logger.Discard(), nil)
+
+	tx := testTx(t, true)
+	movieID := uuid.New()
+	snapshot := json.RawMessage(`{"deleted_at": null}`)
+
+	// Фильм не в корзине: восстанавливать нечего, запись не добавляется
+	movieStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil)
+	auditStore.EXPECT().SnapshotEntity(gomock.Any(), tx, models.AuditMovie, movieID, true).Return(snapshot, nil)
+	movieStore.EXPECT().RestoreMovie(gomock.Any(), tx, movieID).Return(false, nil)
+	auditStore.EXPECT().SnapshotEntity(gomock.Any(), tx, models.AuditMovie, movieID, false).Return(snapshot, nil)
+
+	restored, err := svc.RestoreMovie(context.Background(), movieID)
+	assert.NoError(t, err)
+	assert.False(t, restored)
+}
+
+func TestAuditWriteFailureRollsBackChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	genreStore := mocks.NewMockstoreGenre(ctrl)
+	auditStore := mocks.NewMockstoreAudit(ctrl)
+	svc := NewGenre(genreStore, NewAuditLog(auditStore, logger.Discard()), logger.Discard(), nil)
+
+	tx := testTx(t, false)
+	genreID := uuid.New()
+	dbErr := errors.New("audit_log is unavailable")
+
+	// Новая сущность: снимка "до" нет, запись в журнал обязательна для коммита
+	genreStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil)
+	genreStore.EXPECT().CreateGenre(gomock.Any(), tx, models.CreateGenre{Name: "Нуар"}).Return(genreID, nil)
+	auditStore.EXPECT().SnapshotEntity(gomock.Any(), tx, models.AuditGenre, genreID, false).Return(json.RawMessage(`{"name": "Нуар"}`), nil)
+	auditStore.EXPECT().AddAuditEntry(gomock.Any(), tx, gomock.Any()).DoAndReturn(func(_ context.Context, _ *sql.Tx, entry models.AuditEntry) error {
+		assert.Equal(t, audit.System.Subject, entry.Subject)
+		assert.Nil(t, entry.Before)
+		return dbErr
+	})
+
+	_, err := svc.CreateGenre(context.Background(), models.CreateGenre{Name: "Нуар"})
+	assert.ErrorIs(t, err, dbErr)
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var ErrInvalidCredentials = errors.New("invalid username or password")
+
+// Хеш для сравнения, когда пользователь не найден: время ответа не должно
+// выдавать, существует ли такое имя пользователя
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
+type storeUser interface {
+	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
+}
+
+type auth struct {
+	store    storeUser
+	secret   []byte
+	tokenTTL time.Duration
+	log      *slog.Logger
+}
+
+func NewAuth(store storeUser, secret []byte, tokenTTL time.Duration, log *slog.Logger) *auth {
+	return &auth{store: store, secret: secret, tokenTTL: tokenTTL, log: log}
+}
+
+// Проверка логина и пароля и выпуск access-токена
+func (a *auth) Login(ctx context.Context, credentials models.LoginRequest) (*models.LoginResponse, error) {
+	user, err := a.store.GetUserByUsername(ctx, credentials.Username)
+	if err != nil {
+		a.log.ErrorContext(ctx, "Failed to retrieve user", "op", "Login", "username", credentials.Username, logger.Err(err))
+		return nil, err
+	}
+	if user == nil {
+		bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(credentials.Password))
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(credentials.Password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub":  user.ID.String(),
+		"role": user.Role,
+		"iat":  now.Unix(),
+		"exp":  now.Add(a.tokenTTL).Unix(),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(a.secret)
+	if err != nil {
+		a.log.ErrorContext(ctx, "Failed to sign token", "op", "Login", "user_id", user.ID, logger.Err(err))
+		return nil, fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return &models.LoginResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(a.tokenTTL.Seconds()),
+	}, nil
+}
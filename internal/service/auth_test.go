@@ -0,0 +1,79 @@
+package service
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"cinema/mocks"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockstoreUser(ctrl)
+
+	secret := []byte("test-secret")
+	userID := uuid.New()
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	mockStore.EXPECT().GetUserByUsername(gomock.Any(), "admin").Return(&models.User{
+		ID:           userID,
+		Username:     "admin",
+		PasswordHash: string(hash),
+		Role:         "admin",
+	}, nil)
+
+	authService := NewAuth(mockStore, secret, time.Hour, logger.Discard())
+
+	result, err := authService.Login(context.Background(), models.LoginRequest{Username: "admin", Password: "correct-password"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer", result.TokenType)
+	assert.Equal(t, int64(3600), result.ExpiresIn)
+
+	// Проверяем подпись и claims выпущенного токена
+	token, err := jwt.Parse(result.AccessToken, func(token *jwt.Token) (interface{}, error) {
+		return secret, nil
+	})
+	assert.NoError(t, err)
+	claims := token.Claims.(jwt.MapClaims)
+	assert.Equal(t, userID.String(), claims["sub"])
+	assert.Equal(t, "admin", claims["role"])
+	assert.Contains(t, claims, "iat")
+	assert.Contains(t, claims, "exp")
+}
+
+func TestLoginInvalidCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockstoreUser(ctrl)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	mockStore.EXPECT().GetUserByUsername(gomock.Any(), "admin").Return(&models.User{
+		ID:           uuid.New(),
+		Username:     "admin",
+		PasswordHash: string(hash),
+		Role:         "admin",
+	}, nil)
+	mockStore.EXPECT().GetUserByUsername(gomock.Any(), "ghost").Return(nil, nil)
+
+	authService := NewAuth(mockStore, []byte("test-secret"), time.Hour, logger.Discard())
+
+	_, err = authService.Login(context.Background(), models.LoginRequest{Username: "admin", Password: "wrong-password"})
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = authService.Login(context.Background(), models.LoginRequest{Username: "ghost", Password: "any-password"})
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"cinema/mocks"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func batchMovie(title string) models.CreateMovie {
+	return models.CreateMovie{
+		Title:       title,
+		ReleaseDate: time.Date(2010, 7, 16, 0, 0, 0, 0, time.UTC),
+		Rating:      8.8,
+		ActorIDs:    []uuid.UUID{uuid.New()},
+		GenreIDs:    []uuid.UUID{uuid.New()},
+	}
+}
+
+func TestCreateMoviesRejectsWholeBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockstoreMovie(ctrl)
+	movies := []models.CreateMovie{batchMovie("Inception"), batchMovie("")}
+
+	// Второй фильм не проходит Validate(), транзакция не начинается
+	mockStore.EXPECT().CheckActorsExist(gomock.Any(), movies[0].ActorIDs).Return(true, nil)
+	mockStore.EXPECT().CheckGenresExist(gomock.Any(), movies[0].GenreIDs).Return(true, nil)
+
+	result, err := NewMovie(mockStore, nil, logger.Discard(), nil).CreateMovies(context.Background(), movies, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Created)
+	assert.Equal(t, 1, result.Failed)
+	assert.Nil(t, result.Items[0].ID)
+	assert.Empty(t, result.Items[0].Errors)
+	assert.Equal(t, 1, result.Items[1].Index)
+	assert.Equal(t, "title", result.Items[1].Errors[0].Field)
+}
+
+func TestCreateMoviesBestEffort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockstoreMovie(ctrl)
+	movies := []models.CreateMovie{batchMovie("Inception"), batchMovie("Tenet"), batchMovie("Memento")}
+	tx := testTx(t, true)
+	first, third := uuid.New(), uuid.New()
+
+	// У второго фильма неизвестные актеры, создаются первый и третий
+	mockStore.EXPECT().CheckActorsExist(gomock.Any(), movies[0].ActorIDs).Return(true, nil)
+	mockStore.EXPECT().CheckGenresExist(gomock.Any(), movies[0].GenreIDs).Return(true, nil)
+	mockStore.EXPECT().CheckActorsExist(gomock.Any(), movies[1].ActorIDs).Return(false, nil)
+	mockStore.EXPECT().CheckGenresExist(gomock.Any(), movies[1].GenreIDs).Return(true, nil)
+	mockStore.EXPECT().CheckActorsExist(gomock.Any(), movies[2].ActorIDs).Return(true, nil)
+	mockStore.EXPECT().CheckGenresExist(gomock.Any(), movies[2].GenreIDs).Return(true, nil)
+	gomock.InOrder(
+		mockStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil),
+		mockStore.EXPECT().CreateMovie(gomock.Any(), tx, movies[0]).Return(first, nil),
+		mockStore.EXPECT().AddMovieActorRelations(gomock.Any(), tx, first, models.CastCredits(movies[0].ActorIDs)).Return(nil),
+		mockStore.EXPECT().AddMovieGenreRelations(gomock.Any(), tx, first, movies[0].GenreIDs).Return(nil),
+		mockStore.EXPECT().CreateMovie(gomock.Any(), tx, movies[2]).Return(third, nil),
+		mockStore.EXPECT().AddMovieActorRelations(gomock.Any(), tx, third, models.CastCredits(movies[2].ActorIDs)).Return(nil),
+		mockStore.EXPECT().AddMovieGenreRelations(gomock.Any(), tx, third, movies[2].GenreIDs).Return(nil),
+	)
+
+	result, err := NewMovie(mockStore, nil, logger.Discard(), nil).CreateMovies(context.Background(), movies, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Created)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, first, *result.Items[0].ID)
+	assert.Nil(t, result.Items[1].ID)
+	assert.Equal(t, []models.ValidationError{{Field: "actor_ids", Message: "One or more actors in the list do not exist"}}, result.Items[1].Errors)
+	assert.Equal(t, third, *result.Items[2].ID)
+}
+
+func TestCreateMoviesRollsBackOnStoreError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockstoreMovie(ctrl)
+	movies := []models.CreateMovie{batchMovie("Inception"), batchMovie("Tenet")}
+	tx := testTx(t, false)
+	first := uuid.New()
+
+	mockStore.EXPECT().CheckActorsExist(gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+	mockStore.EXPECT().CheckGenresExist(gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+	gomock.InOrder(
+		mockStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil),
+		mockStore.EXPECT().CreateMovie(gomock.Any(), tx, movies[0]).Return(first, nil),
+		mockStore.EXPECT().AddMovieActorRelations(gomock.Any(), tx, first, gomock.Any()).Return(nil),
+		mockStore.EXPECT().AddMovieGenreRelations(gomock.Any(), tx, first, gomock.Any()).Return(nil),
+		mockStore.EXPECT().CreateMovie(gomock.Any(), tx, movies[1]).Return(uuid.Nil, assert.AnError),
+	)
+
+	result, err := NewMovie(mockStore, nil, logger.Discard(), nil).CreateMovies(context.Background(), movies, false)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Nil(t, result)
+}
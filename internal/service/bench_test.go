@@ -0,0 +1,113 @@
+package service
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"cinema/internal/repository"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+// Бенчмарки списков проходят путь репозиторий + сервис на страницах максимального размера API.
+// База заменена sqlmock, поэтому в цифрах есть и его накладные расходы, одинаковые для всех вариантов.
+const benchPageSize = 100
+
+func BenchmarkGetMoviesWithFilters(b *testing.B) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+
+	movieService := NewMovie(repository.NewMovie(db, logger.Discard(), nil), nil, logger.Discard(), nil)
+	genres := []byte(`[{"id": "` + uuid.NewString() + `", "name": "Драма"}]`)
+	releaseDate := time.Date(2010, 7, 16, 0, 0, 0, 0, time.UTC)
+	updatedAt := time.Now()
+	page := models.PageRequest{Limit: benchPageSize}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		rows := sqlmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "genres",
+			"poster_key", "version", "updated_at"})
+		for j := 0; j < benchPageSize; j++ {
+			rows.AddRow(uuid.New(), fmt.Sprintf("Movie %d", j), "A mind-bending thriller.", releaseDate, 8.8, genres,
+				nil, int64(1), updatedAt)
+		}
+		mock.ExpectQuery(`SELECT`).WillReturnRows(rows)
+		b.StartTimer()
+
+		if _, err := movieService.GetMoviesWithFilters(context.Background(), models.MovieFilter{}, "rating", "DESC", page); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetAllActors(b *testing.B) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+
+	actorService := NewActor(repository.NewActor(db, logger.Discard(), nil), nil, logger.Discard(), nil)
+	birthDate := time.Date(1974, 11, 11, 0, 0, 0, 0, time.UTC)
+	updatedAt := time.Now()
+	page := models.PageRequest{Limit: benchPageSize}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		rows := sqlmock.NewRows([]string{"id", "name", "gender", "date_of_birth", "headshot_key", "version", "updated_at"})
+		for j := 0; j < benchPageSize; j++ {
+			rows.AddRow(uuid.New(), fmt.Sprintf("Actor %d", j), "male", birthDate, nil, int64(1), updatedAt)
+		}
+		mock.ExpectQuery(`SELECT`).WillReturnRows(rows)
+		b.StartTimer()
+
+		if _, err := actorService.GetAllActors(context.Background(), page); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetActorsWithMovies(b *testing.B) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+
+	actorService := NewActor(repository.NewActor(db, logger.Discard(), nil), nil, logger.Discard(), nil)
+	birthDate := time.Date(1974, 11, 11, 0, 0, 0, 0, time.UTC)
+	releaseDate := time.Date(2010, 7, 16, 0, 0, 0, 0, time.UTC)
+	genres := []byte(`[]`)
+	updatedAt := time.Now()
+	page := models.PageRequest{Limit: benchPageSize}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		// По три фильма на актера
+		rows := sqlmock.NewRows([]string{"id", "name", "gender", "date_of_birth", "headshot_key", "version", "updated_at",
+			"id", "title", "description", "release_date", "rating", "genres", "poster_key", "version", "updated_at"})
+		for j := 0; j < benchPageSize; j++ {
+			actorID := uuid.New()
+			for k := 0; k < 3; k++ {
+				rows.AddRow(actorID, fmt.Sprintf("Actor %d", j), "male", birthDate, nil, int64(1), updatedAt, uuid.New(),
+					fmt.Sprintf("Movie %d", k), "A mind-bending thriller.", releaseDate, 8.8, genres, nil, int64(1), updatedAt)
+			}
+		}
+		mock.ExpectQuery(`SELECT`).WillReturnRows(rows)
+		b.StartTimer()
+
+		if _, err := actorService.GetActorsWithMovies(context.Background(), page); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
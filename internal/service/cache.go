@@ -0,0 +1,305 @@
+package service
+
+import (
+	"cinema/internal/cache"
+	"cinema/internal/models"
+	"context"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// Префиксы ключей кэша. Записи по ID и списки разделены, чтобы изменение одного фильма
+// не сбрасывало остальные фильмы, а только списки, в которые он мог попасть.
+const (
+	cacheMovie           = "movie:"              // фильм по ID
+	cacheCredits         = "credits:"            // титры фильма
+	cacheMovies          = "movies:"             // все списки фильмов, включая поиск
+	cacheMoviesByActor   = "movies:actor:"       // фильмы актера
+	cacheMoviesFilter    = "movies:filter:"      // фильмы по фильтру
+	cacheMoviesSearch    = "movies:search:"      // полнотекстовый поиск
+	cacheActor           = "actor:"              // актер по ID
+	cacheActors          = "actors:"             // все списки актеров
+	cacheActorsAll       = "actors:all:"         // список актеров
+	cacheActorsWithMovie = "actors:with-movies:" // актеры вместе с фильмами
+)
+
+// Читает значение из кэша или загружает его через load. Ошибки не кэшируются.
+// Закэшированные значения общие для всех запросов, вызывающий код не должен их изменять.
+func cached[T any](c *cache.LRU, key string, load func() (T, error)) (T, error) {
+	if value, ok := c.Get(key); ok {
+		return value.(T), nil
+	}
+
+	generation := c.Generation()
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+	c.Set(key, value, generation)
+	return value, nil
+}
+
+// Ключ для набора параметров запроса. Фильтр содержит указатели, поэтому %v не подходит:
+// в ключ попали бы адреса, а не значения.
+func cacheKey(prefix string, params ...any) string {
+	data, _ := json.Marshal(params)
+	return prefix + string(data)
+}
+
+func pageKey(page models.PageRequest) string {
+	return strconv.Itoa(page.Limit) + ":" + strconv.FormatBool(page.IncludeTotal) + ":" + page.Cursor
+}
+
+// Фильмы с кэшированием чтения
+type cachedMovie struct {
+	*movie
+	cache *cache.LRU
+}
+
+// NewCachedMovie оборачивает сервис фильмов кэшем. Изменения сбрасывают затронутые записи,
+// в том числе записи актеров, в которых встречается фильм. Если cache равен nil, кэш не используется.
+func NewCachedMovie(next *movie, c *cache.LRU) *cachedMovie {
+	return &cachedMovie{movie: next, cache: c}
+}
+
+func (s *cachedMovie) GetMovieByID(ctx context.Context, movieID uuid.UUID) (*models.Movie, error) {
+	return cached(s.cache, cacheMovie+movieID.String(), func() (*models.Movie, error) {
+		return s.movie.GetMovieByID(ctx, movieID)
+	})
+}
+
+func (s *cachedMovie) GetMovieCredits(ctx context.Context, movieID uuid.UUID) (*models.MovieCredits, error) {
+	return cached(s.cache, cacheCredits+movieID.String(), func() (*models.MovieCredits, error) {
+		return s.movie.GetMovieCredits(ctx, movieID)
+	})
+}
+
+func (s *cachedMovie) GetMoviesByActorID(ctx context.Context, actorID uuid.UUID, page models.PageRequest) (*models.Page[models.Movie], error) {
+	key := cacheMoviesByActor + actorID.String() + ":" + pageKey(page)
+	return cached(s.cache, key, func() (*models.Page[models.Movie], error) {
+		return s.movie.GetMoviesByActorID(ctx, actorID, page)
+	})
+}
+
+func (s *cachedMovie) GetMoviesWithFilters(ctx context.Context, filter models.MovieFilter, sortBy string, order string, page models.PageRequest) (*models.Page[models.Movie], error) {
+	key := cacheKey(cacheMoviesFilter, filter, sortBy, order, page)
+	return cached(s.cache, key, func() (*models.Page[models.Movie], error) {
+		return s.movie.GetMoviesWithFilters(ctx, filter, sortBy, order, page)
+	})
+}
+
+func (s *cachedMovie) SearchMovies(ctx context.Context, query string, limit, offset int) ([]models.MovieSearchResult, error) {
+	key := cacheKey(cacheMoviesSearch, query, limit, offset)
+	return cached(s.cache, key, func() ([]models.MovieSearchResult, error) {
+		return s.movie.SearchMovies(ctx, query, limit, offset)
+	})
+}
+
+// Новый фильм попадает в списки фильмов, а через actor_ids - в списки актеров с фильмами
+func (s *cachedMovie) CreateMovie(ctx context.Context, movie models.CreateMovie) (uuid.UUID, error) {
+	defer s.cache.Invalidate(cacheMovies, cacheActorsWithMovie)
+	return s.movie.CreateMovie(ctx, movie)
+}
+
+func (s *cachedMovie) CreateMovies(ctx context.Context, movies []models.CreateMovie, bestEffort bool) (*models.BatchResult, error) {
+	defer s.cache.Invalidate(cacheMovies, cacheActorsWithMovie)
+	return s.movie.CreateMovies(ctx, movies, bestEffort)
+}
+
+func (s *cachedMovie) UpdateMovie(ctx context.Context, movieID uuid.UUID, movie models.UpdateMovie, version *int64) error {
+	defer s.invalidateMovie(movieID)
+	return s.movie.UpdateMovie(ctx, movieID, movie, version)
+}
+
+func (s *cachedMovie) PatchMovie(ctx context.Context, movieID uuid.UUID, patch []byte, version *int64) (*models.Movie, error) {
+	defer s.invalidateMovie(movieID)
+	return s.movie.PatchMovie(ctx, movieID, patch, version)
+}
+
+func (s *cachedMovie) DeleteMovie(ctx context.Context, movieID uuid.UUID, version *int64) error {
+	defer s.invalidateMovie(movieID)
+	return s.movie.DeleteMovie(ctx, movieID, version)
+}
+
+func (s *cachedMovie) AddMovieActorRelations(ctx context.Context, movieID uuid.UUID, credits []models.CreditInput) error {
+	defer s.invalidateRelations(movieID)
+	return s.movie.AddMovieActorRelations(ctx, movieID, credits)
+}
+
+func (s *cachedMovie) UpdateMovieActorRelations(ctx context.Context, movieID uuid.UUID, credits []models.CreditInput) error {
+	defer s.invalidateRelations(movieID)
+	return s.movie.UpdateMovieActorRelations(ctx, movieID, credits)
+}
+
+func (s *cachedMovie) RemoveSelectedMovieActorRelations(ctx context.Context, movieID uuid.UUID, actorIDs []uuid.UUID) error {
+	defer s.invalidateRelations(movieID)
+	return s.movie.RemoveSelectedMovieActorRelations(ctx, movieID, actorIDs)
+}
+
+// Сброс выполняется и при ошибке: транзакция могла успеть закоммитить изменения
+// до того, как ошибка дошла до сервиса.
+func (s *cachedMovie) invalidateMovie(movieID uuid.UUID) {
+	s.cache.Invalidate(cacheMovie+movieID.String(), cacheCredits+movieID.String(), cacheMovies, cacheActorsWithMovie)
+}
+
+// Связи не меняют сам фильм, но меняют его титры, фильмы актеров и фильтр по актерам
+func (s *cachedMovie) invalidateRelations(movieID uuid.UUID) {
+	s.cache.Invalidate(cacheCredits+movieID.String(), cacheMovies, cacheActorsWithMovie)
+}
+
+// Актеры с кэшированием чтения
+type cachedActor struct {
+	*actor
+	cache *cache.LRU
+}
+
+// NewCachedActor оборачивает сервис актеров кэшем. Кэш должен быть общим с NewCachedMovie:
+// изменение актера сбрасывает титры фильмов, в которых он указан.
+func NewCachedActor(next *actor, c *cache.LRU) *cachedActor {
+	return &cachedActor{actor: next, cache: c}
+}
+
+func (a *cachedActor) GetActor(ctx context.Context, id uuid.UUID) (*models.Actor, error) {
+	return cached(a.cache, cacheActor+id.String(), func() (*models.Actor, error) {
+		return a.actor.GetActor(ctx, id)
+	})
+}
+
+func (a *cachedActor) GetAllActors(ctx context.Context, page models.PageRequest) (*models.Page[models.Actor], error) {
+	return cached(a.cache, cacheActorsAll+pageKey(page), func() (*models.Page[models.Actor], error) {
+		return a.actor.GetAllActors(ctx, page)
+	})
+}
+
+func (a *cachedActor) GetActorsWithMovies(ctx context.Context, page models.PageRequest) (*models.Page[models.ActorWithMovies], error) {
+	return cached(a.cache, cacheActorsWithMovie+pageKey(page), func() (*models.Page[models.ActorWithMovies], error) {
+		return a.actor.GetActorsWithMovies(ctx, page)
+	})
+}
+
+func (a *cachedActor) CreateActor(ctx context.Context, actor models.CreateActor) (uuid.UUID, error) {
+	defer a.cache.Invalidate(cacheActors)
+	return a.actor.CreateActor(ctx, actor)
+}
+
+// Имя актера выводится в титрах фильмов, сами фильмы его не содержат,
+// но по нему ранжируется полнотекстовый поиск
+func (a *cachedActor) UpdateActor(ctx context.Context, id uuid.UUID, actor models.UpdateActor, version *int64) error {
+	defer a.cache.Invalidate(cacheActor+id.String(), cacheActors, cacheCredits, cacheMoviesSearch)
+	return a.actor.UpdateActor(ctx, id, actor, version)
+}
+
+func (a *cachedActor) PatchActor(ctx context.Context, id uuid.UUID, patch []byte, version *int64) (*models.Actor, error) {
+	defer a.cache.Invalidate(cacheActor+id.String(), cacheActors, cacheCredits, cacheMoviesSearch)
+	return a.actor.PatchActor(ctx, id, patch, version)
+}
+
+// Вместе с актером удаляются его связи, поэтому меняются и списки фильмов с фильтром по актерам,
+// и поиск по именам актеров
+func (a *cachedActor) DeleteActor(ctx context.Context, id uuid.UUID, version *int64) error {
+	defer a.cache.Invalidate(cacheActor+id.String(), cacheActors, cacheCredits, cacheMovies, cacheMoviesSearch)
+	return a.actor.DeleteActor(ctx, id, version)
+}
+
+// Жанры не кэшируются, но входят в состав фильмов, поэтому их изменение сбрасывает фильмы
+type cachedGenre struct {
+	*genre
+	cache *cache.LRU
+}
+
+func NewCachedGenre(next *genre, c *cache.LRU) *cachedGenre {
+	return &cachedGenre{genre: next, cache: c}
+}
+
+func (g *cachedGenre) UpdateGenre(ctx context.Context, id uuid.UUID, genre models.UpdateGenre) error {
+	defer g.cache.Invalidate(cacheMovie, cacheMovies, cacheActorsWithMovie)
+	return g.genre.UpdateGenre(ctx, id, genre)
+}
+
+func (g *cachedGenre) DeleteGenre(ctx context.Context, id uuid.UUID) error {
+	defer g.cache.Invalidate(cacheMovie, cacheMovies, cacheActorsWithMovie)
+	return g.genre.DeleteGenre(ctx, id)
+}
+
+// Корзина с кэшем: восстановленные записи снова видны в публичных запросах,
+// поэтому их прежние отсутствующие значения нужно сбросить
+type cachedTrash struct {
+	*trash
+	cache *cache.LRU
+}
+
+func NewCachedTrash(next *trash, c *cache.LRU) *cachedTrash {
+	return &cachedTrash{trash: next, cache: c}
+}
+
+func (t *cachedTrash) RestoreMovie(ctx context.Context, id uuid.UUID) (bool, error) {
+	defer t.cache.Invalidate(cacheMovie+id.String(), cacheCredits+id.String(), cacheMovies, cacheActorsWithMovie)
+	return t.trash.RestoreMovie(ctx, id)
+}
+
+// Восстановленный актер снова в титрах, в фильтре по актерам и в поиске
+func (t *cachedTrash) RestoreActor(ctx context.Context, id uuid.UUID) (bool, error) {
+	defer t.cache.Invalidate(cacheActor+id.String(), cacheActors, cacheCredits, cacheMovies, cacheMoviesSearch)
+	return t.trash.RestoreActor(ctx, id)
+}
+
+// Импорт с кэшем: созданные записи могут попасть в любые списки и титры
+type cachedImporter struct {
+	*importer
+	cache *cache.LRU
+}
+
+func NewCachedImporter(next *importer, c *cache.LRU) *cachedImporter {
+	return &cachedImporter{importer: next, cache: c}
+}
+
+func (i *cachedImporter) Import(ctx context.Context, r io.Reader, opts models.ImportOptions) (*models.ImportResult, error) {
+	result, err := i.importer.Import(ctx, r, opts)
+	if result != nil && result.Committed {
+		i.cache.Invalidate(cacheMovies, cacheActors, cacheCredits)
+	}
+	return result, err
+}
+
+// Изображения с кэшем: адреса изображений входят в фильмы и актеров, а версия записи
+// меняется вместе с изображением
+type cachedImages struct {
+	*images
+	cache *cache.LRU
+}
+
+func NewCachedImages(next *images, c *cache.LRU) *cachedImages {
+	return &cachedImages{images: next, cache: c}
+}
+
+func (i *cachedImages) SetMoviePoster(ctx context.Context, movieID uuid.UUID, r io.Reader) (bool, error) {
+	defer i.invalidateMovie(movieID)
+	return i.images.SetMoviePoster(ctx, movieID, r)
+}
+
+func (i *cachedImages) DeleteMoviePoster(ctx context.Context, movieID uuid.UUID) (bool, error) {
+	defer i.invalidateMovie(movieID)
+	return i.images.DeleteMoviePoster(ctx, movieID)
+}
+
+func (i *cachedImages) SetActorHeadshot(ctx context.Context, actorID uuid.UUID, r io.Reader) (bool, error) {
+	defer i.invalidateActor(actorID)
+	return i.images.SetActorHeadshot(ctx, actorID, r)
+}
+
+func (i *cachedImages) DeleteActorHeadshot(ctx context.Context, actorID uuid.UUID) (bool, error) {
+	defer i.invalidateActor(actorID)
+	return i.images.DeleteActorHeadshot(ctx, actorID)
+}
+
+func (i *cachedImages) invalidateMovie(movieID uuid.UUID) {
+	i.cache.Invalidate(cacheMovie+movieID.String(), cacheCredits+movieID.String(), cacheMovies, cacheActorsWithMovie)
+}
+
+// Фотография актера есть и в титрах фильмов
+func (i *cachedImages) invalidateActor(actorID uuid.UUID) {
+	i.cache.Invalidate(cacheActor+actorID.String(), cacheActors, cacheCredits)
+}
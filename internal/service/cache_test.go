@@ -0,0 +1,178 @@
+package service
+
+import (
+	"cinema/internal/cache"
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"cinema/mocks"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedMovieInvalidatesOnlyChangedMovie(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockstoreMovie(ctrl)
+	c := cache.New(100, time.Minute)
+	svc := NewCachedMovie(NewMovie(mockStore, nil, logger.Discard(), nil), c)
+	ctx := context.Background()
+
+	deleted, kept := uuid.New(), uuid.New()
+	mockStore.EXPECT().GetMovieByID(gomock.Any(), deleted).Return(&models.Movie{ID: deleted}, nil)
+	mockStore.EXPECT().GetMovieByID(gomock.Any(), kept).Return(&models.Movie{ID: kept}, nil).Times(1)
+	tx := testTx(t, true)
+	mockStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil)
+	mockStore.EXPECT().DeleteMovie(gomock.Any(), tx, deleted, nil).Return(nil)
+	mockStore.EXPECT().GetMovieByID(gomock.Any(), deleted).Return(nil, nil)
+
+	// Повторное чтение обслуживается кэшем
+	for i := 0; i < 2; i++ {
+		movie, err := svc.GetMovieByID(ctx, deleted)
+		assert.NoError(t, err)
+		assert.Equal(t, deleted, movie.ID)
+		_, err = svc.GetMovieByID(ctx, kept)
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, svc.DeleteMovie(ctx, deleted, nil))
+
+	movie, err := svc.GetMovieByID(ctx, deleted)
+	assert.NoError(t, err)
+	assert.Nil(t, movie)
+	_, err = svc.GetMovieByID(ctx, kept)
+	assert.NoError(t, err)
+
+	stats := c.Stats()
+	assert.Equal(t, uint64(3), stats.Hits)
+	assert.Equal(t, uint64(3), stats.Misses)
+}
+
+func TestCachedActorUpdateInvalidatesMovieCredits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	movieStore := mocks.NewMockstoreMovie(ctrl)
+	actorStore := mocks.NewMockstoreActor(ctrl)
+	c := cache.New(100, time.Minute)
+	movies := NewCachedMovie(NewMovie(movieStore, nil, logger.Discard(), nil), c)
+	actors := NewCachedActor(NewActor(actorStore, nil, logger.Discard(), nil), c)
+	ctx := context.Background()
+
+	movieID, actorID := uuid.New(), uuid.New()
+	credit := models.Credit{ActorID: actorID, Name: "Old Name", Role: models.RoleActor}
+	renamed := credit
+	renamed.Name = "New Name"
+
+	tx := testTx(t, true)
+	gomock.InOrder(
+		movieStore.EXPECT().CheckMovieExists(gomock.Any(), movieID).Return(true, nil),
+		movieStore.EXPECT().GetMovieCredits(gomock.Any(), movieID).Return([]models.Credit{credit}, nil),
+		actorStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil),
+		actorStore.EXPECT().UpdateActor(gomock.Any(), tx, actorID, gomock.Any(), nil).Return(nil),
+		movieStore.EXPECT().CheckMovieExists(gomock.Any(), movieID).Return(true, nil),
+		movieStore.EXPECT().GetMovieCredits(gomock.Any(), movieID).Return([]models.Credit{renamed}, nil),
+	)
+
+	_, err := movies.GetMovieCredits(ctx, movieID)
+	assert.NoError(t, err)
+	_, err = movies.GetMovieCredits(ctx, movieID)
+	assert.NoError(t, err)
+
+	name := "New Name"
+	assert.NoError(t, actors.UpdateActor(ctx, actorID, models.UpdateActor{Name: &name}, nil))
+
+	credits, err := movies.GetMovieCredits(ctx, movieID)
+	assert.NoError(t, err)
+	assert.Equal(t, "New Name", credits.Cast[0].Name)
+}
+
+func TestCachedActorUpdateInvalidatesSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	movieStore := mocks.NewMockstoreMovie(ctrl)
+	actorStore := mocks.NewMockstoreActor(ctrl)
+	c := cache.New(100, time.Minute)
+	movies := NewCachedMovie(NewMovie(movieStore, nil, logger.Discard(), nil), c)
+	actors := NewCachedActor(NewActor(actorStore, nil, logger.Discard(), nil), c)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	found := []models.MovieSearchResult{{Movie: models.Movie{ID: uuid.New()}}}
+	tx := testTx(t, true)
+	gomock.InOrder(
+		movieStore.EXPECT().SearchMovies(gomock.Any(), "nolan", 10, 0).Return(found, nil),
+		actorStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil),
+		actorStore.EXPECT().UpdateActor(gomock.Any(), tx, actorID, gomock.Any(), nil).Return(nil),
+		// Поиск ранжируется по именам актеров, поэтому после переименования загружается заново
+		movieStore.EXPECT().SearchMovies(gomock.Any(), "nolan", 10, 0).Return(nil, nil),
+	)
+
+	_, err := movies.SearchMovies(ctx, "nolan", 10, 0)
+	assert.NoError(t, err)
+
+	name := "Renamed"
+	assert.NoError(t, actors.UpdateActor(ctx, actorID, models.UpdateActor{Name: &name}, nil))
+
+	result, err := movies.SearchMovies(ctx, "nolan", 10, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestCachedActorPatchInvalidatesSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	movieStore := mocks.NewMockstoreMovie(ctrl)
+	actorStore := mocks.NewMockstoreActor(ctrl)
+	c := cache.New(100, time.Minute)
+	movies := NewCachedMovie(NewMovie(movieStore, nil, logger.Discard(), nil), c)
+	actors := NewCachedActor(NewActor(actorStore, nil, logger.Discard(), nil), c)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	found := []models.MovieSearchResult{{Movie: models.Movie{ID: uuid.New()}}}
+	tx := testTx(t, true)
+	gomock.InOrder(
+		movieStore.EXPECT().SearchMovies(gomock.Any(), "nolan", 10, 0).Return(found, nil),
+		actorStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil),
+		actorStore.EXPECT().GetActorFields(gomock.Any(), tx, actorID).Return(&models.ActorFields{Name: "Nolan"}, nil),
+		actorStore.EXPECT().ReplaceActor(gomock.Any(), tx, actorID, gomock.Any(), nil).Return(nil),
+		actorStore.EXPECT().GetActor(gomock.Any(), actorID).Return(&models.Actor{ID: actorID, Name: "Renamed"}, nil),
+		movieStore.EXPECT().SearchMovies(gomock.Any(), "nolan", 10, 0).Return(nil, nil),
+	)
+
+	_, err := movies.SearchMovies(ctx, "nolan", 10, 0)
+	assert.NoError(t, err)
+	_, err = actors.PatchActor(ctx, actorID, []byte(`{"name":"Renamed"}`), nil)
+	assert.NoError(t, err)
+
+	result, err := movies.SearchMovies(ctx, "nolan", 10, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestCachedMovieFilterKeyUsesValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockstoreMovie(ctrl)
+	svc := NewCachedMovie(NewMovie(mockStore, nil, logger.Discard(), nil), cache.New(100, time.Minute))
+	ctx := context.Background()
+
+	// Одинаковые значения по разным указателям дают одну запись кэша
+	minRating, sameMinRating := 7.0, 7.0
+	mockStore.EXPECT().GetMoviesWithFilters(gomock.Any(), gomock.Any(), "rating", "desc", gomock.Nil(), 11).Return([]models.Movie{}, nil).Times(1)
+
+	for _, rating := range []*float64{&minRating, &sameMinRating} {
+		page, err := svc.GetMoviesWithFilters(ctx, models.MovieFilter{MinRating: rating}, "rating", "desc", models.PageRequest{Limit: 10})
+		assert.NoError(t, err)
+		assert.Empty(t, page.Items)
+	}
+}
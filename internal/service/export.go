@@ -0,0 +1,220 @@
+package service
+
+import (
+	"bufio"
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Размер буфера выгрузки. Пока буфер не заполнен, в ответ ничего не отправлено,
+// и об ошибке еще можно сообщить обычным ответом с кодом ошибки.
+const exportBufferSize = 32 << 10
+
+type storeExport interface {
+	BeginSnapshot(ctx context.Context) (*sql.Tx, error)
+	ExportMovies(ctx context.Context, tx *sql.Tx, filter models.MovieFilter, updatedSince *time.Time, each func(models.Movie) error) error
+	ExportCredits(ctx context.Context, tx *sql.Tx, filter models.MovieFilter, each func(models.ExportCredit) error) error
+}
+
+type storeActorExport interface {
+	ExportActors(ctx context.Context, tx *sql.Tx, updatedSince *time.Time, each func(models.Actor) error) error
+}
+
+type exporter struct {
+	movies storeExport
+	actors storeActorExport
+	log    *slog.Logger
+}
+
+func NewExporter(movies storeExport, actors storeActorExport, log *slog.Logger) *exporter {
+	return &exporter{movies: movies, actors: actors, log: log}
+}
+
+// Export пишет выгрузку в w потоком, записи идут из БД прямо в w без накопления в памяти.
+// Все запросы выполняются в одной транзакции repeatable read, поэтому выгрузка согласована,
+// даже если каталог меняется во время нее. Возвращает число выгруженных записей.
+// opts должны пройти Validate().
+func (e *exporter) Export(ctx context.Context, w io.Writer, opts models.ExportOptions) (int, error) {
+	tx, err := e.movies.BeginSnapshot(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback() // транзакция только читает, фиксировать нечего
+
+	out := bufio.NewWriterSize(w, exportBufferSize)
+	var count int
+	switch opts.Kind {
+	case models.ExportMovies:
+		count, err = exportRows(out, opts.Format, movieExport, func(each func(models.Movie) error) error {
+			return e.movies.ExportMovies(ctx, tx, opts.Filter, opts.UpdatedSince, each)
+		})
+	case models.ExportActors:
+		count, err = exportRows(out, opts.Format, actorExport, func(each func(models.Actor) error) error {
+			return e.actors.ExportActors(ctx, tx, opts.UpdatedSince, each)
+		})
+	case models.ExportCredits:
+		count, err = exportRows(out, opts.Format, creditExport, func(each func(models.ExportCredit) error) error {
+			return e.movies.ExportCredits(ctx, tx, opts.Filter, each)
+		})
+	default:
+		err = fmt.Errorf("unknown export kind %q", opts.Kind)
+	}
+	if err == nil {
+		err = out.Flush()
+	}
+	if err != nil {
+		e.log.ErrorContext(ctx, "Export failed", "op", "Export", "kind", opts.Kind, "format", opts.Format, "exported", count, logger.Err(err))
+		return count, err
+	}
+
+	e.log.InfoContext(ctx, "Export finished", "kind", opts.Kind, "format", opts.Format, "exported", count)
+	return count, nil
+}
+
+// Колонки CSV одного вида выгрузки и значения записи в том же порядке
+type exportSchema[T any] struct {
+	columns []string
+	row     func(record T) []string
+}
+
+// Пишет записи, которые stream передает по одной, в формате format
+func exportRows[T any](w *bufio.Writer, format string, schema exportSchema[T], stream func(each func(T) error) error) (int, error) {
+	count := 0
+	var write func(record T) error
+	var end func() error
+
+	switch format {
+	case models.FormatNDJSON:
+		encoder := json.NewEncoder(w)
+		write = func(record T) error { return encoder.Encode(record) }
+		end = func() error { return nil }
+	case models.FormatJSON:
+		// Один массив: скобки и запятые между записями пишутся вручную, чтобы не собирать массив в памяти
+		if _, err := w.WriteString("["); err != nil {
+			return 0, err
+		}
+		write = func(record T) error {
+			data, err := json.Marshal(record)
+			if err != nil {
+				return err
+			}
+			separator := ",\n"
+			if count == 0 {
+				separator = "\n"
+			}
+			if _, err := w.WriteString(separator); err != nil {
+				return err
+			}
+			_, err = w.Write(data)
+			return err
+		}
+		end = func() error {
+			closing := "]\n"
+			if count > 0 {
+				closing = "\n]\n"
+			}
+			_, err := w.WriteString(closing)
+			return err
+		}
+	case models.FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(schema.columns); err != nil {
+			return 0, err
+		}
+		write = func(record T) error { return writer.Write(schema.row(record)) }
+		end = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	default:
+		return 0, fmt.Errorf("unknown export format %q", format)
+	}
+
+	err := stream(func(record T) error {
+		if err := write(record); err != nil {
+			return fmt.Errorf("failed to write record: %w", err)
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+	return count, end()
+}
+
+// Значения CSV в тех же форматах, что принимает импорт
+func csvDate(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+	return value.Format(time.DateOnly)
+}
+
+func csvFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func csvOptional[T any](value *T, format func(T) string) string {
+	if value == nil {
+		return ""
+	}
+	return format(*value)
+}
+
+var movieExport = exportSchema[models.Movie]{
+	columns: []string{"id", "title", "description", "release_date", "rating", "genre_ids", "version", "updated_at"},
+	row: func(movie models.Movie) []string {
+		genreIDs := make([]string, 0, len(movie.Genres))
+		for _, genre := range movie.Genres {
+			genreIDs = append(genreIDs, genre.ID.String())
+		}
+		return []string{
+			movie.ID.String(),
+			movie.Title,
+			movie.Description,
+			csvDate(movie.ReleaseDate),
+			csvOptional(movie.Rating, csvFloat),
+			strings.Join(genreIDs, ","),
+			strconv.FormatInt(movie.Version, 10),
+			movie.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		}
+	},
+}
+
+var actorExport = exportSchema[models.Actor]{
+	columns: []string{"id", "name", "gender", "date_of_birth", "version", "updated_at"},
+	row: func(actor models.Actor) []string {
+		return []string{
+			actor.ID.String(),
+			actor.Name,
+			actor.Gender,
+			csvDate(actor.DateOfBirth),
+			strconv.FormatInt(actor.Version, 10),
+			actor.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		}
+	},
+}
+
+var creditExport = exportSchema[models.ExportCredit]{
+	columns: []string{"movie_id", "actor_id", "role", "character", "order"},
+	row: func(credit models.ExportCredit) []string {
+		return []string{
+			credit.MovieID.String(),
+			credit.ActorID.String(),
+			credit.Role,
+			csvOptional(credit.Character, func(character string) string { return character }),
+			csvOptional(credit.Order, strconv.Itoa),
+		}
+	},
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"bytes"
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"cinema/mocks"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportCreditsCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	movieStore := mocks.NewMockstoreExport(ctrl)
+	actorStore := mocks.NewMockstoreActorExport(ctrl)
+	svc := NewExporter(movieStore, actorStore, logger.Discard())
+
+	tx := testTx(t, false)
+	movieID := uuid.MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	actorID := uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	character, order := "Eames, the forger", 2
+
+	movieStore.EXPECT().BeginSnapshot(gomock.Any()).Return(tx, nil)
+	movieStore.EXPECT().ExportCredits(gomock.Any(), tx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ any, _ models.MovieFilter, each func(models.ExportCredit) error) error {
+			if err := each(models.ExportCredit{MovieID: movieID, ActorID: actorID, Role: models.RoleActor, Character: &character, Order: &order}); err != nil {
+				return err
+			}
+			return each(models.ExportCredit{MovieID: movieID, ActorID: actorID, Role: models.RoleDirector})
+		})
+
+	var out bytes.Buffer
+	count, err := svc.Export(context.Background(), &out, models.ExportOptions{Kind: models.ExportCredits, Format: models.FormatCSV})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "movie_id,actor_id,role,character,order\n"+
+		movieID.String()+","+actorID.String()+`,actor,"Eames, the forger",2`+"\n"+
+		movieID.String()+","+actorID.String()+",director,,\n", out.String())
+}
+
+func TestExportMoviesJSONArray(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	movieStore := mocks.NewMockstoreExport(ctrl)
+	actorStore := mocks.NewMockstoreActorExport(ctrl)
+	svc := NewExporter(movieStore, actorStore, logger.Discard())
+
+	released := time.Date(2010, 7, 16, 0, 0, 0, 0, time.UTC)
+	inception, dunkirk := 8.8, 7.8
+	movies := []models.Movie{
+		{ID: uuid.New(), Title: "Inception", ReleaseDate: released, Rating: &inception, Genres: []models.Genre{}},
+		{ID: uuid.New(), Title: "Dunkirk", ReleaseDate: released, Rating: &dunkirk, Genres: []models.Genre{}},
+	}
+
+	for _, exported := range [][]models.Movie{nil, movies} {
+		tx := testTx(t, false)
+		movieStore.EXPECT().BeginSnapshot(gomock.Any()).Return(tx, nil)
+		movieStore.EXPECT().ExportMovies(gomock.Any(), tx, gomock.Any(), nil, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ any, _ models.MovieFilter, _ *time.Time, each func(models.Movie) error) error {
+				for _, movie := range exported {
+					if err := each(movie); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+
+		var out bytes.Buffer
+		count, err := svc.Export(context.Background(), &out, models.ExportOptions{Kind: models.ExportMovies, Format: models.FormatJSON})
+		assert.NoError(t, err)
+		assert.Equal(t, len(exported), count)
+		// Результат - один корректный JSON-документ, даже если записей нет
+		var decoded []models.Movie
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+		assert.Len(t, decoded, len(exported))
+	}
+}
+
+func TestExportMoviesWithoutRating(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	movieStore := mocks.NewMockstoreExport(ctrl)
+	actorStore := mocks.NewMockstoreActorExport(ctrl)
+	svc := NewExporter(movieStore, actorStore, logger.Discard())
+
+	updated := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	movie := models.Movie{ID: uuid.MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479"), Title: "Tenet", Version: 1, UpdatedAt: updated}
+
+	// Фильм без рейтинга выгружается пустой ячейкой CSV и null в JSON, а не рейтингом 0
+	for format, expected := range map[string]string{
+		models.FormatCSV: "id,title,description,release_date,rating,genre_ids,version,updated_at\n" +
+			movie.ID.String() + ",Tenet,,,,,1,2024-05-01T12:00:00Z\n",
+		models.FormatJSON: `"rating":null`,
+	} {
+		tx := testTx(t, false)
+		movieStore.EXPECT().BeginSnapshot(gomock.Any()).Return(tx, nil)
+		movieStore.EXPECT().ExportMovies(gomock.Any(), tx, gomock.Any(), nil, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ any, _ models.MovieFilter, _ *time.Time, each func(models.Movie) error) error {
+				return each(movie)
+			})
+
+		var out bytes.Buffer
+		_, err := svc.Export(context.Background(), &out, models.ExportOptions{Kind: models.ExportMovies, Format: format})
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), expected)
+	}
+}
+
+func TestExportFailureIsNotFlushed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	movieStore := mocks.NewMockstoreExport(ctrl)
+	actorStore := mocks.NewMockstoreActorExport(ctrl)
+	svc := NewExporter(movieStore, actorStore, logger.Discard())
+
+	tx := testTx(t, false)
+	dbErr := errors.New("connection reset")
+
+	movieStore.EXPECT().BeginSnapshot(gomock.Any()).Return(tx, nil)
+	actorStore.EXPECT().ExportActors(gomock.Any(), tx, nil, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ any, _ *time.Time, each func(models.Actor) error) error {
+			if err := each(models.Actor{ID: uuid.New(), Name: "Tom Hardy"}); err != nil {
+				return err
+			}
+			return dbErr
+		})
+
+	// Пока буфер не заполнен, в w ничего не попадает, и контроллер может ответить ошибкой
+	var out bytes.Buffer
+	count, err := svc.Export(context.Background(), &out, models.ExportOptions{Kind: models.ExportActors, Format: models.FormatNDJSON})
+	assert.ErrorIs(t, err, dbErr)
+	assert.Equal(t, 1, count)
+	assert.Zero(t, out.Len())
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/metrics"
+	"cinema/internal/models"
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/google/uuid"
+)
+
+type storeGenre interface {
+	BeginTransaction(ctx context.Context) (*sql.Tx, error)
+	CreateGenre(ctx context.Context, tx *sql.Tx, genre models.CreateGenre) (uuid.UUID, error)
+	GetGenre(ctx context.Context, id uuid.UUID) (*models.Genre, error)
+	GetAllGenres(ctx context.Context) ([]models.Genre, error)
+	UpdateGenre(ctx context.Context, tx *sql.Tx, id uuid.UUID, genre models.UpdateGenre) error
+	DeleteGenre(ctx context.Context, tx *sql.Tx, id uuid.UUID) error
+}
+
+type genre struct {
+	store   storeGenre
+	audit   *auditLog
+	log     *slog.Logger
+	metrics *metrics.Metrics
+}
+
+// NewGenre создает сервис жанров. Изменения записываются в audit, если он не nil.
+func NewGenre(store storeGenre, audit *auditLog, log *slog.Logger, metrics *metrics.Metrics) *genre {
+	return &genre{store: store, audit: audit, log: log, metrics: metrics}
+}
+
+// Добавление жанра
+func (g *genre) CreateGenre(ctx context.Context, genre models.CreateGenre) (uuid.UUID, error) {
+	return withTransactionUUID(ctx, g.store.BeginTransaction, g.metrics, func(tx *sql.Tx) (uuid.UUID, error) {
+		return g.audit.RecordCreate(ctx, tx, models.AuditGenre, func() (uuid.UUID, error) {
+			return g.store.CreateGenre(ctx, tx, genre)
+		})
+	})
+}
+
+// Получение жанра по ID
+func (g *genre) GetGenre(ctx context.Context, id uuid.UUID) (*models.Genre, error) {
+	genre, err := g.store.GetGenre(ctx, id)
+	if err != nil {
+		g.log.ErrorContext(ctx, "Failed to retrieve genre", "op", "GetGenre", "genre_id", id, logger.Err(err))
+		return nil, err
+	}
+	return genre, nil // nil, если жанр не найден
+}
+
+// Получение всех жанров
+func (g *genre) GetAllGenres(ctx context.Context) ([]models.Genre, error) {
+	genres, err := g.store.GetAllGenres(ctx)
+	if err != nil {
+		g.log.ErrorContext(ctx, "Failed to retrieve genres", "op", "GetAllGenres", logger.Err(err))
+		return nil, err
+	}
+	return genres, nil
+}
+
+// Обновление жанра
+func (g *genre) UpdateGenre(ctx context.Context, id uuid.UUID, genre models.UpdateGenre) error {
+	return withTransactionError(ctx, g.store.BeginTransaction, g.metrics, func(tx *sql.Tx) error {
+		return g.audit.Record(ctx, tx, models.AuditUpdate, models.AuditGenre, id, func() error {
+			return g.store.UpdateGenre(ctx, tx, id, genre)
+		})
+	})
+}
+
+// Удаление жанра. Связи с фильмами удаляются каскадно и в журнал отдельно не записываются.
+func (g *genre) DeleteGenre(ctx context.Context, id uuid.UUID) error {
+	return withTransactionError(ctx, g.store.BeginTransaction, g.metrics, func(tx *sql.Tx) error {
+		return g.audit.Record(ctx, tx, models.AuditDelete, models.AuditGenre, id, func() error {
+			return g.store.DeleteGenre(ctx, tx, id)
+		})
+	})
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"cinema/internal/models"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Проверка зависимости сервиса, nil - зависимость доступна
+type HealthCheckFunc func(ctx context.Context) error
+
+var errShuttingDown = errors.New("server is shutting down")
+
+type healthCheck struct {
+	name  string
+	check HealthCheckFunc
+}
+
+type health struct {
+	timeout      time.Duration
+	checks       []healthCheck
+	shuttingDown atomic.Bool
+}
+
+// NewHealth создает проверку готовности, каждая зависимость проверяется не дольше timeout
+func NewHealth(timeout time.Duration) *health {
+	return &health{timeout: timeout}
+}
+
+// AddCheck регистрирует проверку. Вызывается при старте, до обработки запросов.
+func (h *health) AddCheck(name string, check HealthCheckFunc) {
+	h.checks = append(h.checks, healthCheck{name: name, check: check})
+}
+
+// SetShuttingDown переводит готовность в fail на время плавной остановки
+func (h *health) SetShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+// Ready выполняет все проверки параллельно и собирает отчет
+func (h *health) Ready(ctx context.Context) models.HealthReport {
+	results := make([]models.HealthCheck, len(h.checks))
+
+	var wg sync.WaitGroup
+	for i, check := range h.checks {
+		wg.Add(1)
+		go func(i int, check healthCheck) {
+			defer wg.Done()
+			results[i] = h.run(ctx, check.check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	report := models.HealthReport{
+		Status: models.HealthStatusOK,
+		Checks: make(map[string]models.HealthCheck, len(h.checks)+1),
+	}
+	for i, check := range h.checks {
+		report.Checks[check.name] = results[i]
+		if results[i].Status != models.HealthStatusOK {
+			report.Status = models.HealthStatusFail
+		}
+	}
+
+	shutdown := models.HealthCheck{Status: models.HealthStatusOK}
+	if h.shuttingDown.Load() {
+		shutdown = models.HealthCheck{Status: models.HealthStatusFail, Error: errShuttingDown.Error()}
+		report.Status = models.HealthStatusFail
+	}
+	report.Checks["shutdown"] = shutdown
+
+	return report
+}
+
+func (h *health) run(ctx context.Context, check HealthCheckFunc) models.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	result := models.HealthCheck{
+		Status:     models.HealthStatusOK,
+		DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = models.HealthStatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
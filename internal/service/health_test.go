@@ -0,0 +1,53 @@
+package service
+
+import (
+	"cinema/internal/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthReady(t *testing.T) {
+	health := NewHealth(time.Second)
+	health.AddCheck("database", func(ctx context.Context) error { return nil })
+
+	report := health.Ready(context.Background())
+	assert.Equal(t, models.HealthStatusOK, report.Status)
+	assert.Equal(t, models.HealthStatusOK, report.Checks["database"].Status)
+	assert.Equal(t, models.HealthStatusOK, report.Checks["shutdown"].Status)
+}
+
+func TestHealthFailingCheck(t *testing.T) {
+	health := NewHealth(time.Second)
+	health.AddCheck("database", func(ctx context.Context) error { return nil })
+	health.AddCheck("migrations", func(ctx context.Context) error { return errors.New("schema version 3, expected 5") })
+
+	report := health.Ready(context.Background())
+	assert.Equal(t, models.HealthStatusFail, report.Status)
+	assert.Equal(t, models.HealthStatusOK, report.Checks["database"].Status)
+	assert.Equal(t, "schema version 3, expected 5", report.Checks["migrations"].Error)
+}
+
+func TestHealthCheckTimeout(t *testing.T) {
+	health := NewHealth(10 * time.Millisecond)
+	health.AddCheck("database", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	report := health.Ready(context.Background())
+	assert.Equal(t, models.HealthStatusFail, report.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["database"].Error)
+}
+
+func TestHealthShuttingDown(t *testing.T) {
+	health := NewHealth(time.Second)
+	health.SetShuttingDown()
+
+	report := health.Ready(context.Background())
+	assert.Equal(t, models.HealthStatusFail, report.Status)
+	assert.Equal(t, models.HealthStatusFail, report.Checks["shutdown"].Status)
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/metrics"
+	"cinema/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrImageTooLarge    = errors.New("image is too large")
+	ErrUnsupportedImage = errors.New("unsupported image type, expected JPEG, PNG or WebP")
+)
+
+// Хранилище файлов изображений. Ключ - относительный путь через "/".
+// Open возвращает storage.ErrNotFound, если файла нет; Delete отсутствующего файла не считается ошибкой.
+type storeBlob interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type storeMovieImage interface {
+	BeginTransaction(ctx context.Context) (*sql.Tx, error)
+	SetMoviePoster(ctx context.Context, tx *sql.Tx, id uuid.UUID, key *string) (previous *string, found bool, err error)
+	GetMoviePoster(ctx context.Context, id uuid.UUID) (*string, error)
+}
+
+type storeActorImage interface {
+	BeginTransaction(ctx context.Context) (*sql.Tx, error)
+	SetActorHeadshot(ctx context.Context, tx *sql.Tx, id uuid.UUID, key *string) (previous *string, found bool, err error)
+	GetActorHeadshot(ctx context.Context, id uuid.UUID) (*string, error)
+}
+
+// Допустимые типы изображений и расширения их файлов в хранилище.
+// Тип определяется по содержимому файла, а при выдаче - по расширению ключа.
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// Постеры фильмов и фотографии актеров
+type images struct {
+	movies  storeMovieImage
+	actors  storeActorImage
+	files   *imageFiles
+	audit   *auditLog
+	maxSize int64
+	log     *slog.Logger
+	metrics *metrics.Metrics
+}
+
+// NewImages создает сервис изображений. Файлы больше maxSize байт отклоняются.
+// Замена изображения записывается в audit как изменение фильма или актера.
+func NewImages(movies storeMovieImage, actors storeActorImage, files *imageFiles, audit *auditLog, maxSize int64, log *slog.Logger, metrics *metrics.Metrics) *images {
+	return &images{movies: movies, actors: actors, files: files, audit: audit, maxSize: maxSize, log: log, metrics: metrics}
+}
+
+// Заменить постер фильма. false, если фильма нет или он в корзине.
+func (s *images) SetMoviePoster(ctx context.Context, movieID uuid.UUID, r io.Reader) (bool, error) {
+	return s.upload(ctx, r, "posters/"+movieID.String()+"/", "SetMoviePoster", func(key *string) (*string, bool, error) {
+		return s.setMoviePoster(ctx, movieID, key)
+	})
+}
+
+// Убрать постер фильма. false, если фильма нет или он в корзине.
+func (s *images) DeleteMoviePoster(ctx context.Context, movieID uuid.UUID) (bool, error) {
+	previous, found, err := s.setMoviePoster(ctx, movieID, nil)
+	if err != nil {
+		s.log.ErrorContext(ctx, "Transaction failed", "op", "DeleteMoviePoster", "movie_id", movieID, logger.Err(err))
+		return false, err
+	}
+	s.files.delete(ctx, stringSlice(previous))
+	return found, nil
+}
+
+// Постер фильма или его копия ширины width (0 - оригинал); nil, если фильма или постера нет.
+// Body результата закрывает вызывающий код.
+func (s *images) GetMoviePoster(ctx context.Context, movieID uuid.UUID, width int) (*models.Image, error) {
+	key, err := s.movies.GetMoviePoster(ctx, movieID)
+	if err != nil || key == nil {
+		return nil, err
+	}
+	return s.files.open(ctx, *key, width)
+}
+
+// Заменить фотографию актера. false, если актера нет или он в корзине.
+func (s *images) SetActorHeadshot(ctx context.Context, actorID uuid.UUID, r io.Reader) (bool, error) {
+	return s.upload(ctx, r, "headshots/"+actorID.String()+"/", "SetActorHeadshot", func(key *string) (*string, bool, error) {
+		return s.setActorHeadshot(ctx, actorID, key)
+	})
+}
+
+// Убрать фотографию актера. false, если актера нет или он в корзине.
+func (s *images) DeleteActorHeadshot(ctx context.Context, actorID uuid.UUID) (bool, error) {
+	previous, found, err := s.setActorHeadshot(ctx, actorID, nil)
+	if err != nil {
+		s.log.ErrorContext(ctx, "Transaction failed", "op", "DeleteActorHeadshot", "actor_id", actorID, logger.Err(err))
+		return false, err
+	}
+	s.files.delete(ctx, stringSlice(previous))
+	return found, nil
+}
+
+// Фотография актера или ее копия ширины width, см. GetMoviePoster
+func (s *images) GetActorHeadshot(ctx context.Context, actorID uuid.UUID, width int) (*models.Image, error) {
+	key, err := s.actors.GetActorHeadshot(ctx, actorID)
+	if err != nil || key == nil {
+		return nil, err
+	}
+	return s.files.open(ctx, *key, width)
+}
+
+func (s *images) setMoviePoster(ctx context.Context, movieID uuid.UUID, key *string) (previous *string, found bool, err error) {
+	err = withTransactionError(ctx, s.movies.BeginTransaction, s.metrics, func(tx *sql.Tx) error {
+		return s.audit.Record(ctx, tx, models.AuditUpdate, models.AuditMovie, movieID, func() error {
+			previous, found, err = s.movies.SetMoviePoster(ctx, tx, movieID, key)
+			return err
+		})
+	})
+	return previous, found, err
+}
+
+func (s *images) setActorHeadshot(ctx context.Context, actorID uuid.UUID, key *string) (previous *string, found bool, err error) {
+	err = withTransactionError(ctx, s.actors.BeginTransaction, s.metrics, func(tx *sql.Tx) error {
+		return s.audit.Record(ctx, tx, models.AuditUpdate, models.AuditActor, actorID, func() error {
+			previous, found, err = s.actors.SetActorHeadshot(ctx, tx, actorID, key)
+			return err
+		})
+	})
+	return previous, found, err
+}
+
+// Загрузка нового изображения. Каждый файл получает новый ключ с префиксом prefix, поэтому
+// читатели никогда не видят наполовину замененный файл, а ETag меняется вместе с файлом.
+// Файл и его копии записываются до транзакции: если запись в БД не удалась, они удаляются,
+// а прежние файлы удаляются только после фиксации.
+func (s *images) upload(ctx context.Context, r io.Reader, prefix, op string, set func(key *string) (*string, bool, error)) (bool, error) {
+	data, contentType, err := s.readImage(r)
+	if err != nil {
+		return false, err
+	}
+
+	key := prefix + uuid.NewString() + imageExtensions[contentType]
+	if err := s.files.put(ctx, key, data); err != nil {
+		if !errors.Is(err, ErrUnsupportedImage) && !errors.Is(err, ErrImageTooLarge) {
+			s.log.ErrorContext(ctx, "Failed to store image", "op", op, "key", key, logger.Err(err))
+		}
+		return false, err
+	}
+
+	previous, found, err := set(&key)
+	if err != nil || !found {
+		s.files.delete(ctx, []string{key})
+		if err != nil {
+			s.log.ErrorContext(ctx, "Transaction failed", "op", op, "key", key, logger.Err(err))
+		}
+		return false, err
+	}
+	s.files.delete(ctx, stringSlice(previous))
+	return true, nil
+}
+
+// Читает изображение целиком, проверяя размер и тип по первым байтам содержимого
+func (s *images) readImage(r io.Reader) ([]byte, string, error) {
+	data, err := io.ReadAll(io.LimitReader(r, s.maxSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read image: %w", err)
+	}
+	if int64(len(data)) > s.maxSize {
+		return nil, "", ErrImageTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	if _, ok := imageExtensions[contentType]; !ok {
+		return nil, "", fmt.Errorf("%w: got %s", ErrUnsupportedImage, contentType)
+	}
+	return data, contentType, nil
+}
+
+func imageContentType(name string) string {
+	ext := path.Ext(name)
+	for contentType, candidate := range imageExtensions {
+		if candidate == ext {
+			return contentType
+		}
+	}
+	return "application/octet-stream"
+}
+
+func stringSlice(value *string) []string {
+	if value == nil {
+		return nil
+	}
+	return []string{*value}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"bytes"
+	"cinema/internal/logger"
+	"cinema/mocks"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Начало PNG-файла: http.DetectContentType определяет тип по сигнатуре
+var testPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestSetMoviePosterReplacesFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	movieStore := mocks.NewMockstoreMovieImage(ctrl)
+	blobs := mocks.NewMockstoreBlob(ctrl)
+	svc := NewImages(movieStore, mocks.NewMockstoreActorImage(ctrl), NewImageFiles(blobs, nil, logger.Discard()), nil, 1024, logger.Discard(), nil)
+
+	movieID := uuid.New()
+	previous := "posters/" + movieID.String() + "/old.jpg"
+	tx := testTx(t, true)
+	var key string
+	gomock.InOrder(
+		blobs.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, k string, r io.Reader) error {
+			key = k
+			data, err := io.ReadAll(r)
+			assert.NoError(t, err)
+			assert.Equal(t, testPNG, data)
+			return nil
+		}),
+		movieStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil),
+		movieStore.EXPECT().SetMoviePoster(gomock.Any(), tx, movieID, gomock.Any()).Return(&previous, true, nil),
+		// Прежний файл удаляется только после фиксации
+		blobs.EXPECT().Delete(gomock.Any(), previous).Return(nil),
+	)
+
+	found, err := svc.SetMoviePoster(context.Background(), movieID, bytes.NewReader(testPNG))
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.True(t, strings.HasPrefix(key, "posters/"+movieID.String()+"/"))
+	assert.True(t, strings.HasSuffix(key, ".png"))
+}
+
+func TestSetActorHeadshotNotFoundDeletesUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorStore := mocks.NewMockstoreActorImage(ctrl)
+	blobs := mocks.NewMockstoreBlob(ctrl)
+	svc := NewImages(mocks.NewMockstoreMovieImage(ctrl), actorStore, NewImageFiles(blobs, nil, logger.Discard()), nil, 1024, logger.Discard(), nil)
+
+	actorID := uuid.New()
+	tx := testTx(t, true)
+	var key string
+	gomock.InOrder(
+		blobs.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, k string, _ io.Reader) error {
+			key = k
+			return nil
+		}),
+		actorStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil),
+		actorStore.EXPECT().SetActorHeadshot(gomock.Any(), tx, actorID, gomock.Any()).Return(nil, false, nil),
+		blobs.EXPECT().Delete(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, k string) error {
+			assert.Equal(t, key, k)
+			return nil
+		}),
+	)
+
+	found, err := svc.SetActorHeadshot(context.Background(), actorID, bytes.NewReader(testPNG))
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestSetMoviePosterRejectsInvalidFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Хранилище и БД не вызываются: файл отклоняется до записи
+	svc := NewImages(mocks.NewMockstoreMovieImage(ctrl), mocks.NewMockstoreActorImage(ctrl), NewImageFiles(mocks.NewMockstoreBlob(ctrl), nil, logger.Discard()), nil, 32, logger.Discard(), nil)
+	movieID := uuid.New()
+
+	_, err := svc.SetMoviePoster(context.Background(), movieID, strings.NewReader("<html><body>not an image</body></html>"))
+	assert.ErrorIs(t, err, ErrImageTooLarge)
+
+	_, err = svc.SetMoviePoster(context.Background(), movieID, strings.NewReader("GIF89a"))
+	assert.ErrorIs(t, err, ErrUnsupportedImage)
+}
+
+func TestGetMoviePosterETagFromKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	movieStore := mocks.NewMockstoreMovieImage(ctrl)
+	blobs := mocks.NewMockstoreBlob(ctrl)
+	svc := NewImages(movieStore, mocks.NewMockstoreActorImage(ctrl), NewImageFiles(blobs, nil, logger.Discard()), nil, 1024, logger.Discard(), nil)
+
+	movieID := uuid.New()
+	key := "posters/" + movieID.String() + "/3f2a.webp"
+	movieStore.EXPECT().GetMoviePoster(gomock.Any(), movieID).Return(&key, nil)
+	blobs.EXPECT().Open(gomock.Any(), key).Return(io.NopCloser(strings.NewReader("data")), nil)
+
+	image, err := svc.GetMoviePoster(context.Background(), movieID, 0)
+	assert.NoError(t, err)
+	defer image.Body.Close()
+	assert.Equal(t, "image/webp", image.ContentType)
+	assert.Equal(t, `"3f2a"`, image.ETag)
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/metrics"
+	"cinema/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Файл импорта не удалось разобрать целиком: неизвестный формат, неверный заголовок CSV и т.п.
+// Ошибки в отдельных строках возвращаются в models.ImportResult.
+var ErrInvalidImport = errors.New("invalid import file")
+
+type storeActorImport interface {
+	BeginTransaction(ctx context.Context) (*sql.Tx, error)
+	CreateActor(ctx context.Context, tx *sql.Tx, actor models.CreateActor) (uuid.UUID, error)
+	FindActors(ctx context.Context, tx *sql.Tx, ref models.ActorRef) ([]uuid.UUID, error)
+}
+
+type storeMovieImport interface {
+	CreateMovie(ctx context.Context, tx *sql.Tx, movie models.CreateMovie) (uuid.UUID, error)
+	AddMovieActorRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID, credits []models.CreditInput) error
+	AddMovieGenreRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID, genreIDs []uuid.UUID) error
+	CheckGenresExist(ctx context.Context, genreIDs []uuid.UUID) (bool, error)
+	FindMovies(ctx context.Context, tx *sql.Tx, ref models.MovieRef) ([]uuid.UUID, error)
+}
+
+type importer struct {
+	actors  storeActorImport
+	movies  storeMovieImport
+	audit   *auditLog
+	log     *slog.Logger
+	metrics *metrics.Metrics
+}
+
+// NewImporter создает сервис импорта. Каждая созданная запись попадает в audit, как и при создании через API.
+func NewImporter(actors storeActorImport, movies storeMovieImport, audit *auditLog, log *slog.Logger, metrics *metrics.Metrics) *importer {
+	return &importer{actors: actors, movies: movies, audit: audit, log: log, metrics: metrics}
+}
+
+// Import читает файл из r и создает записи в одной транзакции. Каждая строка проверяется
+// тем же Validate(), что и запрос API; строки с ошибками пропускаются и попадают в результат.
+// Транзакция откатывается при DryRun, а при Atomic - если хотя бы одна строка с ошибкой.
+// Ошибка базы данных прерывает весь импорт.
+func (i *importer) Import(ctx context.Context, r io.Reader, opts models.ImportOptions) (*models.ImportResult, error) {
+	result := &models.ImportResult{
+		Kind:   opts.Kind,
+		DryRun: opts.DryRun,
+		Atomic: opts.Atomic,
+		Errors: []models.ImportRowError{},
+	}
+
+	// Вид и формат проверяются до начала транзакции
+	switch opts.Kind {
+	case models.ImportActors, models.ImportMovies, models.ImportCredits:
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %q, expected actors, movies or credits", ErrInvalidImport, opts.Kind)
+	}
+	if opts.Format != models.FormatCSV && opts.Format != models.FormatNDJSON {
+		return nil, fmt.Errorf("%w: unknown format %q, expected csv or ndjson", ErrInvalidImport, opts.Format)
+	}
+
+	tx, err := i.actors.BeginTransaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	// Транзакция фиксируется вручную, а не через withTransactionError: при DryRun и ошибках
+	// в строках она откатывается без ошибки. В метриках учитывается так же, как остальные.
+	defer func() {
+		if !result.Committed {
+			tx.Rollback()
+		}
+		i.metrics.TransactionFinished(result.Committed)
+	}()
+
+	row := func(line int, errs []models.ValidationError, write func() ([]models.ValidationError, error)) error {
+		result.Rows++
+		// Строки записываются и в режиме "все или ничего": так находятся ошибки во всех строках,
+		// а транзакция все равно откатывается
+		if len(errs) == 0 {
+			var err error
+			if errs, err = write(); err != nil {
+				return fmt.Errorf("row %d: %w", line, err)
+			}
+		}
+		if len(errs) > 0 {
+			result.Errors = append(result.Errors, models.ImportRowError{Row: line, Errors: errs})
+			return nil
+		}
+		result.Imported++
+		return nil
+	}
+
+	switch opts.Kind {
+	case models.ImportActors:
+		err = importRows(r, opts.Format, actorCSV, row, func(actor models.CreateActor) ([]models.ValidationError, error) {
+			return i.importActor(ctx, tx, actor)
+		})
+	case models.ImportMovies:
+		err = importRows(r, opts.Format, movieCSV, row, func(movie models.CreateMovie) ([]models.ValidationError, error) {
+			return i.importMovie(ctx, tx, movie)
+		})
+	case models.ImportCredits:
+		err = importRows(r, opts.Format, creditCSV, row, func(credit models.ImportCredit) ([]models.ValidationError, error) {
+			return i.importCredit(ctx, tx, credit)
+		})
+	}
+	if err != nil {
+		i.log.ErrorContext(ctx, "Import failed", "op", "Import", "kind", opts.Kind, "rows", result.Rows, logger.Err(err))
+		return nil, err
+	}
+
+	if !opts.DryRun && result.Imported > 0 && !(opts.Atomic && len(result.Errors) > 0) {
+		if err := tx.Commit(); err != nil {
+			i.log.ErrorContext(ctx, "Failed to commit import", "op", "Import", "kind", opts.Kind, logger.Err(err))
+			return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		result.Committed = true
+	}
+
+	i.log.InfoContext(ctx, "Import finished", "kind", opts.Kind, "rows", result.Rows, "imported", result.Imported,
+		"failed", len(result.Errors), "dry_run", opts.DryRun, "committed", result.Committed)
+	return result, nil
+}
+
+// Разбирает строки файла и передает каждую в row вместе с ошибками разбора и Validate() записи
+func importRows[T importRecord](r io.Reader, format string, schema csvSchema[T],
+	row func(line int, errs []models.ValidationError, write func() ([]models.ValidationError, error)) error,
+	write func(record T) ([]models.ValidationError, error)) error {
+	return decodeImport(r, format, schema, func(line int, record T, errs []models.ValidationError) error {
+		return row(line, withValidation(errs, record.Validate()), func() ([]models.ValidationError, error) {
+			return write(record)
+		})
+	})
+}
+
+func (i *importer) importActor(ctx context.Context, tx *sql.Tx, actor models.CreateActor) ([]models.ValidationError, error) {
+	// Актер с тем же именем и датой рождения уже есть в базе или выше в файле
+	ids, err := i.actors.FindActors(ctx, tx, models.ActorRef{Name: actor.Name, DateOfBirth: &actor.DateOfBirth})
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > 0 {
+		return []models.ValidationError{{Field: "name", Message: "Actor with this name and date of birth already exists"}}, nil
+	}
+
+	_, err = i.audit.RecordCreate(ctx, tx, models.AuditActor, func() (uuid.UUID, error) {
+		return i.actors.CreateActor(ctx, tx, actor)
+	})
+	return nil, err
+}
+
+func (i *importer) importMovie(ctx context.Context, tx *sql.Tx, movie models.CreateMovie) ([]models.ValidationError, error) {
+	var errs []models.ValidationError
+
+	ids, err := i.movies.FindMovies(ctx, tx, models.MovieRef{Title: movie.Title, ReleaseDate: &movie.ReleaseDate})
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > 0 {
+		errs = append(errs, models.ValidationError{Field: "title", Message: "Movie with this title and release date already exists"})
+	}
+
+	for _, actorID := range creditActorIDs(models.CastCredits(movie.ActorIDs)) {
+		ids, err := i.actors.FindActors(ctx, tx, models.ActorRef{ID: &actorID})
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			errs = append(errs, models.ValidationError{Field: "actor_ids", Message: "Actor not found: " + actorID.String()})
+		}
+	}
+
+	exists, err := i.movies.CheckGenresExist(ctx, movie.GenreIDs)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		errs = append(errs, models.ValidationError{Field: "genre_ids", Message: "One or more genres in the list do not exist"})
+	}
+	if len(errs) > 0 {
+		return errs, nil
+	}
+
+	_, err = i.audit.RecordCreate(ctx, tx, models.AuditMovie, func() (uuid.UUID, error) {
+		movieID, err := i.movies.CreateMovie(ctx, tx, movie)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("failed to add movie: %w", err)
+		}
+		if err := i.movies.AddMovieActorRelations(ctx, tx, movieID, models.CastCredits(movie.ActorIDs)); err != nil {
+			return uuid.Nil, fmt.Errorf("failed to add movie-actor relations: %w", err)
+		}
+		if err := i.movies.AddMovieGenreRelations(ctx, tx, movieID, movie.GenreIDs); err != nil {
+			return uuid.Nil, fmt.Errorf("failed to add movie-genre relations: %w", err)
+		}
+		return movieID, nil
+	})
+	return nil, err
+}
+
+func (i *importer) importCredit(ctx context.Context, tx *sql.Tx, credit models.ImportCredit) ([]models.ValidationError, error) {
+	var errs []models.ValidationError
+
+	movieIDs, err := i.movies.FindMovies(ctx, tx, credit.Movie())
+	if err != nil {
+		return nil, err
+	}
+	if msg := refError("Movie", movieIDs); msg != "" {
+		errs = append(errs, models.ValidationError{Field: "movie_id", Message: msg})
+	}
+
+	actorIDs, err := i.actors.FindActors(ctx, tx, credit.Actor())
+	if err != nil {
+		return nil, err
+	}
+	if msg := refError("Actor", actorIDs); msg != "" {
+		errs = append(errs, models.ValidationError{Field: "actor_id", Message: msg})
+	}
+	if len(errs) > 0 {
+		return errs, nil
+	}
+
+	input := models.CreditInput{ActorID: actorIDs[0], Role: credit.Role, Character: credit.Character, Order: credit.Order}
+	if input.Role == "" {
+		input.Role = models.RoleActor
+	}
+	// Поля ValidateCredits относятся к списку, а в файле запись одна
+	for _, err := range models.ValidateCredits([]models.CreditInput{input}) {
+		err.Field = strings.TrimPrefix(strings.TrimPrefix(err.Field, "credits[0]"), ".")
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return errs, nil
+	}
+
+	err = i.audit.Record(ctx, tx, models.AuditCreate, models.AuditMovieCredits, movieIDs[0], func() error {
+		return i.movies.AddMovieActorRelations(ctx, tx, movieIDs[0], []models.CreditInput{input})
+	})
+	return nil, err
+}
+
+// Ошибка ссылки на фильм или актера: не найдено ничего или найдено несколько записей
+func refError(entity string, ids []uuid.UUID) string {
+	switch len(ids) {
+	case 0:
+		return entity + " not found"
+	case 1:
+		return ""
+	default:
+		return entity + " reference is ambiguous, use the ID"
+	}
+}
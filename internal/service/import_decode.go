@@ -0,0 +1,311 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"cinema/internal/models"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Максимальная длина строки NDJSON
+const maxImportLine = 1 << 20
+
+// Запись файла импорта, которая проверяется так же, как тело запроса API
+type importRecord interface {
+	Validate() []models.ValidationError
+}
+
+// Колонки CSV одного вида импорта и разбор строки в запись
+type csvSchema[T any] struct {
+	columns  []string // допустимые колонки в порядке документации
+	required []string // колонки, без которых файл не принимается
+	parse    func(row *csvRow) T
+}
+
+// Разбирает файл в формате format и передает each каждую строку с данными вместе с ошибками разбора.
+// Ошибки всего файла (формат, заголовок, битые кавычки) возвращаются с ErrInvalidImport.
+func decodeImport[T any](r io.Reader, format string, schema csvSchema[T], each func(line int, record T, errs []models.ValidationError) error) error {
+	switch format {
+	case models.FormatCSV:
+		return decodeCSV(r, schema, each)
+	case models.FormatNDJSON:
+		return decodeNDJSON(r, each)
+	default:
+		return fmt.Errorf("%w: unknown format %q, expected csv or ndjson", ErrInvalidImport, format)
+	}
+}
+
+// NDJSON: по объекту в строке, поля как в теле запросов API. Пустые строки пропускаются.
+func decodeNDJSON[T any](r io.Reader, each func(line int, record T, errs []models.ValidationError) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLine)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var record T
+		var errs []models.ValidationError
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&record); err != nil {
+			errs = append(errs, models.ValidationError{Message: "Invalid JSON: " + err.Error()})
+		}
+		if err := each(line, record, errs); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w: line %d: %w", ErrInvalidImport, line+1, err)
+	}
+	return nil
+}
+
+// CSV: первая строка - заголовок с названиями колонок, порядок колонок любой
+func decodeCSV[T any](r io.Reader, schema csvSchema[T], each func(line int, record T, errs []models.ValidationError) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: missing CSV header", ErrInvalidImport)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+	columns, err := csvColumns(header, schema.columns, schema.required)
+	if err != nil {
+		return err
+	}
+
+	for {
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidImport, err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		row := &csvRow{columns: columns, values: values}
+		if len(values) != len(header) {
+			row.fail("", fmt.Sprintf("Expected %d fields, got %d", len(header), len(values)))
+		}
+		record := schema.parse(row)
+		if err := each(line, record, row.errs); err != nil {
+			return err
+		}
+	}
+}
+
+// Номера колонок по названиям из заголовка. Неизвестная колонка - скорее всего опечатка,
+// поэтому такой файл не принимается, а не импортируется без этой колонки.
+func csvColumns(header, known, required []string) (map[string]int, error) {
+	allowed := make(map[string]struct{}, len(known))
+	for _, column := range known {
+		allowed[column] = struct{}{}
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff") // BOM от Excel
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := allowed[name]; !ok {
+			return nil, fmt.Errorf("%w: unknown CSV column %q, expected some of: %s", ErrInvalidImport, name, strings.Join(known, ", "))
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("%w: duplicate CSV column %q", ErrInvalidImport, name)
+		}
+		columns[name] = i
+	}
+
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: missing CSV column %q", ErrInvalidImport, name)
+		}
+	}
+	return columns, nil
+}
+
+// Строка CSV: значения по названию колонки, ошибки разбора копятся в errs.
+// Пустое значение и отсутствующая колонка означают, что поле не задано.
+type csvRow struct {
+	columns map[string]int
+	values  []string
+	errs    []models.ValidationError
+}
+
+func (r *csvRow) fail(field, message string) {
+	r.errs = append(r.errs, models.ValidationError{Field: field, Message: message})
+}
+
+func (r *csvRow) str(column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(r.values) {
+		return ""
+	}
+	return strings.TrimSpace(r.values[i])
+}
+
+func (r *csvRow) strPtr(column string) *string {
+	value := r.str(column)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func (r *csvRow) date(column string) *time.Time {
+	raw := r.str(column)
+	if raw == "" {
+		return nil
+	}
+	value, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		r.fail(column, "Must be a date in YYYY-MM-DD format")
+		return nil
+	}
+	return &value
+}
+
+func (r *csvRow) float(column string) *float64 {
+	raw := r.str(column)
+	if raw == "" {
+		return nil
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		r.fail(column, "Must be a number")
+		return nil
+	}
+	return &value
+}
+
+func (r *csvRow) int(column string) *int {
+	raw := r.str(column)
+	if raw == "" {
+		return nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		r.fail(column, "Must be an integer")
+		return nil
+	}
+	return &value
+}
+
+func (r *csvRow) uuid(column string) *uuid.UUID {
+	raw := r.str(column)
+	if raw == "" {
+		return nil
+	}
+	value, err := uuid.Parse(raw)
+	if err != nil {
+		r.fail(column, "Must be a UUID")
+		return nil
+	}
+	return &value
+}
+
+// Список ID через запятую внутри одного поля, как в фильтрах API
+func (r *csvRow) uuids(column string) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, raw := range strings.Split(r.str(column), ",") {
+		if raw = strings.TrimSpace(raw); raw == "" {
+			continue
+		}
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			r.fail(column, "Invalid ID: "+raw)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func valueOr[T any](value *T, fallback T) T {
+	if value == nil {
+		return fallback
+	}
+	return *value
+}
+
+var actorCSV = csvSchema[models.CreateActor]{
+	columns:  []string{"name", "gender", "date_of_birth"},
+	required: []string{"name", "gender", "date_of_birth"},
+	parse: func(row *csvRow) models.CreateActor {
+		return models.CreateActor{
+			Name:        row.str("name"),
+			Gender:      row.str("gender"),
+			DateOfBirth: valueOr(row.date("date_of_birth"), time.Time{}),
+		}
+	},
+}
+
+var movieCSV = csvSchema[models.CreateMovie]{
+	columns:  []string{"title", "description", "release_date", "rating", "actor_ids", "genre_ids"},
+	required: []string{"title", "release_date", "rating"},
+	parse: func(row *csvRow) models.CreateMovie {
+		return models.CreateMovie{
+			Title:       row.str("title"),
+			Description: row.str("description"),
+			ReleaseDate: valueOr(row.date("release_date"), time.Time{}),
+			Rating:      valueOr(row.float("rating"), 0),
+			ActorIDs:    row.uuids("actor_ids"),
+			GenreIDs:    row.uuids("genre_ids"),
+		}
+	},
+}
+
+var creditCSV = csvSchema[models.ImportCredit]{
+	columns: []string{"movie_id", "movie_title", "movie_release_date", "actor_id", "actor_name", "actor_date_of_birth",
+		"role", "character", "order"},
+	parse: func(row *csvRow) models.ImportCredit {
+		return models.ImportCredit{
+			MovieID:          row.uuid("movie_id"),
+			MovieTitle:       row.str("movie_title"),
+			MovieReleaseDate: row.date("movie_release_date"),
+			ActorID:          row.uuid("actor_id"),
+			ActorName:        row.str("actor_name"),
+			ActorDateOfBirth: row.date("actor_date_of_birth"),
+			Role:             row.str("role"),
+			Character:        row.strPtr("character"),
+			Order:            row.int("order"),
+		}
+	},
+}
+
+// Ошибки разбора строки и ошибки Validate() записи. Поле с ошибкой разбора Validate() считает
+// пустым, поэтому его ошибка для этого поля отбрасывается.
+func withValidation(parseErrs, validationErrs []models.ValidationError) []models.ValidationError {
+	failed := make(map[string]struct{}, len(parseErrs))
+	for _, err := range parseErrs {
+		failed[err.Field] = struct{}{}
+	}
+
+	errs := parseErrs
+	for _, err := range validationErrs {
+		if _, ok := failed[err.Field]; !ok {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
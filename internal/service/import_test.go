@@ -0,0 +1,156 @@
+package service
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"cinema/mocks"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportActorsCSVReportsRowErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorStore := mocks.NewMockstoreActorImport(ctrl)
+	movieStore := mocks.NewMockstoreMovieImport(ctrl)
+	svc := NewImporter(actorStore, movieStore, nil, logger.Discard(), nil)
+
+	tx := testTx(t, true)
+	file := "\ufeffName,Date_of_Birth,gender\n" +
+		"Tom Hardy,1977-09-15,male\n" +
+		"Emily Blunt,15.02.1983,female\n" +
+		"Cillian Murphy,1976-05-25,unknown\n" +
+		"Tom Hardy,1977-09-15,male\n"
+	birth := time.Date(1977, 9, 15, 0, 0, 0, 0, time.UTC)
+	tomHardy := models.CreateActor{Name: "Tom Hardy", Gender: "male", DateOfBirth: birth}
+	ref := models.ActorRef{Name: "Tom Hardy", DateOfBirth: &birth}
+
+	actorStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil)
+	gomock.InOrder(
+		actorStore.EXPECT().FindActors(gomock.Any(), tx, ref).Return(nil, nil),
+		actorStore.EXPECT().CreateActor(gomock.Any(), tx, tomHardy).Return(uuid.New(), nil),
+		// Повтор строки находит актера, созданного выше в той же транзакции
+		actorStore.EXPECT().FindActors(gomock.Any(), tx, ref).Return([]uuid.UUID{uuid.New()}, nil),
+	)
+
+	result, err := svc.Import(context.Background(), strings.NewReader(file), models.ImportOptions{Kind: models.ImportActors, Format: models.FormatCSV})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result.Rows)
+	assert.Equal(t, 1, result.Imported)
+	assert.True(t, result.Committed)
+	assert.Equal(t, []models.ImportRowError{
+		{Row: 3, Errors: []models.ValidationError{{Field: "date_of_birth", Message: "Must be a date in YYYY-MM-DD format"}}},
+		{Row: 4, Errors: []models.ValidationError{{Field: "gender", Message: "Gender must be 'male', 'female', or 'other'"}}},
+		{Row: 5, Errors: []models.ValidationError{{Field: "name", Message: "Actor with this name and date of birth already exists"}}},
+	}, result.Errors)
+}
+
+func TestImportDryRunRollsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorStore := mocks.NewMockstoreActorImport(ctrl)
+	movieStore := mocks.NewMockstoreMovieImport(ctrl)
+	svc := NewImporter(actorStore, movieStore, nil, logger.Discard(), nil)
+
+	tx := testTx(t, false)
+	file := `{"name": "Tom Hardy", "gender": "male", "date_of_birth": "1977-09-15T00:00:00Z"}` + "\n\n"
+
+	actorStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil)
+	actorStore.EXPECT().FindActors(gomock.Any(), tx, gomock.Any()).Return(nil, nil)
+	actorStore.EXPECT().CreateActor(gomock.Any(), tx, gomock.Any()).Return(uuid.New(), nil)
+
+	result, err := svc.Import(context.Background(), strings.NewReader(file),
+		models.ImportOptions{Kind: models.ImportActors, Format: models.FormatNDJSON, DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Rows)
+	assert.Equal(t, 1, result.Imported)
+	assert.False(t, result.Committed)
+	assert.Empty(t, result.Errors)
+}
+
+func TestImportAtomicRollsBackOnRowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorStore := mocks.NewMockstoreActorImport(ctrl)
+	movieStore := mocks.NewMockstoreMovieImport(ctrl)
+	svc := NewImporter(actorStore, movieStore, nil, logger.Discard(), nil)
+
+	tx := testTx(t, false)
+	file := `{"name": "Tom Hardy", "gender": "male", "date_of_birth": "1977-09-15T00:00:00Z"}` + "\n" +
+		`{"name": "Emily Blunt", "gender": "female", "birthday": "1983-02-23T00:00:00Z"}` + "\n"
+
+	actorStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil)
+	actorStore.EXPECT().FindActors(gomock.Any(), tx, gomock.Any()).Return(nil, nil)
+	actorStore.EXPECT().CreateActor(gomock.Any(), tx, gomock.Any()).Return(uuid.New(), nil)
+
+	result, err := svc.Import(context.Background(), strings.NewReader(file),
+		models.ImportOptions{Kind: models.ImportActors, Format: models.FormatNDJSON, Atomic: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Rows)
+	assert.False(t, result.Committed)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, 2, result.Errors[0].Row)
+		assert.Contains(t, result.Errors[0].Errors[0].Message, "Invalid JSON")
+	}
+}
+
+func TestImportCreditsResolvesReferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorStore := mocks.NewMockstoreActorImport(ctrl)
+	movieStore := mocks.NewMockstoreMovieImport(ctrl)
+	svc := NewImporter(actorStore, movieStore, nil, logger.Discard(), nil)
+
+	tx := testTx(t, true)
+	movieID, actorID := uuid.New(), uuid.New()
+	file := "movie_title,movie_release_date,actor_id,role,character\n" +
+		"Inception,2010-07-16," + actorID.String() + ",,Eames\n" +
+		"Inception,2010-07-16," + actorID.String() + ",director,Eames\n" +
+		"Dunkirk,2017-07-21," + actorID.String() + ",actor,\n"
+	released := time.Date(2010, 7, 16, 0, 0, 0, 0, time.UTC)
+	inception := models.MovieRef{Title: "Inception", ReleaseDate: &released}
+	character := "Eames"
+
+	actorStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil)
+	movieStore.EXPECT().FindMovies(gomock.Any(), tx, inception).Return([]uuid.UUID{movieID}, nil).Times(2)
+	movieStore.EXPECT().FindMovies(gomock.Any(), tx, gomock.Not(inception)).Return(nil, nil)
+	actorStore.EXPECT().FindActors(gomock.Any(), tx, models.ActorRef{ID: &actorID}).Return([]uuid.UUID{actorID}, nil).Times(3)
+	// Роль по умолчанию - actor, как в API
+	movieStore.EXPECT().AddMovieActorRelations(gomock.Any(), tx, movieID, []models.CreditInput{
+		{ActorID: actorID, Role: models.RoleActor, Character: &character},
+	}).Return(nil)
+
+	result, err := svc.Import(context.Background(), strings.NewReader(file), models.ImportOptions{Kind: models.ImportCredits, Format: models.FormatCSV})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Imported)
+	assert.Equal(t, []models.ImportRowError{
+		{Row: 3, Errors: []models.ValidationError{{Field: "character", Message: "Character can only be set for the actor role"}}},
+		{Row: 4, Errors: []models.ValidationError{{Field: "movie_id", Message: "Movie not found"}}},
+	}, result.Errors)
+}
+
+func TestImportRejectsUnknownCSVColumn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorStore := mocks.NewMockstoreActorImport(ctrl)
+	movieStore := mocks.NewMockstoreMovieImport(ctrl)
+	svc := NewImporter(actorStore, movieStore, nil, logger.Discard(), nil)
+
+	actorStore.EXPECT().BeginTransaction(gomock.Any()).Return(testTx(t, false), nil)
+
+	_, err := svc.Import(context.Background(), strings.NewReader("title,rating,release_year\n"),
+		models.ImportOptions{Kind: models.ImportMovies, Format: models.FormatCSV})
+	assert.True(t, errors.Is(err, ErrInvalidImport))
+}
@@ -1,138 +1,165 @@
 package service
 
 import (
+	"cinema/internal/logger"
+	"cinema/internal/metrics"
 	"cinema/internal/models"
+	"cinema/internal/pagination"
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type storeMovie interface {
-	BeginTransaction() (*sql.Tx, error)
-	CheckMovieExists(movieID uuid.UUID) (bool, error)
-	CheckActorsExist(actorIDs []uuid.UUID) (bool, error)
-	AddMovieActorRelations(tx *sql.Tx, movieID uuid.UUID, actorIDs []uuid.UUID) error
-	RemoveMovieActorRelations(tx *sql.Tx, movieID uuid.UUID) error
-	RemoveSelectedMovieActorRelations(tx *sql.Tx, movieID uuid.UUID, actorIDs []uuid.UUID) error
-	CreateMovie(tx *sql.Tx, movie models.CreateMovie) (uuid.UUID, error)
-	GetMovieByID(id uuid.UUID) (map[string]interface{}, error)
-	GetMoviesByActorID(actorID uuid.UUID, limit, offset int) ([]map[string]interface{}, error)
-	GetMoviesWithFilters(sortBy string, order string, limit, offset int) ([]map[string]interface{}, error)
-	SearchMoviesByTitleAndActor(titleFragment, actorNameFragment string, limit, offset int) ([]map[string]interface{}, error)
-	UpdateMovie(tx *sql.Tx, id uuid.UUID, movie models.UpdateMovie) error
-	DeleteMovie(id uuid.UUID) error
+	BeginTransaction(ctx context.Context) (*sql.Tx, error)
+	CheckMovieExists(ctx context.Context, movieID uuid.UUID) (bool, error)
+	LockMovie(ctx context.Context, tx *sql.Tx, movieID uuid.UUID) error
+	CheckActorsExist(ctx context.Context, actorIDs []uuid.UUID) (bool, error)
+	AddMovieActorRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID, credits []models.CreditInput) error
+	RemoveMovieActorRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID) error
+	RemoveMovieCastRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID) error
+	RemoveSelectedMovieActorRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID, actorIDs []uuid.UUID) error
+	CheckGenresExist(ctx context.Context, genreIDs []uuid.UUID) (bool, error)
+	AddMovieGenreRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID, genreIDs []uuid.UUID) error
+	RemoveMovieGenreRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID) error
+	CreateMovie(ctx context.Context, tx *sql.Tx, movie models.CreateMovie) (uuid.UUID, error)
+	GetMovieByID(ctx context.Context, id uuid.UUID) (*models.Movie, error)
+	GetMovieCredits(ctx context.Context, movieID uuid.UUID) ([]models.Credit, error)
+	GetMoviesByActorID(ctx context.Context, actorID uuid.UUID, after *pagination.Cursor, limit int) ([]models.Movie, error)
+	CountMoviesByActorID(ctx context.Context, actorID uuid.UUID) (int64, error)
+	GetMoviesWithFilters(ctx context.Context, filter models.MovieFilter, sortBy string, order string, after *pagination.Cursor, limit int) ([]models.Movie, error)
+	CountMovies(ctx context.Context, filter models.MovieFilter) (int64, error)
+	SearchMovies(ctx context.Context, query string, limit, offset int) ([]models.MovieSearchResult, error)
+	UpdateMovie(ctx context.Context, tx *sql.Tx, id uuid.UUID, movie models.UpdateMovie, version *int64) error
+	GetMovieFields(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*models.MovieFields, error)
+	ReplaceMovie(ctx context.Context, tx *sql.Tx, id uuid.UUID, fields models.MovieFields, version *int64) error
+	DeleteMovie(ctx context.Context, tx *sql.Tx, id uuid.UUID, version *int64) error
 }
 
+// Фильм ссылается на несуществующих актеров или жанры
+var (
+	ErrUnknownActors = errors.New("one or more actors in the list do not exist")
+	ErrUnknownGenres = errors.New("one or more genres in the list do not exist")
+)
+
 type movie struct {
-	store storeMovie
+	store   storeMovie
+	audit   *auditLog
+	log     *slog.Logger
+	metrics *metrics.Metrics
 }
 
-func NewMovie(store storeMovie) *movie {
-	return &movie{store: store}
+// NewMovie создает сервис фильмов. Изменения записываются в audit, если он не nil.
+func NewMovie(store storeMovie, audit *auditLog, log *slog.Logger, metrics *metrics.Metrics) *movie {
+	return &movie{store: store, audit: audit, log: log, metrics: metrics}
 }
 
-// Обертка транзакция возвращающая err
-func withTransactionError(beginTx func() (*sql.Tx, error), action func(tx *sql.Tx) error) error {
-	tx, err := beginTx()
+// Обертка транзакция возвращающая err. Итог транзакции учитывается в метриках.
+func withTransactionError(ctx context.Context, beginTx func(context.Context) (*sql.Tx, error), m *metrics.Metrics, action func(tx *sql.Tx) error) error {
+	tx, err := beginTx(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to start transaction: %w", err)
 	}
 
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		}
-	}()
-
-	err = action(tx)
-	if err != nil {
+	if err := action(tx); err != nil {
 		tx.Rollback()
+		m.TransactionFinished(false)
 		return err
 	}
 
-	err = tx.Commit()
-	if err != nil {
+	if err := tx.Commit(); err != nil {
+		// Неудачный коммит откатывает транзакцию
+		m.TransactionFinished(false)
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	m.TransactionFinished(true)
 	return nil
 }
 
 // Обертка транзакция возвращающая uuid err
-func withTransactionUUID(beginTx func() (*sql.Tx, error), action func(tx *sql.Tx) (uuid.UUID, error)) (uuid.UUID, error) {
+func withTransactionUUID(ctx context.Context, beginTx func(context.Context) (*sql.Tx, error), m *metrics.Metrics, action func(tx *sql.Tx) (uuid.UUID, error)) (uuid.UUID, error) {
 	var result uuid.UUID
-
-	tx, err := beginTx()
-	if err != nil {
-		return result, fmt.Errorf("failed to start transaction: %w", err)
-	}
-
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		}
-	}()
-
-	result, err = action(tx)
-	if err != nil {
-		tx.Rollback()
-		return result, err
-	}
-
-	err = tx.Commit()
-	if err != nil {
-		return result, fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
-	return result, nil
+	err := withTransactionError(ctx, beginTx, m, func(tx *sql.Tx) error {
+		var err error
+		result, err = action(tx)
+		return err
+	})
+	return result, err
 }
 
-func (s *movie) ValidateActorIDs(actorIDs []uuid.UUID) error {
-	exists, err := s.store.CheckActorsExist(actorIDs)
+func (s *movie) ValidateActorIDs(ctx context.Context, actorIDs []uuid.UUID) error {
+	exists, err := s.store.CheckActorsExist(ctx, actorIDs)
 	if err != nil {
 		return fmt.Errorf("failed to validate actor IDs: %w", err)
 	}
 	if !exists {
-		return fmt.Errorf("one or more actors in the list do not exist")
+		return ErrUnknownActors
 	}
 	return nil
 }
 
-// Валидация movieID (проверка на существование)
-func (m *movie) ValidateMovieID(movieID uuid.UUID) error {
-	exists, err := m.store.CheckMovieExists(movieID)
+func (s *movie) ValidateGenreIDs(ctx context.Context, genreIDs []uuid.UUID) error {
+	exists, err := s.store.CheckGenresExist(ctx, genreIDs)
 	if err != nil {
-		return fmt.Errorf("failed to check movie existence: %w", err)
+		return fmt.Errorf("failed to validate genre IDs: %w", err)
 	}
 	if !exists {
-		return fmt.Errorf("movie with ID %s not found", movieID)
+		return ErrUnknownGenres
 	}
 	return nil
 }
 
-func (s *movie) AddMovieActorRelations(movieID uuid.UUID, actorIDs []uuid.UUID) error {
-	// Validate movie and actors
-	if err := s.ValidateMovieID(movieID); err != nil {
-		return err
+// Валидация movieID (проверка на существование) в транзакции tx. Фильм блокируется до ее конца,
+// поэтому его не удалят между проверкой и изменением. Фильма нет - models.ErrNotFound.
+func (m *movie) ValidateMovieID(ctx context.Context, tx *sql.Tx, movieID uuid.UUID) error {
+	if err := m.store.LockMovie(ctx, tx, movieID); err != nil {
+		return fmt.Errorf("movie with ID %s: %w", movieID, err)
 	}
-	if err := s.ValidateActorIDs(actorIDs); err != nil {
+	return nil
+}
+
+// ID людей из титров без повторов: у одного человека может быть несколько ролей
+func creditActorIDs(credits []models.CreditInput) []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{}, len(credits))
+	actorIDs := make([]uuid.UUID, 0, len(credits))
+	for _, credit := range credits {
+		if _, ok := seen[credit.ActorID]; !ok {
+			seen[credit.ActorID] = struct{}{}
+			actorIDs = append(actorIDs, credit.ActorID)
+		}
+	}
+	return actorIDs
+}
+
+func (s *movie) AddMovieActorRelations(ctx context.Context, movieID uuid.UUID, credits []models.CreditInput) error {
+	// Validate actors, the movie is checked inside the transaction
+	if err := s.ValidateActorIDs(ctx, creditActorIDs(credits)); err != nil {
 		return err
 	}
 
 	// Transaction for adding relations
-	err := withTransactionError(s.store.BeginTransaction, func(tx *sql.Tx) error {
-		err := s.store.AddMovieActorRelations(tx, movieID, actorIDs)
-		if err != nil {
-			log.Printf("Error adding movie-actor relations for movie ID %v: %v", movieID, err)
-			return fmt.Errorf("failed to add movie-actor relations: %w", err)
+	err := withTransactionError(ctx, s.store.BeginTransaction, s.metrics, func(tx *sql.Tx) error {
+		if err := s.ValidateMovieID(ctx, tx, movieID); err != nil {
+			return err
 		}
-		return nil
+		return s.audit.Record(ctx, tx, models.AuditCreate, models.AuditMovieCredits, movieID, func() error {
+			err := s.store.AddMovieActorRelations(ctx, tx, movieID, credits)
+			if err != nil {
+				s.log.ErrorContext(ctx, "Error adding movie-actor relations", "op", "AddMovieActorRelations", "movie_id", movieID, logger.Err(err))
+				return fmt.Errorf("failed to add movie-actor relations: %w", err)
+			}
+			return nil
+		})
 	})
 	if err != nil {
-		log.Printf("Transaction failed while adding movie-actor relations for movie ID %v: %v", movieID, err)
+		s.log.ErrorContext(ctx, "Transaction failed while adding movie-actor relations", "op", "AddMovieActorRelations", "movie_id", movieID, logger.Err(err))
 		return err
 	}
 
@@ -140,228 +167,386 @@ func (s *movie) AddMovieActorRelations(movieID uuid.UUID, actorIDs []uuid.UUID)
 
 }
 
-func (s *movie) UpdateMovieActorRelations(movieID uuid.UUID, actorIDs []uuid.UUID) error {
-	// Validate movie and actors
-	if err := s.ValidateMovieID(movieID); err != nil {
-		return err
-	}
-	if err := s.ValidateActorIDs(actorIDs); err != nil {
+func (s *movie) UpdateMovieActorRelations(ctx context.Context, movieID uuid.UUID, credits []models.CreditInput) error {
+	// Validate actors, the movie is checked inside the transaction
+	if err := s.ValidateActorIDs(ctx, creditActorIDs(credits)); err != nil {
 		return err
 	}
 
 	// Transaction for updating relations (remove old, add new)
-	err := withTransactionError(s.store.BeginTransaction, func(tx *sql.Tx) error {
-		// Remove old relations
-		if err := s.store.RemoveMovieActorRelations(tx, movieID); err != nil {
-			log.Printf("Error removing old movie-actor relations for movie ID %v: %v", movieID, err)
-			return fmt.Errorf("failed to remove old relations: %w", err)
+	err := withTransactionError(ctx, s.store.BeginTransaction, s.metrics, func(tx *sql.Tx) error {
+		if err := s.ValidateMovieID(ctx, tx, movieID); err != nil {
+			return err
 		}
-		// Add new relations
-		if err := s.store.AddMovieActorRelations(tx, movieID, actorIDs); err != nil {
-			log.Printf("Error adding new movie-actor relations for movie ID %v: %v", movieID, err)
-			return fmt.Errorf("failed to add new relations: %w", err)
-		}
-		return nil
+		return s.audit.Record(ctx, tx, models.AuditUpdate, models.AuditMovieCredits, movieID, func() error {
+			// Remove old relations
+			if err := s.store.RemoveMovieActorRelations(ctx, tx, movieID); err != nil {
+				s.log.ErrorContext(ctx, "Error removing old movie-actor relations", "op", "UpdateMovieActorRelations", "movie_id", movieID, logger.Err(err))
+				return fmt.Errorf("failed to remove old relations: %w", err)
+			}
+			// Add new relations
+			if err := s.store.AddMovieActorRelations(ctx, tx, movieID, credits); err != nil {
+				s.log.ErrorContext(ctx, "Error adding new movie-actor relations", "op", "UpdateMovieActorRelations", "movie_id", movieID, logger.Err(err))
+				return fmt.Errorf("failed to add new relations: %w", err)
+			}
+			return nil
+		})
 	})
 	if err != nil {
-		log.Printf("Transaction failed while updating movie-actor relations for movie ID %v: %v", movieID, err)
+		s.log.ErrorContext(ctx, "Transaction failed while updating movie-actor relations", "op", "UpdateMovieActorRelations", "movie_id", movieID, logger.Err(err))
 		return err
 	}
 	return nil
 }
 
-func (s *movie) RemoveSelectedMovieActorRelations(movieID uuid.UUID, actorIDs []uuid.UUID) error {
-	// Validate movie and actors
-	if err := s.ValidateMovieID(movieID); err != nil {
-		return err
-	}
-	if err := s.ValidateActorIDs(actorIDs); err != nil {
+func (s *movie) RemoveSelectedMovieActorRelations(ctx context.Context, movieID uuid.UUID, actorIDs []uuid.UUID) error {
+	// Validate actors, the movie is checked inside the transaction
+	if err := s.ValidateActorIDs(ctx, actorIDs); err != nil {
 		return err
 	}
 
 	// Transaction for removing specific relations
-	err := withTransactionError(s.store.BeginTransaction, func(tx *sql.Tx) error {
-		err := s.store.RemoveSelectedMovieActorRelations(tx, movieID, actorIDs)
-		if err != nil {
-			log.Printf("Error removing selected movie-actor relations for movie ID %v: %v", movieID, err)
-			return fmt.Errorf("failed to remove movie-actor relations: %w", err)
+	err := withTransactionError(ctx, s.store.BeginTransaction, s.metrics, func(tx *sql.Tx) error {
+		if err := s.ValidateMovieID(ctx, tx, movieID); err != nil {
+			return err
 		}
-		return nil
+		return s.audit.Record(ctx, tx, models.AuditDelete, models.AuditMovieCredits, movieID, func() error {
+			err := s.store.RemoveSelectedMovieActorRelations(ctx, tx, movieID, actorIDs)
+			if err != nil {
+				s.log.ErrorContext(ctx, "Error removing selected movie-actor relations", "op", "RemoveSelectedMovieActorRelations", "movie_id", movieID, logger.Err(err))
+				return fmt.Errorf("failed to remove movie-actor relations: %w", err)
+			}
+			return nil
+		})
 	})
 	if err != nil {
-		log.Printf("Transaction failed while removing selected movie-actor relations for movie ID %v: %v", movieID, err)
+		s.log.ErrorContext(ctx, "Transaction failed while removing selected movie-actor relations", "op", "RemoveSelectedMovieActorRelations", "movie_id", movieID, logger.Err(err))
 		return err
 	}
 	return nil
 }
 
-func (s *movie) CreateMovie(movie models.CreateMovie) (uuid.UUID, error) {
+func (s *movie) CreateMovie(ctx context.Context, movie models.CreateMovie) (uuid.UUID, error) {
 	// Validate actors before proceeding
-	if err := s.ValidateActorIDs(movie.ActorIDs); err != nil {
+	if err := s.ValidateActorIDs(ctx, movie.ActorIDs); err != nil {
+		return uuid.Nil, err
+	}
+	if err := s.ValidateGenreIDs(ctx, movie.GenreIDs); err != nil {
 		return uuid.Nil, err
 	}
 
 	// Transaction for adding a new movie and its relations
-	movieID, err := withTransactionUUID(s.store.BeginTransaction, func(tx *sql.Tx) (uuid.UUID, error) {
-		// Add the movie
-		movieID, err := s.store.CreateMovie(tx, movie)
-
-		if err != nil {
-			log.Printf("[CreateMovie] Failed to add movie %v: %v", movie.Title, err)
-			return uuid.Nil, fmt.Errorf("failed to add movie: %w", err)
-		}
-		// Add actor relations
-		err = s.store.AddMovieActorRelations(tx, movieID, movie.ActorIDs)
-		if err != nil {
-			log.Printf("[CreateMovie] Failed to add movie-actor relations for movie ID %v: %v", movieID, err)
-			return uuid.Nil, fmt.Errorf("failed to add movie-actor relations: %w", err)
-		}
-		return movieID, nil
+	movieID, err := withTransactionUUID(ctx, s.store.BeginTransaction, s.metrics, func(tx *sql.Tx) (uuid.UUID, error) {
+		return s.audit.RecordCreate(ctx, tx, models.AuditMovie, func() (uuid.UUID, error) {
+			return s.createMovie(ctx, tx, movie)
+		})
 	})
 
 	if err != nil {
-		log.Printf("[CreateMovie] Transaction failed for movie %v: %v", movie.Title, err)
+		s.log.ErrorContext(ctx, "Transaction failed for movie", "op", "CreateMovie", "title", movie.Title, logger.Err(err))
 		return uuid.Nil, err
 	}
 
 	return movieID, nil
 }
 
-// Получение фильма по ID
-func (s *movie) GetMovieByID(movieID uuid.UUID) (*models.Movie, error) {
-	// Вызываем репозиторий, чтобы получить сырые данные
-	rawData, err := s.store.GetMovieByID(movieID)
+// Добавление фильма и его связей в транзакции tx
+func (s *movie) createMovie(ctx context.Context, tx *sql.Tx, movie models.CreateMovie) (uuid.UUID, error) {
+	// Add the movie
+	movieID, err := s.store.CreateMovie(ctx, tx, movie)
+
+	if err != nil {
+		s.log.ErrorContext(ctx, "Failed to add movie", "op", "CreateMovie", "title", movie.Title, logger.Err(err))
+		return uuid.Nil, fmt.Errorf("failed to add movie: %w", err)
+	}
+	// Add actor relations
+	err = s.store.AddMovieActorRelations(ctx, tx, movieID, models.CastCredits(movie.ActorIDs))
+	if err != nil {
+		s.log.ErrorContext(ctx, "Failed to add movie-actor relations", "op", "CreateMovie", "movie_id", movieID, logger.Err(err))
+		return uuid.Nil, fmt.Errorf("failed to add movie-actor relations: %w", err)
+	}
+	// Add genre relations
+	err = s.store.AddMovieGenreRelations(ctx, tx, movieID, movie.GenreIDs)
+	if err != nil {
+		s.log.ErrorContext(ctx, "Failed to add movie-genre relations", "op", "CreateMovie", "movie_id", movieID, logger.Err(err))
+		return uuid.Nil, fmt.Errorf("failed to add movie-genre relations: %w", err)
+	}
+	return movieID, nil
+}
+
+// Пакетное создание фильмов. Все фильмы проверяются до начала транзакции: Validate(),
+// существование актеров и жанров. Без bestEffort при ошибке хотя бы в одном фильме не создается ни один,
+// с bestEffort создаются все фильмы без ошибок. Фильмы создаются в одной транзакции,
+// ошибка базы данных откатывает весь пакет.
+func (s *movie) CreateMovies(ctx context.Context, movies []models.CreateMovie, bestEffort bool) (*models.BatchResult, error) {
+	result := &models.BatchResult{Items: make([]models.BatchItemResult, len(movies))}
+	for i, movie := range movies {
+		errs, err := s.validateNewMovie(ctx, movie)
+		if err != nil {
+			return nil, err
+		}
+		result.Items[i] = models.BatchItemResult{Index: i, Errors: errs}
+		if len(errs) > 0 {
+			result.Failed++
+		}
+	}
+	if result.Failed == len(movies) || (result.Failed > 0 && !bestEffort) {
+		return result, nil
+	}
+
+	err := withTransactionError(ctx, s.store.BeginTransaction, s.metrics, func(tx *sql.Tx) error {
+		for i, movie := range movies {
+			if len(result.Items[i].Errors) > 0 {
+				continue
+			}
+			movieID, err := s.audit.RecordCreate(ctx, tx, models.AuditMovie, func() (uuid.UUID, error) {
+				return s.createMovie(ctx, tx, movie)
+			})
+			if err != nil {
+				return fmt.Errorf("movie %d: %w", i, err)
+			}
+			result.Items[i].ID = &movieID
+		}
+		return nil
+	})
 	if err != nil {
-		log.Printf("[GetMovieByID] Failed to retrieve movie with ID %v: %v", movieID, err)
+		s.log.ErrorContext(ctx, "Transaction failed for movie batch", "op", "CreateMovies", "size", len(movies), logger.Err(err))
 		return nil, err
 	}
-	if rawData == nil {
-		return nil, nil // Фильм не найден
+
+	result.Created = len(movies) - result.Failed
+	return result, nil
+}
+
+// Ошибки фильма из пакета: Validate() и ссылки на несуществующих актеров и жанры.
+// Ошибка базы данных возвращается отдельно.
+func (s *movie) validateNewMovie(ctx context.Context, movie models.CreateMovie) ([]models.ValidationError, error) {
+	errs := movie.Validate()
+	if len(errs) > 0 {
+		return errs, nil
 	}
 
-	// Маппим сырые данные в структуру Movie
-	movie := &models.Movie{
-		ID:          rawData["id"].(uuid.UUID),
-		Title:       rawData["title"].(string),
-		Description: rawData["description"].(string),
-		ReleaseDate: rawData["release_date"].(time.Time),
-		Rating:      rawData["rating"].(float64),
+	err := s.ValidateActorIDs(ctx, movie.ActorIDs)
+	if errors.Is(err, ErrUnknownActors) {
+		errs = append(errs, models.ValidationError{Field: "actor_ids", Message: "One or more actors in the list do not exist"})
+	} else if err != nil {
+		return nil, err
 	}
 
-	return movie, nil
+	err = s.ValidateGenreIDs(ctx, movie.GenreIDs)
+	if errors.Is(err, ErrUnknownGenres) {
+		errs = append(errs, models.ValidationError{Field: "genre_ids", Message: "One or more genres in the list do not exist"})
+	} else if err != nil {
+		return nil, err
+	}
+
+	return errs, nil
 }
 
-// Получение фильмов по ID актера с пагинацией
-func (m *movie) GetMoviesByActorID(actorID uuid.UUID, limit, offset int) ([]*models.Movie, error) {
-	rawData, err := m.store.GetMoviesByActorID(actorID, limit, offset)
+// Получение фильма по ID
+func (s *movie) GetMovieByID(ctx context.Context, movieID uuid.UUID) (*models.Movie, error) {
+	movie, err := s.store.GetMovieByID(ctx, movieID)
 	if err != nil {
-		log.Printf("[GetMoviesByActorID] Failed to retrieve movies for actor ID %v: %v", actorID, err)
+		s.log.ErrorContext(ctx, "Failed to retrieve movie", "op", "GetMovieByID", "movie_id", movieID, logger.Err(err))
 		return nil, err
 	}
-	var movies []*models.Movie
-	for _, data := range rawData {
-		movie := &models.Movie{
-			ID:          data["id"].(uuid.UUID),
-			Title:       data["title"].(string),
-			Description: data["description"].(string),
-			ReleaseDate: data["release_date"].(time.Time),
-			Rating:      data["rating"].(float64),
+	return movie, nil // nil, если фильм не найден
+}
+
+// Титры фильма, разделенные на актеров и съемочную группу. nil, если фильм не найден.
+func (m *movie) GetMovieCredits(ctx context.Context, movieID uuid.UUID) (*models.MovieCredits, error) {
+	exists, err := m.store.CheckMovieExists(ctx, movieID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check movie existence: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	rows, err := m.store.GetMovieCredits(ctx, movieID)
+	if err != nil {
+		m.log.ErrorContext(ctx, "Failed to retrieve credits", "op", "GetMovieCredits", "movie_id", movieID, logger.Err(err))
+		return nil, err
+	}
+
+	// Порядок из репозитория сохраняется внутри каждого списка
+	credits := &models.MovieCredits{Cast: []models.Credit{}, Crew: []models.Credit{}}
+	for _, credit := range rows {
+		if credit.Role == models.RoleActor {
+			credits.Cast = append(credits.Cast, credit)
+		} else {
+			credits.Crew = append(credits.Crew, credit)
 		}
-		movies = append(movies, movie)
 	}
-	return movies, nil
+
+	return credits, nil
 }
 
-// Получение фильмов с сортировкой и пагинацией
-func (m *movie) GetMoviesWithFilters(sortBy string, order string, limit, offset int) ([]*models.Movie, error) {
-	// Получаем сырые данные из репозитория
-	rawData, err := m.store.GetMoviesWithFilters(sortBy, order, limit, offset)
+// Получение фильмов по ID актера с курсорной пагинацией
+func (m *movie) GetMoviesByActorID(ctx context.Context, actorID uuid.UUID, page models.PageRequest) (*models.Page[models.Movie], error) {
+	after, err := pagination.Decode(page.Cursor, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	// Запрашиваем на одну запись больше, чтобы узнать, есть ли следующая страница
+	movies, err := m.store.GetMoviesByActorID(ctx, actorID, after, page.Limit+1)
 	if err != nil {
-		log.Printf("[GetMoviesWithFilters] Failed to fetch movies with sortBy=%s, order=%s: %v", sortBy, order, err)
+		m.log.ErrorContext(ctx, "Failed to retrieve movies", "op", "GetMoviesByActorID", "actor_id", actorID, logger.Err(err))
 		return nil, err
 	}
 
-	// Маппим сырые данные в структуры моделей
-	var movies []*models.Movie
-	for _, data := range rawData {
-		movie := &models.Movie{
-			ID:          data["id"].(uuid.UUID),
-			Title:       data["title"].(string),
-			Description: data["description"].(string),
-			ReleaseDate: data["release_date"].(time.Time),
-			Rating:      data["rating"].(float64),
+	result := &models.Page[models.Movie]{Items: movies}
+	if len(movies) > page.Limit {
+		result.Items = movies[:page.Limit]
+		last := result.Items[page.Limit-1]
+		key, null := movieSortKey(last, "release_date")
+		next := pagination.Encode(pagination.Cursor{Key: key, Null: null, ID: last.ID})
+		result.NextCursor = &next
+	}
+
+	if page.IncludeTotal {
+		total, err := m.store.CountMoviesByActorID(ctx, actorID)
+		if err != nil {
+			m.log.ErrorContext(ctx, "Failed to count movies", "op", "GetMoviesByActorID", "actor_id", actorID, logger.Err(err))
+			return nil, err
 		}
-		movies = append(movies, movie)
+		result.Total = &total
 	}
 
-	return movies, nil
+	return result, nil
 }
 
-// Поиск фильмов по названию и актеру
-func (m *movie) SearchMoviesByTitleAndActor(titleFragment string, actorNameFragment string, limit, offset int) ([]*models.Movie, error) {
-	// Получаем сырые данные из репозитория
-	rawData, err := m.store.SearchMoviesByTitleAndActor(titleFragment, actorNameFragment, limit, offset)
+// Получение фильмов по фильтру с сортировкой и курсорной пагинацией
+func (m *movie) GetMoviesWithFilters(ctx context.Context, filter models.MovieFilter, sortBy string, order string, page models.PageRequest) (*models.Page[models.Movie], error) {
+	// Курсор действителен только для той сортировки, с которой он был выдан
+	after, err := pagination.Decode(page.Cursor, sortBy, order)
+	if err != nil {
+		return nil, err
+	}
+
+	// Получаем фильмы из репозитория, на одну запись больше лимита
+	movies, err := m.store.GetMoviesWithFilters(ctx, filter, sortBy, order, after, page.Limit+1)
 	if err != nil {
-		log.Printf("[SearchMoviesByTitleAndActor] Failed to search movies by titleFragment=%q, actorNameFragment=%q: %v", titleFragment, actorNameFragment, err)
+		m.log.ErrorContext(ctx, "Failed to fetch movies", "op", "GetMoviesWithFilters", "sort_by", sortBy, "order", order, logger.Err(err))
 		return nil, err
 	}
 
-	// Маппим сырые данные в структуры моделей
-	var movies []*models.Movie
-	for _, data := range rawData {
-		movie := &models.Movie{
-			ID:          data["id"].(uuid.UUID),
-			Title:       data["title"].(string),
-			Description: data["description"].(string),
-			ReleaseDate: data["release_date"].(time.Time),
-			Rating:      data["rating"].(float64),
+	result := &models.Page[models.Movie]{Items: movies}
+	if len(movies) > page.Limit {
+		result.Items = movies[:page.Limit]
+		last := result.Items[page.Limit-1]
+		key, null := movieSortKey(last, sortBy)
+		next := pagination.Encode(pagination.Cursor{SortBy: sortBy, Order: order, Key: key, Null: null, ID: last.ID})
+		result.NextCursor = &next
+	}
+
+	if page.IncludeTotal {
+		total, err := m.store.CountMovies(ctx, filter)
+		if err != nil {
+			m.log.ErrorContext(ctx, "Failed to count movies", "op", "GetMoviesWithFilters", logger.Err(err))
+			return nil, err
 		}
-		movies = append(movies, movie)
+		result.Total = &total
 	}
 
-	return movies, nil
+	return result, nil
 }
 
-func (s *movie) UpdateMovie(movieID uuid.UUID, movie models.UpdateMovie) error {
-	// Validate movie ID
-	if err := s.ValidateMovieID(movieID); err != nil {
-		return err
+// Значение ключа сортировки фильма для курсора; null равен true, если в БД ключ NULL.
+// Дата выхода NULL читается как нулевое время.
+func movieSortKey(movie models.Movie, sortBy string) (key string, null bool) {
+	switch sortBy {
+	case "title":
+		return movie.Title, false
+	case "release_date":
+		if movie.ReleaseDate.IsZero() {
+			return "", true
+		}
+		return movie.ReleaseDate.Format(time.DateOnly), false
+	default:
+		if movie.Rating == nil {
+			return "", true
+		}
+		return strconv.FormatFloat(*movie.Rating, 'f', -1, 64), false
 	}
+}
 
-	// Transaction for updating movie and its relations
-	err := withTransactionError(s.store.BeginTransaction, func(tx *sql.Tx) error {
-		// Update the movie details
-		err := s.store.UpdateMovie(tx, movieID, movie)
-		if err != nil {
-			log.Printf("[UpdateMovie] Failed to update movie details for ID %v: %v", movieID, err)
-			return fmt.Errorf("[UpdateMovie] failed to update movie: %w", err)
+// Полнотекстовый поиск фильмов, результаты упорядочены по релевантности
+func (m *movie) SearchMovies(ctx context.Context, query string, limit, offset int) ([]models.MovieSearchResult, error) {
+	movies, err := m.store.SearchMovies(ctx, query, limit, offset)
+	if err != nil {
+		m.log.ErrorContext(ctx, "Failed to search movies", "op", "SearchMovies", "query", query, logger.Err(err))
+		return nil, err
+	}
+	return movies, nil
+}
+
+// Обновление фильма. Если version задана, фильм обновляется только в этой версии,
+// иначе возвращается models.ErrVersionMismatch. Фильма нет - models.ErrNotFound.
+// Существование проверяет само обновление в транзакции, до изменения связей.
+func (s *movie) UpdateMovie(ctx context.Context, movieID uuid.UUID, movie models.UpdateMovie, version *int64) error {
+	if movie.GenreIDs != nil {
+		if err := s.ValidateGenreIDs(ctx, *movie.GenreIDs); err != nil {
+			return err
 		}
+	}
 
-		// Update actor relations if provided
-		if movie.ActorIDs != nil {
-			err = s.store.RemoveMovieActorRelations(tx, movieID)
+	// Transaction for updating movie and its relations
+	err := withTransactionError(ctx, s.store.BeginTransaction, s.metrics, func(tx *sql.Tx) error {
+		return s.audit.Record(ctx, tx, models.AuditUpdate, models.AuditMovie, movieID, func() error {
+			// Update the movie details
+			err := s.store.UpdateMovie(ctx, tx, movieID, movie, version)
 			if err != nil {
-				log.Printf("[UpdateMovie] Failed to remove old relations for movie ID %v: %v", movieID, err)
-				return fmt.Errorf("[UpdateMovie] failed to remove old relations: %w", err)
+				s.log.ErrorContext(ctx, "Failed to update movie details", "op", "UpdateMovie", "movie_id", movieID, logger.Err(err))
+				return fmt.Errorf("[UpdateMovie] failed to update movie: %w", err)
 			}
 
-			err = s.store.AddMovieActorRelations(tx, movieID, *movie.ActorIDs)
-			if err != nil {
-				log.Printf("[UpdateMovie] Failed to add new relations for movie ID %v: %v", movieID, err)
-				return fmt.Errorf("[UpdateMovie] failed to add new relations: %w", err)
+			// actor_ids заменяет только актерский состав, съемочная группа остается
+			if movie.ActorIDs != nil {
+				err = s.store.RemoveMovieCastRelations(ctx, tx, movieID)
+				if err != nil {
+					s.log.ErrorContext(ctx, "Failed to remove old cast", "op", "UpdateMovie", "movie_id", movieID, logger.Err(err))
+					return fmt.Errorf("[UpdateMovie] failed to remove old cast: %w", err)
+				}
+
+				err = s.store.AddMovieActorRelations(ctx, tx, movieID, models.CastCredits(*movie.ActorIDs))
+				if err != nil {
+					s.log.ErrorContext(ctx, "Failed to add new relations", "op", "UpdateMovie", "movie_id", movieID, logger.Err(err))
+					return fmt.Errorf("[UpdateMovie] failed to add new relations: %w", err)
+				}
 			}
-		}
-		return nil
+
+			// Replace genres if provided
+			if movie.GenreIDs != nil {
+				err = s.store.RemoveMovieGenreRelations(ctx, tx, movieID)
+				if err != nil {
+					s.log.ErrorContext(ctx, "Failed to remove old genres", "op", "UpdateMovie", "movie_id", movieID, logger.Err(err))
+					return fmt.Errorf("[UpdateMovie] failed to remove old genres: %w", err)
+				}
+
+				err = s.store.AddMovieGenreRelations(ctx, tx, movieID, *movie.GenreIDs)
+				if err != nil {
+					s.log.ErrorContext(ctx, "Failed to add new genres", "op", "UpdateMovie", "movie_id", movieID, logger.Err(err))
+					return fmt.Errorf("[UpdateMovie] failed to add new genres: %w", err)
+				}
+			}
+			return nil
+		})
 	})
 
 	if err != nil {
-		log.Printf("[UpdateMovie] Transaction failed for movie ID %v: %v", movieID, err)
+		s.log.ErrorContext(ctx, "Transaction failed", "op", "UpdateMovie", "movie_id", movieID, logger.Err(err))
 		return err
 	}
 	return nil
 }
 
-// Удаление фильма по ID
-func (m *movie) DeleteMovie(movieID uuid.UUID) error {
-	return m.store.DeleteMovie(movieID)
+// Удаление фильма по ID, с проверкой версии, если она задана
+func (m *movie) DeleteMovie(ctx context.Context, movieID uuid.UUID, version *int64) error {
+	return withTransactionError(ctx, m.store.BeginTransaction, m.metrics, func(tx *sql.Tx) error {
+		return m.audit.Record(ctx, tx, models.AuditDelete, models.AuditMovie, movieID, func() error {
+			return m.store.DeleteMovie(ctx, tx, movieID, version)
+		})
+	})
 }
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"cinema/internal/pagination"
+	"cinema/mocks"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMovieCreditsSplitsCastAndCrew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockstoreMovie(ctrl)
+
+	movieID := uuid.New()
+	character := "Cobb"
+	first := 0
+	newCredit := func(name, role string, character *string, order *int) models.Credit {
+		return models.Credit{ActorID: uuid.New(), Name: name, Role: role, Character: character, Order: order}
+	}
+	rows := []models.Credit{
+		newCredit("Leonardo DiCaprio", models.RoleActor, &character, &first),
+		newCredit("Christopher Nolan", models.RoleDirector, nil, &first),
+		newCredit("Hans Zimmer", models.RoleComposer, nil, nil),
+	}
+
+	mockStore.EXPECT().CheckMovieExists(gomock.Any(), movieID).Return(true, nil)
+	mockStore.EXPECT().GetMovieCredits(gomock.Any(), movieID).Return(rows, nil)
+
+	credits, err := NewMovie(mockStore, nil, logger.Discard(), nil).GetMovieCredits(context.Background(), movieID)
+	assert.NoError(t, err)
+	assert.Len(t, credits.Cast, 1)
+	assert.Equal(t, "Cobb", *credits.Cast[0].Character)
+	assert.Len(t, credits.Crew, 2)
+	assert.Equal(t, "Christopher Nolan", credits.Crew[0].Name)
+	assert.Nil(t, credits.Crew[1].Order)
+}
+
+func TestGetMovieCreditsMovieNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockstoreMovie(ctrl)
+
+	movieID := uuid.New()
+	mockStore.EXPECT().CheckMovieExists(gomock.Any(), movieID).Return(false, nil)
+
+	credits, err := NewMovie(mockStore, nil, logger.Discard(), nil).GetMovieCredits(context.Background(), movieID)
+	assert.NoError(t, err)
+	assert.Nil(t, credits)
+}
+
+func TestGetMoviesWithFiltersCursorOnNullRating(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockstoreMovie(ctrl)
+	rating := 8.8
+	movies := []models.Movie{{ID: uuid.New(), Rating: &rating}, {ID: uuid.New()}, {ID: uuid.New()}}
+	mockStore.EXPECT().GetMoviesWithFilters(gomock.Any(), gomock.Any(), "rating", "DESC", nil, 3).Return(movies, nil)
+
+	page, err := NewMovie(mockStore, nil, logger.Discard(), nil).GetMoviesWithFilters(context.Background(), models.MovieFilter{}, "rating", "DESC", models.PageRequest{Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 2)
+
+	// Последний фильм страницы без рейтинга: курсор помечен как NULL, а не рейтинг 0
+	cursor, err := pagination.Decode(*page.NextCursor, "rating", "DESC")
+	assert.NoError(t, err)
+	assert.True(t, cursor.Null)
+	assert.Equal(t, movies[1].ID, cursor.ID)
+}
+
+func TestUpdateMovieActorIDsKeepsCrew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockstoreMovie(ctrl)
+	tx := testTx(t, true)
+	movieID := uuid.New()
+	actorIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	update := models.UpdateMovie{ActorIDs: &actorIDs}
+
+	// actor_ids заменяет только актерский состав: RemoveMovieActorRelations, удаляющий
+	// и съемочную группу, не вызывается
+	gomock.InOrder(
+		mockStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil),
+		mockStore.EXPECT().UpdateMovie(gomock.Any(), tx, movieID, update, nil).Return(nil),
+		mockStore.EXPECT().RemoveMovieCastRelations(gomock.Any(), tx, movieID).Return(nil),
+		mockStore.EXPECT().AddMovieActorRelations(gomock.Any(), tx, movieID, models.CastCredits(actorIDs)).Return(nil),
+	)
+
+	err := NewMovie(mockStore, nil, logger.Discard(), nil).UpdateMovie(context.Background(), movieID, update, nil)
+	assert.NoError(t, err)
+}
+
+func TestUpdateMovieNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockstoreMovie(ctrl)
+	tx := testTx(t, false)
+	movieID := uuid.New()
+	actorIDs := []uuid.UUID{uuid.New()}
+	update := models.UpdateMovie{ActorIDs: &actorIDs}
+
+	// Существование проверяет само обновление в транзакции, связи после него не трогаются
+	gomock.InOrder(
+		mockStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil),
+		mockStore.EXPECT().UpdateMovie(gomock.Any(), tx, movieID, update, nil).Return(models.ErrNotFound),
+	)
+
+	err := NewMovie(mockStore, nil, logger.Discard(), nil).UpdateMovie(context.Background(), movieID, update, nil)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+}
+
+func TestAddMovieActorRelationsMovieNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockstoreMovie(ctrl)
+	tx := testTx(t, false)
+	movieID := uuid.New()
+	credits := models.CastCredits([]uuid.UUID{uuid.New()})
+
+	// Фильм проверяется и блокируется в той же транзакции, что и добавление титров
+	gomock.InOrder(
+		mockStore.EXPECT().CheckActorsExist(gomock.Any(), []uuid.UUID{credits[0].ActorID}).Return(true, nil),
+		mockStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil),
+		mockStore.EXPECT().LockMovie(gomock.Any(), tx, movieID).Return(models.ErrNotFound),
+	)
+
+	err := NewMovie(mockStore, nil, logger.Discard(), nil).AddMovieActorRelations(context.Background(), movieID, credits)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+}
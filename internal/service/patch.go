@@ -0,0 +1,204 @@
+package service
+
+import (
+	"bytes"
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Патч не является JSON-объектом или после применения содержит поля,
+// которых нет у записи, либо значения не того типа
+var ErrInvalidPatch = errors.New("invalid merge patch")
+
+// Изменение фильма патчем JSON Merge Patch (RFC 7396): отсутствующие в патче поля не меняются,
+// null очищает поле, массив genre_ids заменяется целиком. Результат проверяется как новый фильм.
+// Возвращает измененный фильм или nil, если фильма нет. Ошибки проверки возвращаются как models.ValidationErrors.
+func (s *movie) PatchMovie(ctx context.Context, movieID uuid.UUID, patch []byte, version *int64) (*models.Movie, error) {
+	if err := checkMergePatch(patch); err != nil {
+		return nil, err
+	}
+
+	found := false
+	err := withTransactionError(ctx, s.store.BeginTransaction, s.metrics, func(tx *sql.Tx) error {
+		current, err := s.store.GetMovieFields(ctx, tx, movieID)
+		if err != nil || current == nil {
+			return err
+		}
+		found = true
+
+		var fields models.MovieFields
+		if err := applyMergePatch(current, patch, &fields); err != nil {
+			return err
+		}
+		if errs := fields.Validate(); len(errs) > 0 {
+			return models.ValidationErrors(errs)
+		}
+		if err := s.ValidateGenreIDs(ctx, fields.GenreIDs); errors.Is(err, ErrUnknownGenres) {
+			return models.ValidationErrors{{Field: "genre_ids", Message: "One or more genres in the list do not exist"}}
+		} else if err != nil {
+			return err
+		}
+
+		return s.audit.Record(ctx, tx, models.AuditUpdate, models.AuditMovie, movieID, func() error {
+			if err := s.store.ReplaceMovie(ctx, tx, movieID, fields, version); err != nil {
+				return fmt.Errorf("failed to update movie: %w", err)
+			}
+			if sameIDs(current.GenreIDs, fields.GenreIDs) {
+				return nil
+			}
+			if err := s.store.RemoveMovieGenreRelations(ctx, tx, movieID); err != nil {
+				return fmt.Errorf("failed to remove old genres: %w", err)
+			}
+			if err := s.store.AddMovieGenreRelations(ctx, tx, movieID, fields.GenreIDs); err != nil {
+				return fmt.Errorf("failed to add new genres: %w", err)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		if !isPatchRejected(err) {
+			s.log.ErrorContext(ctx, "Transaction failed", "op", "PatchMovie", "movie_id", movieID, logger.Err(err))
+		}
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return s.store.GetMovieByID(ctx, movieID)
+}
+
+// Изменение актера патчем JSON Merge Patch, см. PatchMovie. Пол и дату рождения можно очистить через null.
+func (a *actor) PatchActor(ctx context.Context, id uuid.UUID, patch []byte, version *int64) (*models.Actor, error) {
+	if err := checkMergePatch(patch); err != nil {
+		return nil, err
+	}
+
+	found := false
+	err := withTransactionError(ctx, a.store.BeginTransaction, a.metrics, func(tx *sql.Tx) error {
+		current, err := a.store.GetActorFields(ctx, tx, id)
+		if err != nil || current == nil {
+			return err
+		}
+		found = true
+
+		var fields models.ActorFields
+		if err := applyMergePatch(current, patch, &fields); err != nil {
+			return err
+		}
+		if errs := fields.Validate(); len(errs) > 0 {
+			return models.ValidationErrors(errs)
+		}
+
+		return a.audit.Record(ctx, tx, models.AuditUpdate, models.AuditActor, id, func() error {
+			return a.store.ReplaceActor(ctx, tx, id, fields, version)
+		})
+	})
+	if err != nil {
+		if !isPatchRejected(err) {
+			a.log.ErrorContext(ctx, "Transaction failed", "op", "PatchActor", "actor_id", id, logger.Err(err))
+		}
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return a.store.GetActor(ctx, id)
+}
+
+// Патч к записи - всегда JSON-объект: другие значения по RFC 7396 заменили бы запись целиком
+func checkMergePatch(patch []byte) error {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &object); err != nil || object == nil {
+		return fmt.Errorf("%w: patch must be a JSON object", ErrInvalidPatch)
+	}
+	return nil
+}
+
+// Применяет патч к JSON-представлению current и разбирает результат в dst.
+// Поля, которых нет в dst, например id или version, считаются ошибкой патча.
+func applyMergePatch(current any, patch []byte, dst any) error {
+	document, err := json.Marshal(current)
+	if err != nil {
+		return fmt.Errorf("failed to encode current state: %w", err)
+	}
+
+	var target, changes any
+	if err := decodeJSON(document, &target); err != nil {
+		return fmt.Errorf("failed to decode current state: %w", err)
+	}
+	if err := decodeJSON(patch, &changes); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	merged, err := json.Marshal(mergePatch(target, changes))
+	if err != nil {
+		return fmt.Errorf("failed to encode patched state: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return nil
+}
+
+// Числа читаются как json.Number, чтобы не терять точность при повторной сериализации
+func decodeJSON(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// Алгоритм MergePatch из RFC 7396, раздел 2
+func mergePatch(target, patch any) any {
+	changes, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	result, ok := target.(map[string]any)
+	if !ok {
+		result = map[string]any{}
+	}
+	for name, value := range changes {
+		if value == nil {
+			delete(result, name)
+			continue
+		}
+		result[name] = mergePatch(result[name], value)
+	}
+	return result
+}
+
+// Ошибки, которые означают отклоненный патч, а не сбой
+func isPatchRejected(err error) bool {
+	var invalid models.ValidationErrors
+	return errors.As(err, &invalid) || errors.Is(err, ErrInvalidPatch) ||
+		errors.Is(err, models.ErrVersionMismatch) || errors.Is(err, models.ErrNotFound)
+}
+
+// Совпадают ли списки ID без учета порядка и повторов
+func sameIDs(a, b []uuid.UUID) bool {
+	set := make(map[uuid.UUID]bool, len(a))
+	for _, id := range a {
+		set[id] = false
+	}
+	for _, id := range b {
+		if _, ok := set[id]; !ok {
+			return false
+		}
+		set[id] = true
+	}
+	for _, seen := range set {
+		if !seen {
+			return false
+		}
+	}
+	return true
+}
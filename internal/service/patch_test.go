@@ -0,0 +1,114 @@
+package service
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"cinema/mocks"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePatch(t *testing.T) {
+	// Примеры из приложения A RFC 7396
+	tests := []struct {
+		target, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, tt := range tests {
+		var target, patch, want any
+		assert.NoError(t, decodeJSON([]byte(tt.target), &target))
+		assert.NoError(t, decodeJSON([]byte(tt.patch), &patch))
+		assert.NoError(t, decodeJSON([]byte(tt.want), &want))
+		assert.Equal(t, want, mergePatch(target, patch), "%s + %s", tt.target, tt.patch)
+	}
+}
+
+func TestPatchMovieClearsDescription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockstoreMovie(ctrl)
+	tx := testTx(t, true)
+	movieID, genreID := uuid.New(), uuid.New()
+	description := "A thief who steals corporate secrets"
+	rating := 8.8
+	current := &models.MovieFields{
+		Title:       "Inception",
+		Description: &description,
+		ReleaseDate: time.Date(2010, 7, 16, 0, 0, 0, 0, time.UTC),
+		Rating:      &rating,
+		GenreIDs:    []uuid.UUID{genreID},
+	}
+	patched := *current
+	patched.Description = nil
+	patched.Title = "Inception (2010)"
+	updated := &models.Movie{ID: movieID, Title: patched.Title, Version: 2}
+
+	// Жанры не изменились, поэтому связи не пересоздаются
+	gomock.InOrder(
+		mockStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil),
+		mockStore.EXPECT().GetMovieFields(gomock.Any(), tx, movieID).Return(current, nil),
+		mockStore.EXPECT().CheckGenresExist(gomock.Any(), []uuid.UUID{genreID}).Return(true, nil),
+		mockStore.EXPECT().ReplaceMovie(gomock.Any(), tx, movieID, patched, nil).Return(nil),
+		mockStore.EXPECT().GetMovieByID(gomock.Any(), movieID).Return(updated, nil),
+	)
+
+	patch := []byte(`{"title": "Inception (2010)", "description": null}`)
+	movie, err := NewMovie(mockStore, nil, logger.Discard(), nil).PatchMovie(context.Background(), movieID, patch, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, updated, movie)
+}
+
+func TestPatchMovieValidatesResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockstoreMovie(ctrl)
+	tx := testTx(t, false)
+	movieID := uuid.New()
+	current := &models.MovieFields{Title: "Inception", ReleaseDate: time.Date(2010, 7, 16, 0, 0, 0, 0, time.UTC)}
+
+	mockStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil)
+	mockStore.EXPECT().GetMovieFields(gomock.Any(), tx, movieID).Return(current, nil)
+
+	// Название обязательно, null его не очищает, а делает результат неверным
+	_, err := NewMovie(mockStore, nil, logger.Discard(), nil).PatchMovie(context.Background(), movieID, []byte(`{"title": null}`), nil)
+	var validationErrors models.ValidationErrors
+	assert.ErrorAs(t, err, &validationErrors)
+	assert.Equal(t, "title", validationErrors[0].Field)
+}
+
+func TestPatchActorRejectsReadOnlyFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockstoreActor(ctrl)
+	svc := NewActor(mockStore, nil, logger.Discard(), nil)
+	tx := testTx(t, false)
+	actorID := uuid.New()
+	gender := "male"
+
+	mockStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil)
+	mockStore.EXPECT().GetActorFields(gomock.Any(), tx, actorID).Return(&models.ActorFields{Name: "Tom Hardy", Gender: &gender}, nil)
+
+	_, err := svc.PatchActor(context.Background(), actorID, []byte(`{"version": 7}`), nil)
+	assert.ErrorIs(t, err, ErrInvalidPatch)
+
+	// Патч не объект - ошибка до начала транзакции
+	_, err = svc.PatchActor(context.Background(), actorID, []byte(`["name"]`), nil)
+	assert.ErrorIs(t, err, ErrInvalidPatch)
+}
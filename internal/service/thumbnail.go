@@ -0,0 +1,203 @@
+package service
+
+import (
+	"bytes"
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"cinema/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"log/slog"
+	"math"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // декодер WebP для image.Decode
+)
+
+// Изображения с большим числом пикселей отклоняются до декодирования:
+// маленький файл может распаковаться в гигабайты памяти
+const maxImagePixels = 40_000_000
+
+// Качество JPEG уменьшенных копий
+const thumbnailQuality = 85
+
+var ErrUnknownImageSize = errors.New("unknown image size")
+
+// Файлы изображений в хранилище вместе с уменьшенными копиями заданных ширин.
+// Копия хранится рядом с оригиналом: posters/<id>/<name>.jpg -> posters/<id>/<name>_185.jpg.
+// Копии PNG сохраняются в PNG, чтобы не терять прозрачность, остальные - в JPEG.
+type imageFiles struct {
+	blobs  storeBlob
+	widths []int
+	log    *slog.Logger
+}
+
+// NewImageFiles создает файлы изображений с копиями ширин widths. Копии ширин, которые потом
+// убрали из конфигурации, при удалении оригинала остаются в хранилище.
+func NewImageFiles(blobs storeBlob, widths []int, log *slog.Logger) *imageFiles {
+	return &imageFiles{blobs: blobs, widths: widths, log: log}
+}
+
+// Сохраняет оригинал и его уменьшенные копии. Копии шире оригинала не создаются:
+// за ними отдается сам оригинал. Если сохранить что-то не удалось, записанные файлы удаляются.
+func (f *imageFiles) put(ctx context.Context, key string, data []byte) error {
+	thumbnails, err := f.thumbnails(key, data)
+	if err != nil {
+		return err
+	}
+
+	written := make([]string, 0, len(thumbnails)+1)
+	store := func(key string, data []byte) error {
+		if err := f.blobs.Put(ctx, key, bytes.NewReader(data)); err != nil {
+			f.delete(ctx, written)
+			return fmt.Errorf("failed to store image: %w", err)
+		}
+		written = append(written, key)
+		return nil
+	}
+
+	// Оригинал записывается последним: пока он не сохранен, на копии ничего не ссылается
+	for _, width := range f.widths {
+		if thumbnail, ok := thumbnails[width]; ok {
+			if err := store(thumbnailKey(key, width), thumbnail); err != nil {
+				return err
+			}
+		}
+	}
+	return store(key, data)
+}
+
+// Уменьшенные копии по ширинам
+func (f *imageFiles) thumbnails(key string, data []byte) (map[int][]byte, error) {
+	if len(f.widths) == 0 {
+		return nil, nil
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, fmt.Errorf("%w: %dx%d pixels", ErrImageTooLarge, config.Width, config.Height)
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+
+	asPNG := path.Ext(key) == ".png"
+	thumbnails := make(map[int][]byte, len(f.widths))
+	for _, width := range f.widths {
+		if width >= src.Bounds().Dx() {
+			continue
+		}
+		var buf bytes.Buffer
+		if asPNG {
+			err = png.Encode(&buf, resize(src, width, false))
+		} else {
+			err = jpeg.Encode(&buf, resize(src, width, true), &jpeg.Options{Quality: thumbnailQuality})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+		}
+		thumbnails[width] = buf.Bytes()
+	}
+	return thumbnails, nil
+}
+
+// Открывает оригинал (width равен 0) или копию заданной ширины. Если копии нет,
+// например оригинал не шире ее, отдается оригинал. Nil, если нет и оригинала.
+func (f *imageFiles) open(ctx context.Context, key string, width int) (*models.Image, error) {
+	if width != 0 {
+		if !slices.Contains(f.widths, width) {
+			return nil, fmt.Errorf("%w, must be one of %s", ErrUnknownImageSize, joinInts(f.widths))
+		}
+		file, err := f.openFile(ctx, thumbnailKey(key, width))
+		if file != nil || err != nil {
+			return file, err
+		}
+	}
+
+	file, err := f.openFile(ctx, key)
+	if file == nil && err == nil {
+		f.log.WarnContext(ctx, "Image file is missing", "key", key)
+	}
+	return file, err
+}
+
+func (f *imageFiles) openFile(ctx context.Context, key string) (*models.Image, error) {
+	body, err := f.blobs.Open(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image: %w", err)
+	}
+	name := path.Base(key)
+	return &models.Image{
+		Body:        body,
+		ContentType: imageContentType(name),
+		ETag:        strconv.Quote(strings.TrimSuffix(name, path.Ext(name))),
+	}, nil
+}
+
+// Удаляет файлы вместе с копиями. Ошибка только пишется в лог: запись уже изменена,
+// и оставшийся файл ни на что не влияет, кроме места на диске. Nil ничего не удаляет.
+func (f *imageFiles) delete(ctx context.Context, keys []string) {
+	if f == nil {
+		return
+	}
+	for _, key := range keys {
+		for _, width := range f.widths {
+			f.deleteFile(ctx, thumbnailKey(key, width))
+		}
+		f.deleteFile(ctx, key)
+	}
+}
+
+func (f *imageFiles) deleteFile(ctx context.Context, key string) {
+	if err := f.blobs.Delete(ctx, key); err != nil {
+		f.log.WarnContext(ctx, "Failed to delete image", "key", key, logger.Err(err))
+	}
+}
+
+// Ключ копии ширины width рядом с оригиналом
+func thumbnailKey(key string, width int) string {
+	ext := ".jpg"
+	if path.Ext(key) == ".png" {
+		ext = ".png"
+	}
+	return strings.TrimSuffix(key, path.Ext(key)) + "_" + strconv.Itoa(width) + ext
+}
+
+// Уменьшает изображение до ширины width с сохранением пропорций.
+// Для JPEG прозрачные области заливаются белым, иначе они станут черными.
+func resize(src image.Image, width int, opaque bool) image.Image {
+	bounds := src.Bounds()
+	height := max(1, int(math.Round(float64(bounds.Dy())*float64(width)/float64(bounds.Dx()))))
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	op := draw.Src
+	if opaque {
+		draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+		op = draw.Over
+	}
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, op, nil)
+	return dst
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		parts = append(parts, strconv.Itoa(value))
+	}
+	return strings.Join(parts, ", ")
+}
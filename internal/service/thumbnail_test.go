@@ -0,0 +1,95 @@
+package service
+
+import (
+	"bytes"
+	"cinema/internal/logger"
+	"cinema/internal/storage"
+	"cinema/mocks"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func testImage(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, height/2, color.NRGBA{R: 200, A: 255})
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestImageFilesPutCreatesThumbnails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blobs := mocks.NewMockstoreBlob(ctrl)
+	files := NewImageFiles(blobs, []int{92, 185, 500}, logger.Discard())
+	data := testImage(t, 300, 450)
+
+	stored := map[string][]byte{}
+	blobs.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Times(3).DoAndReturn(func(_ context.Context, key string, r io.Reader) error {
+		body, err := io.ReadAll(r)
+		stored[key] = body
+		return err
+	})
+
+	assert.NoError(t, files.put(context.Background(), "posters/m/a.png", data))
+	// Копия 500 шире оригинала и не создается
+	assert.Len(t, stored, 3)
+	assert.Equal(t, data, stored["posters/m/a.png"])
+	for key, size := range map[string]image.Point{"posters/m/a_92.png": {92, 138}, "posters/m/a_185.png": {185, 278}} {
+		config, format, err := image.DecodeConfig(bytes.NewReader(stored[key]))
+		assert.NoError(t, err, key)
+		assert.Equal(t, "png", format)
+		assert.Equal(t, size, image.Point{config.Width, config.Height}, key)
+	}
+}
+
+func TestImageFilesPutRejectsCorruptImage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Сигнатура PNG без содержимого проходит проверку типа, но не декодируется
+	files := NewImageFiles(mocks.NewMockstoreBlob(ctrl), []int{92}, logger.Discard())
+	err := files.put(context.Background(), "posters/m/a.png", testPNG)
+	assert.ErrorIs(t, err, ErrUnsupportedImage)
+}
+
+func TestImageFilesOpenThumbnail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blobs := mocks.NewMockstoreBlob(ctrl)
+	files := NewImageFiles(blobs, []int{92, 185}, logger.Discard())
+	ctx := context.Background()
+
+	blobs.EXPECT().Open(gomock.Any(), "posters/m/a_92.jpg").Return(io.NopCloser(strings.NewReader("small")), nil)
+	file, err := files.open(ctx, "posters/m/a.webp", 92)
+	assert.NoError(t, err)
+	assert.Equal(t, "image/jpeg", file.ContentType)
+	assert.Equal(t, `"a_92"`, file.ETag)
+
+	// Оригинал не шире 185, поэтому копии нет и отдается он сам
+	gomock.InOrder(
+		blobs.EXPECT().Open(gomock.Any(), "posters/m/a_185.jpg").Return(nil, storage.ErrNotFound),
+		blobs.EXPECT().Open(gomock.Any(), "posters/m/a.webp").Return(io.NopCloser(strings.NewReader("original")), nil),
+	)
+	file, err = files.open(ctx, "posters/m/a.webp", 185)
+	assert.NoError(t, err)
+	assert.Equal(t, "image/webp", file.ContentType)
+	assert.Equal(t, `"a"`, file.ETag)
+
+	_, err = files.open(ctx, "posters/m/a.webp", 300)
+	assert.ErrorIs(t, err, ErrUnknownImageSize)
+	assert.ErrorContains(t, err, "92, 185")
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"cinema/internal/logger"
+	"cinema/internal/metrics"
+	"cinema/internal/models"
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type storeMovieTrash interface {
+	BeginTransaction(ctx context.Context) (*sql.Tx, error)
+	GetDeletedMovies(ctx context.Context, limit, offset int) ([]models.TrashedMovie, error)
+	RestoreMovie(ctx context.Context, tx *sql.Tx, id uuid.UUID) (bool, error)
+	PurgeMovies(ctx context.Context, before time.Time) (int64, []string, error)
+}
+
+type storeActorTrash interface {
+	BeginTransaction(ctx context.Context) (*sql.Tx, error)
+	GetDeletedActors(ctx context.Context, limit, offset int) ([]models.TrashedActor, error)
+	RestoreActor(ctx context.Context, tx *sql.Tx, id uuid.UUID) (bool, error)
+	PurgeActors(ctx context.Context, before time.Time) (int64, []string, error)
+}
+
+// Корзина удаленных фильмов и актеров
+type trash struct {
+	movies    storeMovieTrash
+	actors    storeActorTrash
+	files     *imageFiles
+	audit     *auditLog
+	retention time.Duration
+	log       *slog.Logger
+	metrics   *metrics.Metrics
+	now       func() time.Time
+}
+
+// NewTrash создает сервис корзины. Записи хранятся в корзине retention, затем Purge удаляет их окончательно.
+// Восстановление записывается в audit, если он не nil; окончательное удаление - системное и в журнал не попадает.
+// Вместе с записями из files удаляются их постеры и фотографии.
+func NewTrash(movies storeMovieTrash, actors storeActorTrash, files *imageFiles, audit *auditLog, retention time.Duration, log *slog.Logger, metrics *metrics.Metrics) *trash {
+	return &trash{movies: movies, actors: actors, files: files, audit: audit, retention: retention, log: log, metrics: metrics, now: time.Now}
+}
+
+func (t *trash) GetDeletedMovies(ctx context.Context, limit, offset int) ([]models.TrashedMovie, error) {
+	return t.movies.GetDeletedMovies(ctx, limit, offset)
+}
+
+func (t *trash) GetDeletedActors(ctx context.Context, limit, offset int) ([]models.TrashedActor, error) {
+	return t.actors.GetDeletedActors(ctx, limit, offset)
+}
+
+// Восстановление фильма вместе со связями. false, если фильма нет в корзине.
+func (t *trash) RestoreMovie(ctx context.Context, id uuid.UUID) (bool, error) {
+	var restored bool
+	err := withTransactionError(ctx, t.movies.BeginTransaction, t.metrics, func(tx *sql.Tx) error {
+		return t.audit.Record(ctx, tx, models.AuditRestore, models.AuditMovie, id, func() error {
+			var err error
+			restored, err = t.movies.RestoreMovie(ctx, tx, id)
+			return err
+		})
+	})
+	return restored, err
+}
+
+// Восстановление актера вместе с титрами. false, если актера нет в корзине.
+func (t *trash) RestoreActor(ctx context.Context, id uuid.UUID) (bool, error) {
+	var restored bool
+	err := withTransactionError(ctx, t.actors.BeginTransaction, t.metrics, func(tx *sql.Tx) error {
+		return t.audit.Record(ctx, tx, models.AuditRestore, models.AuditActor, id, func() error {
+			var err error
+			restored, err = t.actors.RestoreActor(ctx, tx, id)
+			return err
+		})
+	})
+	return restored, err
+}
+
+// Окончательное удаление записей, пролежавших в корзине дольше срока хранения.
+// Сначала удаляются фильмы, затем актеры; ошибка по фильмам не мешает очистить актеров.
+// Файлы изображений удаляются после записей: если файл удалить не удалось, он остается на диске,
+// но на него уже ничего не ссылается.
+func (t *trash) Purge(ctx context.Context) (models.PurgeResult, error) {
+	before := t.now().Add(-t.retention)
+
+	var result models.PurgeResult
+	movies, posters, moviesErr := t.movies.PurgeMovies(ctx, before)
+	if moviesErr != nil {
+		t.log.ErrorContext(ctx, "Failed to purge movies", "op", "Purge", logger.Err(moviesErr))
+	}
+	result.Movies = movies
+	t.files.delete(ctx, posters)
+
+	actors, headshots, actorsErr := t.actors.PurgeActors(ctx, before)
+	if actorsErr != nil {
+		t.log.ErrorContext(ctx, "Failed to purge actors", "op", "Purge", logger.Err(actorsErr))
+	}
+	result.Actors = actors
+	t.files.delete(ctx, headshots)
+
+	if moviesErr != nil {
+		return result, moviesErr
+	}
+	return result, actorsErr
+}
+
+// RunPurge очищает корзину сразу и затем каждые interval, пока не отменен ctx.
+// Предназначен для запуска в worker.Group.
+func (t *trash) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := t.Purge(ctx)
+		if err == nil && (result.Movies > 0 || result.Actors > 0) {
+			t.log.InfoContext(ctx, "Trash purged", "movies", result.Movies, "actors", result.Actors, "retention", t.retention.String())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
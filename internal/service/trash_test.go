@@ -0,0 +1,75 @@
+package service
+
+import (
+	"cinema/internal/cache"
+	"cinema/internal/logger"
+	"cinema/internal/models"
+	"cinema/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrashPurgeUsesRetention(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	movieStore := mocks.NewMockstoreMovieTrash(ctrl)
+	actorStore := mocks.NewMockstoreActorTrash(ctrl)
+	blobs := mocks.NewMockstoreBlob(ctrl)
+	svc := NewTrash(movieStore, actorStore, NewImageFiles(blobs, []int{92}, logger.Discard()), nil, 24*time.Hour, logger.Discard(), nil)
+	now := time.Date(2024, 6, 2, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	before := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	dbErr := errors.New("db is down")
+	movieStore.EXPECT().PurgeMovies(gomock.Any(), before).Return(int64(0), nil, dbErr)
+	// Ошибка по фильмам не останавливает очистку актеров
+	actorStore.EXPECT().PurgeActors(gomock.Any(), before).Return(int64(2), []string{"headshots/a.jpg"}, nil)
+	// Файлы удаленных записей удаляются вместе с ними и с уменьшенными копиями
+	blobs.EXPECT().Delete(gomock.Any(), "headshots/a_92.jpg").Return(nil)
+	blobs.EXPECT().Delete(gomock.Any(), "headshots/a.jpg").Return(nil)
+
+	result, err := svc.Purge(context.Background())
+	assert.ErrorIs(t, err, dbErr)
+	assert.Equal(t, models.PurgeResult{Actors: 2}, result)
+}
+
+func TestCachedTrashRestoreInvalidatesMovie(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	movieStore := mocks.NewMockstoreMovie(ctrl)
+	trashStore := mocks.NewMockstoreMovieTrash(ctrl)
+	c := cache.New(100, time.Minute)
+	movies := NewCachedMovie(NewMovie(movieStore, nil, logger.Discard(), nil), c)
+	trash := NewCachedTrash(NewTrash(trashStore, mocks.NewMockstoreActorTrash(ctrl), nil, nil, time.Hour, logger.Discard(), nil), c)
+	ctx := context.Background()
+
+	movieID := uuid.New()
+	tx := testTx(t, true)
+	gomock.InOrder(
+		movieStore.EXPECT().GetMovieByID(gomock.Any(), movieID).Return(nil, nil),
+		trashStore.EXPECT().BeginTransaction(gomock.Any()).Return(tx, nil),
+		trashStore.EXPECT().RestoreMovie(gomock.Any(), tx, movieID).Return(true, nil),
+		movieStore.EXPECT().GetMovieByID(gomock.Any(), movieID).Return(&models.Movie{ID: movieID}, nil),
+	)
+
+	// Пока фильм в корзине, кэшируется его отсутствие
+	movie, err := movies.GetMovieByID(ctx, movieID)
+	assert.NoError(t, err)
+	assert.Nil(t, movie)
+
+	restored, err := trash.RestoreMovie(ctx, movieID)
+	assert.NoError(t, err)
+	assert.True(t, restored)
+
+	movie, err = movies.GetMovieByID(ctx, movieID)
+	assert.NoError(t, err)
+	assert.Equal(t, movieID, movie.ID)
+}
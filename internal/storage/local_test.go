@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalPutOpenDelete(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewLocal(filepath.Join(dir, "images"))
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	assert.NoError(t, store.Put(ctx, "posters/1/a.jpg", strings.NewReader("first")))
+	// Повторная запись заменяет файл целиком
+	assert.NoError(t, store.Put(ctx, "posters/1/a.jpg", strings.NewReader("second")))
+
+	file, err := store.Open(ctx, "posters/1/a.jpg")
+	assert.NoError(t, err)
+	data, err := io.ReadAll(file)
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+	assert.Equal(t, "second", string(data))
+
+	// Временные файлы не остаются
+	entries, err := os.ReadDir(filepath.Join(dir, "images", "posters", "1"))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	assert.NoError(t, store.Delete(ctx, "posters/1/a.jpg"))
+	assert.NoError(t, store.Delete(ctx, "posters/1/a.jpg"))
+	_, err = store.Open(ctx, "posters/1/a.jpg")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLocalRejectsKeysOutsideDir(t *testing.T) {
+	store, err := NewLocal(t.TempDir())
+	assert.NoError(t, err)
+
+	for _, key := range []string{"../secret", "/etc/passwd", "", "posters/../../x"} {
+		assert.Error(t, store.Put(context.Background(), key, strings.NewReader("x")), key)
+	}
+}
@@ -51,3 +51,66 @@ func BadRequestResponse(ctx *gin.Context, message string) {
 		Details: nil,
 	})
 }
+
+// Метод для ошибки 401 - не авторизован
+func UnauthorizedResponse(ctx *gin.Context, message string) {
+	ctx.JSON(http.StatusUnauthorized, models.APIError{
+		Code:    "UNAUTHORIZED",
+		Message: message,
+		Details: nil,
+	})
+}
+
+// Метод для ошибки 409 - конфликт с существующими данными
+func ConflictResponse(ctx *gin.Context, message string) {
+	ctx.JSON(http.StatusConflict, models.APIError{
+		Code:    "CONFLICT",
+		Message: message,
+		Details: nil,
+	})
+}
+
+// Метод для ошибки 504 - истек таймаут обработки запроса
+func GatewayTimeoutResponse(ctx *gin.Context, message string) {
+	ctx.JSON(http.StatusGatewayTimeout, models.APIError{
+		Code:    "GATEWAY_TIMEOUT",
+		Message: message,
+		Details: nil,
+	})
+}
+
+// Метод для ошибки 429 - превышен лимит запросов
+func TooManyRequestsResponse(ctx *gin.Context, message string) {
+	ctx.JSON(http.StatusTooManyRequests, models.APIError{
+		Code:    "TOO_MANY_REQUESTS",
+		Message: message,
+		Details: nil,
+	})
+}
+
+// Метод для ошибки 412 - версия записи не совпала с If-Match
+func PreconditionFailedResponse(ctx *gin.Context, message string) {
+	ctx.JSON(http.StatusPreconditionFailed, models.APIError{
+		Code:    "PRECONDITION_FAILED",
+		Message: message,
+		Details: nil,
+	})
+}
+
+// Метод для ошибки 413 - тело запроса больше допустимого
+func PayloadTooLargeResponse(ctx *gin.Context, message string) {
+	ctx.JSON(http.StatusRequestEntityTooLarge, models.APIError{
+		Code:    "PAYLOAD_TOO_LARGE",
+		Message: message,
+		Details: nil,
+	})
+}
+
+// Метод для ошибки 415 - тело запроса в неподдерживаемом формате
+func UnsupportedMediaTypeResponse(ctx *gin.Context, message string) {
+	ctx.JSON(http.StatusUnsupportedMediaType, models.APIError{
+		Code:    "UNSUPPORTED_MEDIA_TYPE",
+		Message: message,
+		Details: nil,
+	})
+}
@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// Group запускает фоновые задачи с общим контекстом и останавливает их вместе
+type Group struct {
+	log    *slog.Logger
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewGroup(log *slog.Logger) *Group {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Group{log: log, ctx: ctx, cancel: cancel}
+}
+
+// Go запускает задачу в отдельной горутине. Задача должна вернуться после отмены ctx.
+func (g *Group) Go(name string, task func(ctx context.Context)) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		g.log.Info("Worker started", "worker", name)
+		task(g.ctx)
+		g.log.Info("Worker stopped", "worker", name)
+	}()
+}
+
+// Stop отменяет контекст задач и ждет их завершения, но не дольше ctx
+func (g *Group) Stop(ctx context.Context) error {
+	g.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("workers did not stop in time: %w", ctx.Err())
+	}
+}
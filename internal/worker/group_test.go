@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"cinema/internal/logger"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupStopWaitsForTasks(t *testing.T) {
+	group := NewGroup(logger.Discard())
+
+	finished := make(chan struct{})
+	group.Go("test", func(ctx context.Context) {
+		<-ctx.Done()
+		close(finished)
+	})
+
+	assert.NoError(t, group.Stop(context.Background()))
+
+	// Stop возвращается только после завершения задачи
+	select {
+	case <-finished:
+	default:
+		t.Fatal("task has not finished")
+	}
+}
+
+func TestGroupStopTimeout(t *testing.T) {
+	group := NewGroup(logger.Discard())
+
+	release := make(chan struct{})
+	defer close(release)
+	group.Go("stuck", func(ctx context.Context) {
+		<-release
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, group.Stop(ctx), context.DeadlineExceeded)
+}
@@ -6,6 +6,9 @@ package mocks
 
 import (
 	models "cinema/internal/models"
+	pagination "cinema/internal/pagination"
+	context "context"
+	sql "database/sql"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,90 +38,149 @@ func (m *MockstoreActor) EXPECT() *MockstoreActorMockRecorder {
 	return m.recorder
 }
 
+// BeginTransaction mocks base method.
+func (m *MockstoreActor) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTransaction", ctx)
+	ret0, _ := ret[0].(*sql.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTransaction indicates an expected call of BeginTransaction.
+func (mr *MockstoreActorMockRecorder) BeginTransaction(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockstoreActor)(nil).BeginTransaction), ctx)
+}
+
+// CountActors mocks base method.
+func (m *MockstoreActor) CountActors(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActors", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActors indicates an expected call of CountActors.
+func (mr *MockstoreActorMockRecorder) CountActors(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActors", reflect.TypeOf((*MockstoreActor)(nil).CountActors), ctx)
+}
+
 // CreateActor mocks base method.
-func (m *MockstoreActor) CreateActor(actor models.CreateActor) (uuid.UUID, error) {
+func (m *MockstoreActor) CreateActor(ctx context.Context, tx *sql.Tx, actor models.CreateActor) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateActor", actor)
+	ret := m.ctrl.Call(m, "CreateActor", ctx, tx, actor)
 	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateActor indicates an expected call of CreateActor.
-func (mr *MockstoreActorMockRecorder) CreateActor(actor interface{}) *gomock.Call {
+func (mr *MockstoreActorMockRecorder) CreateActor(ctx, tx, actor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActor", reflect.TypeOf((*MockstoreActor)(nil).CreateActor), actor)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActor", reflect.TypeOf((*MockstoreActor)(nil).CreateActor), ctx, tx, actor)
 }
 
 // DeleteActor mocks base method.
-func (m *MockstoreActor) DeleteActor(id uuid.UUID) error {
+func (m *MockstoreActor) DeleteActor(ctx context.Context, tx *sql.Tx, id uuid.UUID, version *int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteActor", id)
+	ret := m.ctrl.Call(m, "DeleteActor", ctx, tx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteActor indicates an expected call of DeleteActor.
-func (mr *MockstoreActorMockRecorder) DeleteActor(id interface{}) *gomock.Call {
+func (mr *MockstoreActorMockRecorder) DeleteActor(ctx, tx, id, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActor", reflect.TypeOf((*MockstoreActor)(nil).DeleteActor), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActor", reflect.TypeOf((*MockstoreActor)(nil).DeleteActor), ctx, tx, id, version)
 }
 
 // GetActor mocks base method.
-func (m *MockstoreActor) GetActor(id uuid.UUID) (map[string]interface{}, error) {
+func (m *MockstoreActor) GetActor(ctx context.Context, id uuid.UUID) (*models.Actor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetActor", id)
-	ret0, _ := ret[0].(map[string]interface{})
+	ret := m.ctrl.Call(m, "GetActor", ctx, id)
+	ret0, _ := ret[0].(*models.Actor)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetActor indicates an expected call of GetActor.
-func (mr *MockstoreActorMockRecorder) GetActor(id interface{}) *gomock.Call {
+func (mr *MockstoreActorMockRecorder) GetActor(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActor", reflect.TypeOf((*MockstoreActor)(nil).GetActor), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActor", reflect.TypeOf((*MockstoreActor)(nil).GetActor), ctx, id)
+}
+
+// GetActorFields mocks base method.
+func (m *MockstoreActor) GetActorFields(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*models.ActorFields, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActorFields", ctx, tx, id)
+	ret0, _ := ret[0].(*models.ActorFields)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActorFields indicates an expected call of GetActorFields.
+func (mr *MockstoreActorMockRecorder) GetActorFields(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorFields", reflect.TypeOf((*MockstoreActor)(nil).GetActorFields), ctx, tx, id)
 }
 
 // GetActorsWithMovies mocks base method.
-func (m *MockstoreActor) GetActorsWithMovies(limit, offset int) ([]map[string]interface{}, error) {
+func (m *MockstoreActor) GetActorsWithMovies(ctx context.Context, after *pagination.Cursor, limit int) ([]models.ActorWithMovies, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetActorsWithMovies", limit, offset)
-	ret0, _ := ret[0].([]map[string]interface{})
+	ret := m.ctrl.Call(m, "GetActorsWithMovies", ctx, after, limit)
+	ret0, _ := ret[0].([]models.ActorWithMovies)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetActorsWithMovies indicates an expected call of GetActorsWithMovies.
-func (mr *MockstoreActorMockRecorder) GetActorsWithMovies(limit, offset interface{}) *gomock.Call {
+func (mr *MockstoreActorMockRecorder) GetActorsWithMovies(ctx, after, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorsWithMovies", reflect.TypeOf((*MockstoreActor)(nil).GetActorsWithMovies), limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorsWithMovies", reflect.TypeOf((*MockstoreActor)(nil).GetActorsWithMovies), ctx, after, limit)
 }
 
 // GetAllActors mocks base method.
-func (m *MockstoreActor) GetAllActors(limit, offset int) ([]map[string]interface{}, error) {
+func (m *MockstoreActor) GetAllActors(ctx context.Context, after *pagination.Cursor, limit int) ([]models.Actor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllActors", limit, offset)
-	ret0, _ := ret[0].([]map[string]interface{})
+	ret := m.ctrl.Call(m, "GetAllActors", ctx, after, limit)
+	ret0, _ := ret[0].([]models.Actor)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllActors indicates an expected call of GetAllActors.
-func (mr *MockstoreActorMockRecorder) GetAllActors(limit, offset interface{}) *gomock.Call {
+func (mr *MockstoreActorMockRecorder) GetAllActors(ctx, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllActors", reflect.TypeOf((*MockstoreActor)(nil).GetAllActors), ctx, after, limit)
+}
+
+// ReplaceActor mocks base method.
+func (m *MockstoreActor) ReplaceActor(ctx context.Context, tx *sql.Tx, id uuid.UUID, fields models.ActorFields, version *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceActor", ctx, tx, id, fields, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceActor indicates an expected call of ReplaceActor.
+func (mr *MockstoreActorMockRecorder) ReplaceActor(ctx, tx, id, fields, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllActors", reflect.TypeOf((*MockstoreActor)(nil).GetAllActors), limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceActor", reflect.TypeOf((*MockstoreActor)(nil).ReplaceActor), ctx, tx, id, fields, version)
 }
 
 // UpdateActor mocks base method.
-func (m *MockstoreActor) UpdateActor(id uuid.UUID, actor models.UpdateActor) error {
+func (m *MockstoreActor) UpdateActor(ctx context.Context, tx *sql.Tx, id uuid.UUID, actor models.UpdateActor, version *int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateActor", id, actor)
+	ret := m.ctrl.Call(m, "UpdateActor", ctx, tx, id, actor, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateActor indicates an expected call of UpdateActor.
-func (mr *MockstoreActorMockRecorder) UpdateActor(id, actor interface{}) *gomock.Call {
+func (mr *MockstoreActorMockRecorder) UpdateActor(ctx, tx, id, actor, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActor", reflect.TypeOf((*MockstoreActor)(nil).UpdateActor), id, actor)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActor", reflect.TypeOf((*MockstoreActor)(nil).UpdateActor), ctx, tx, id, actor, version)
 }
@@ -0,0 +1,179 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/import.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "cinema/internal/models"
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockstoreActorImport is a mock of storeActorImport interface.
+type MockstoreActorImport struct {
+	ctrl     *gomock.Controller
+	recorder *MockstoreActorImportMockRecorder
+}
+
+// MockstoreActorImportMockRecorder is the mock recorder for MockstoreActorImport.
+type MockstoreActorImportMockRecorder struct {
+	mock *MockstoreActorImport
+}
+
+// NewMockstoreActorImport creates a new mock instance.
+func NewMockstoreActorImport(ctrl *gomock.Controller) *MockstoreActorImport {
+	mock := &MockstoreActorImport{ctrl: ctrl}
+	mock.recorder = &MockstoreActorImportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstoreActorImport) EXPECT() *MockstoreActorImportMockRecorder {
+	return m.recorder
+}
+
+// BeginTransaction mocks base method.
+func (m *MockstoreActorImport) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTransaction", ctx)
+	ret0, _ := ret[0].(*sql.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTransaction indicates an expected call of BeginTransaction.
+func (mr *MockstoreActorImportMockRecorder) BeginTransaction(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockstoreActorImport)(nil).BeginTransaction), ctx)
+}
+
+// CreateActor mocks base method.
+func (m *MockstoreActorImport) CreateActor(ctx context.Context, tx *sql.Tx, actor models.CreateActor) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateActor", ctx, tx, actor)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateActor indicates an expected call of CreateActor.
+func (mr *MockstoreActorImportMockRecorder) CreateActor(ctx, tx, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActor", reflect.TypeOf((*MockstoreActorImport)(nil).CreateActor), ctx, tx, actor)
+}
+
+// FindActors mocks base method.
+func (m *MockstoreActorImport) FindActors(ctx context.Context, tx *sql.Tx, ref models.ActorRef) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActors", ctx, tx, ref)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActors indicates an expected call of FindActors.
+func (mr *MockstoreActorImportMockRecorder) FindActors(ctx, tx, ref interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActors", reflect.TypeOf((*MockstoreActorImport)(nil).FindActors), ctx, tx, ref)
+}
+
+// MockstoreMovieImport is a mock of storeMovieImport interface.
+type MockstoreMovieImport struct {
+	ctrl     *gomock.Controller
+	recorder *MockstoreMovieImportMockRecorder
+}
+
+// MockstoreMovieImportMockRecorder is the mock recorder for MockstoreMovieImport.
+type MockstoreMovieImportMockRecorder struct {
+	mock *MockstoreMovieImport
+}
+
+// NewMockstoreMovieImport creates a new mock instance.
+func NewMockstoreMovieImport(ctrl *gomock.Controller) *MockstoreMovieImport {
+	mock := &MockstoreMovieImport{ctrl: ctrl}
+	mock.recorder = &MockstoreMovieImportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstoreMovieImport) EXPECT() *MockstoreMovieImportMockRecorder {
+	return m.recorder
+}
+
+// AddMovieActorRelations mocks base method.
+func (m *MockstoreMovieImport) AddMovieActorRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID, credits []models.CreditInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMovieActorRelations", ctx, tx, movieID, credits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMovieActorRelations indicates an expected call of AddMovieActorRelations.
+func (mr *MockstoreMovieImportMockRecorder) AddMovieActorRelations(ctx, tx, movieID, credits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovieActorRelations", reflect.TypeOf((*MockstoreMovieImport)(nil).AddMovieActorRelations), ctx, tx, movieID, credits)
+}
+
+// AddMovieGenreRelations mocks base method.
+func (m *MockstoreMovieImport) AddMovieGenreRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID, genreIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMovieGenreRelations", ctx, tx, movieID, genreIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMovieGenreRelations indicates an expected call of AddMovieGenreRelations.
+func (mr *MockstoreMovieImportMockRecorder) AddMovieGenreRelations(ctx, tx, movieID, genreIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovieGenreRelations", reflect.TypeOf((*MockstoreMovieImport)(nil).AddMovieGenreRelations), ctx, tx, movieID, genreIDs)
+}
+
+// CheckGenresExist mocks base method.
+func (m *MockstoreMovieImport) CheckGenresExist(ctx context.Context, genreIDs []uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckGenresExist", ctx, genreIDs)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckGenresExist indicates an expected call of CheckGenresExist.
+func (mr *MockstoreMovieImportMockRecorder) CheckGenresExist(ctx, genreIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckGenresExist", reflect.TypeOf((*MockstoreMovieImport)(nil).CheckGenresExist), ctx, genreIDs)
+}
+
+// CreateMovie mocks base method.
+func (m *MockstoreMovieImport) CreateMovie(ctx context.Context, tx *sql.Tx, movie models.CreateMovie) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMovie", ctx, tx, movie)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMovie indicates an expected call of CreateMovie.
+func (mr *MockstoreMovieImportMockRecorder) CreateMovie(ctx, tx, movie interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMovie", reflect.TypeOf((*MockstoreMovieImport)(nil).CreateMovie), ctx, tx, movie)
+}
+
+// FindMovies mocks base method.
+func (m *MockstoreMovieImport) FindMovies(ctx context.Context, tx *sql.Tx, ref models.MovieRef) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMovies", ctx, tx, ref)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMovies indicates an expected call of FindMovies.
+func (mr *MockstoreMovieImportMockRecorder) FindMovies(ctx, tx, ref interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMovies", reflect.TypeOf((*MockstoreMovieImport)(nil).FindMovies), ctx, tx, ref)
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/audit.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "cinema/internal/models"
+	context "context"
+	sql "database/sql"
+	json "encoding/json"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockstoreAudit is a mock of storeAudit interface.
+type MockstoreAudit struct {
+	ctrl     *gomock.Controller
+	recorder *MockstoreAuditMockRecorder
+}
+
+// MockstoreAuditMockRecorder is the mock recorder for MockstoreAudit.
+type MockstoreAuditMockRecorder struct {
+	mock *MockstoreAudit
+}
+
+// NewMockstoreAudit creates a new mock instance.
+func NewMockstoreAudit(ctrl *gomock.Controller) *MockstoreAudit {
+	mock := &MockstoreAudit{ctrl: ctrl}
+	mock.recorder = &MockstoreAuditMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstoreAudit) EXPECT() *MockstoreAuditMockRecorder {
+	return m.recorder
+}
+
+// AddAuditEntry mocks base method.
+func (m *MockstoreAudit) AddAuditEntry(ctx context.Context, tx *sql.Tx, entry models.AuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAuditEntry", ctx, tx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAuditEntry indicates an expected call of AddAuditEntry.
+func (mr *MockstoreAuditMockRecorder) AddAuditEntry(ctx, tx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAuditEntry", reflect.TypeOf((*MockstoreAudit)(nil).AddAuditEntry), ctx, tx, entry)
+}
+
+// GetAuditLog mocks base method.
+func (m *MockstoreAudit) GetAuditLog(ctx context.Context, filter models.AuditFilter, limit, offset int) ([]models.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLog", ctx, filter, limit, offset)
+	ret0, _ := ret[0].([]models.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLog indicates an expected call of GetAuditLog.
+func (mr *MockstoreAuditMockRecorder) GetAuditLog(ctx, filter, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLog", reflect.TypeOf((*MockstoreAudit)(nil).GetAuditLog), ctx, filter, limit, offset)
+}
+
+// SnapshotEntity mocks base method.
+func (m *MockstoreAudit) SnapshotEntity(ctx context.Context, tx *sql.Tx, entityType string, id uuid.UUID, lock bool) (json.RawMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotEntity", ctx, tx, entityType, id, lock)
+	ret0, _ := ret[0].(json.RawMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnapshotEntity indicates an expected call of SnapshotEntity.
+func (mr *MockstoreAuditMockRecorder) SnapshotEntity(ctx, tx, entityType, id, lock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotEntity", reflect.TypeOf((*MockstoreAudit)(nil).SnapshotEntity), ctx, tx, entityType, id, lock)
+}
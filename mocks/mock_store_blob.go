@@ -0,0 +1,219 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/image.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	sql "database/sql"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockstoreBlob is a mock of storeBlob interface.
+type MockstoreBlob struct {
+	ctrl     *gomock.Controller
+	recorder *MockstoreBlobMockRecorder
+}
+
+// MockstoreBlobMockRecorder is the mock recorder for MockstoreBlob.
+type MockstoreBlobMockRecorder struct {
+	mock *MockstoreBlob
+}
+
+// NewMockstoreBlob creates a new mock instance.
+func NewMockstoreBlob(ctrl *gomock.Controller) *MockstoreBlob {
+	mock := &MockstoreBlob{ctrl: ctrl}
+	mock.recorder = &MockstoreBlobMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstoreBlob) EXPECT() *MockstoreBlobMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockstoreBlob) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockstoreBlobMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockstoreBlob)(nil).Delete), ctx, key)
+}
+
+// Open mocks base method.
+func (m *MockstoreBlob) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, key)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockstoreBlobMockRecorder) Open(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockstoreBlob)(nil).Open), ctx, key)
+}
+
+// Put mocks base method.
+func (m *MockstoreBlob) Put(ctx context.Context, key string, r io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, key, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockstoreBlobMockRecorder) Put(ctx, key, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockstoreBlob)(nil).Put), ctx, key, r)
+}
+
+// MockstoreMovieImage is a mock of storeMovieImage interface.
+type MockstoreMovieImage struct {
+	ctrl     *gomock.Controller
+	recorder *MockstoreMovieImageMockRecorder
+}
+
+// MockstoreMovieImageMockRecorder is the mock recorder for MockstoreMovieImage.
+type MockstoreMovieImageMockRecorder struct {
+	mock *MockstoreMovieImage
+}
+
+// NewMockstoreMovieImage creates a new mock instance.
+func NewMockstoreMovieImage(ctrl *gomock.Controller) *MockstoreMovieImage {
+	mock := &MockstoreMovieImage{ctrl: ctrl}
+	mock.recorder = &MockstoreMovieImageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstoreMovieImage) EXPECT() *MockstoreMovieImageMockRecorder {
+	return m.recorder
+}
+
+// BeginTransaction mocks base method.
+func (m *MockstoreMovieImage) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTransaction", ctx)
+	ret0, _ := ret[0].(*sql.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTransaction indicates an expected call of BeginTransaction.
+func (mr *MockstoreMovieImageMockRecorder) BeginTransaction(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockstoreMovieImage)(nil).BeginTransaction), ctx)
+}
+
+// GetMoviePoster mocks base method.
+func (m *MockstoreMovieImage) GetMoviePoster(ctx context.Context, id uuid.UUID) (*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMoviePoster", ctx, id)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMoviePoster indicates an expected call of GetMoviePoster.
+func (mr *MockstoreMovieImageMockRecorder) GetMoviePoster(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMoviePoster", reflect.TypeOf((*MockstoreMovieImage)(nil).GetMoviePoster), ctx, id)
+}
+
+// SetMoviePoster mocks base method.
+func (m *MockstoreMovieImage) SetMoviePoster(ctx context.Context, tx *sql.Tx, id uuid.UUID, key *string) (*string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMoviePoster", ctx, tx, id, key)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SetMoviePoster indicates an expected call of SetMoviePoster.
+func (mr *MockstoreMovieImageMockRecorder) SetMoviePoster(ctx, tx, id, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMoviePoster", reflect.TypeOf((*MockstoreMovieImage)(nil).SetMoviePoster), ctx, tx, id, key)
+}
+
+// MockstoreActorImage is a mock of storeActorImage interface.
+type MockstoreActorImage struct {
+	ctrl     *gomock.Controller
+	recorder *MockstoreActorImageMockRecorder
+}
+
+// MockstoreActorImageMockRecorder is the mock recorder for MockstoreActorImage.
+type MockstoreActorImageMockRecorder struct {
+	mock *MockstoreActorImage
+}
+
+// NewMockstoreActorImage creates a new mock instance.
+func NewMockstoreActorImage(ctrl *gomock.Controller) *MockstoreActorImage {
+	mock := &MockstoreActorImage{ctrl: ctrl}
+	mock.recorder = &MockstoreActorImageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstoreActorImage) EXPECT() *MockstoreActorImageMockRecorder {
+	return m.recorder
+}
+
+// BeginTransaction mocks base method.
+func (m *MockstoreActorImage) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTransaction", ctx)
+	ret0, _ := ret[0].(*sql.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTransaction indicates an expected call of BeginTransaction.
+func (mr *MockstoreActorImageMockRecorder) BeginTransaction(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockstoreActorImage)(nil).BeginTransaction), ctx)
+}
+
+// GetActorHeadshot mocks base method.
+func (m *MockstoreActorImage) GetActorHeadshot(ctx context.Context, id uuid.UUID) (*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActorHeadshot", ctx, id)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActorHeadshot indicates an expected call of GetActorHeadshot.
+func (mr *MockstoreActorImageMockRecorder) GetActorHeadshot(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorHeadshot", reflect.TypeOf((*MockstoreActorImage)(nil).GetActorHeadshot), ctx, id)
+}
+
+// SetActorHeadshot mocks base method.
+func (m *MockstoreActorImage) SetActorHeadshot(ctx context.Context, tx *sql.Tx, id uuid.UUID, key *string) (*string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetActorHeadshot", ctx, tx, id, key)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SetActorHeadshot indicates an expected call of SetActorHeadshot.
+func (mr *MockstoreActorImageMockRecorder) SetActorHeadshot(ctx, tx, id, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetActorHeadshot", reflect.TypeOf((*MockstoreActorImage)(nil).SetActorHeadshot), ctx, tx, id, key)
+}
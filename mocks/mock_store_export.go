@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/export.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "cinema/internal/models"
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockstoreExport is a mock of storeExport interface.
+type MockstoreExport struct {
+	ctrl     *gomock.Controller
+	recorder *MockstoreExportMockRecorder
+}
+
+// MockstoreExportMockRecorder is the mock recorder for MockstoreExport.
+type MockstoreExportMockRecorder struct {
+	mock *MockstoreExport
+}
+
+// NewMockstoreExport creates a new mock instance.
+func NewMockstoreExport(ctrl *gomock.Controller) *MockstoreExport {
+	mock := &MockstoreExport{ctrl: ctrl}
+	mock.recorder = &MockstoreExportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstoreExport) EXPECT() *MockstoreExportMockRecorder {
+	return m.recorder
+}
+
+// BeginSnapshot mocks base method.
+func (m *MockstoreExport) BeginSnapshot(ctx context.Context) (*sql.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginSnapshot", ctx)
+	ret0, _ := ret[0].(*sql.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginSnapshot indicates an expected call of BeginSnapshot.
+func (mr *MockstoreExportMockRecorder) BeginSnapshot(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginSnapshot", reflect.TypeOf((*MockstoreExport)(nil).BeginSnapshot), ctx)
+}
+
+// ExportCredits mocks base method.
+func (m *MockstoreExport) ExportCredits(ctx context.Context, tx *sql.Tx, filter models.MovieFilter, each func(models.ExportCredit) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCredits", ctx, tx, filter, each)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportCredits indicates an expected call of ExportCredits.
+func (mr *MockstoreExportMockRecorder) ExportCredits(ctx, tx, filter, each interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCredits", reflect.TypeOf((*MockstoreExport)(nil).ExportCredits), ctx, tx, filter, each)
+}
+
+// ExportMovies mocks base method.
+func (m *MockstoreExport) ExportMovies(ctx context.Context, tx *sql.Tx, filter models.MovieFilter, updatedSince *time.Time, each func(models.Movie) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportMovies", ctx, tx, filter, updatedSince, each)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportMovies indicates an expected call of ExportMovies.
+func (mr *MockstoreExportMockRecorder) ExportMovies(ctx, tx, filter, updatedSince, each interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportMovies", reflect.TypeOf((*MockstoreExport)(nil).ExportMovies), ctx, tx, filter, updatedSince, each)
+}
+
+// MockstoreActorExport is a mock of storeActorExport interface.
+type MockstoreActorExport struct {
+	ctrl     *gomock.Controller
+	recorder *MockstoreActorExportMockRecorder
+}
+
+// MockstoreActorExportMockRecorder is the mock recorder for MockstoreActorExport.
+type MockstoreActorExportMockRecorder struct {
+	mock *MockstoreActorExport
+}
+
+// NewMockstoreActorExport creates a new mock instance.
+func NewMockstoreActorExport(ctrl *gomock.Controller) *MockstoreActorExport {
+	mock := &MockstoreActorExport{ctrl: ctrl}
+	mock.recorder = &MockstoreActorExportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstoreActorExport) EXPECT() *MockstoreActorExportMockRecorder {
+	return m.recorder
+}
+
+// ExportActors mocks base method.
+func (m *MockstoreActorExport) ExportActors(ctx context.Context, tx *sql.Tx, updatedSince *time.Time, each func(models.Actor) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportActors", ctx, tx, updatedSince, each)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportActors indicates an expected call of ExportActors.
+func (mr *MockstoreActorExportMockRecorder) ExportActors(ctx, tx, updatedSince, each interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportActors", reflect.TypeOf((*MockstoreActorExport)(nil).ExportActors), ctx, tx, updatedSince, each)
+}
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/genre.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "cinema/internal/models"
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockstoreGenre is a mock of storeGenre interface.
+type MockstoreGenre struct {
+	ctrl     *gomock.Controller
+	recorder *MockstoreGenreMockRecorder
+}
+
+// MockstoreGenreMockRecorder is the mock recorder for MockstoreGenre.
+type MockstoreGenreMockRecorder struct {
+	mock *MockstoreGenre
+}
+
+// NewMockstoreGenre creates a new mock instance.
+func NewMockstoreGenre(ctrl *gomock.Controller) *MockstoreGenre {
+	mock := &MockstoreGenre{ctrl: ctrl}
+	mock.recorder = &MockstoreGenreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstoreGenre) EXPECT() *MockstoreGenreMockRecorder {
+	return m.recorder
+}
+
+// BeginTransaction mocks base method.
+func (m *MockstoreGenre) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTransaction", ctx)
+	ret0, _ := ret[0].(*sql.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTransaction indicates an expected call of BeginTransaction.
+func (mr *MockstoreGenreMockRecorder) BeginTransaction(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockstoreGenre)(nil).BeginTransaction), ctx)
+}
+
+// CreateGenre mocks base method.
+func (m *MockstoreGenre) CreateGenre(ctx context.Context, tx *sql.Tx, genre models.CreateGenre) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGenre", ctx, tx, genre)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGenre indicates an expected call of CreateGenre.
+func (mr *MockstoreGenreMockRecorder) CreateGenre(ctx, tx, genre interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGenre", reflect.TypeOf((*MockstoreGenre)(nil).CreateGenre), ctx, tx, genre)
+}
+
+// DeleteGenre mocks base method.
+func (m *MockstoreGenre) DeleteGenre(ctx context.Context, tx *sql.Tx, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGenre", ctx, tx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGenre indicates an expected call of DeleteGenre.
+func (mr *MockstoreGenreMockRecorder) DeleteGenre(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGenre", reflect.TypeOf((*MockstoreGenre)(nil).DeleteGenre), ctx, tx, id)
+}
+
+// GetAllGenres mocks base method.
+func (m *MockstoreGenre) GetAllGenres(ctx context.Context) ([]models.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllGenres", ctx)
+	ret0, _ := ret[0].([]models.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllGenres indicates an expected call of GetAllGenres.
+func (mr *MockstoreGenreMockRecorder) GetAllGenres(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllGenres", reflect.TypeOf((*MockstoreGenre)(nil).GetAllGenres), ctx)
+}
+
+// GetGenre mocks base method.
+func (m *MockstoreGenre) GetGenre(ctx context.Context, id uuid.UUID) (*models.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenre", ctx, id)
+	ret0, _ := ret[0].(*models.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenre indicates an expected call of GetGenre.
+func (mr *MockstoreGenreMockRecorder) GetGenre(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenre", reflect.TypeOf((*MockstoreGenre)(nil).GetGenre), ctx, id)
+}
+
+// UpdateGenre mocks base method.
+func (m *MockstoreGenre) UpdateGenre(ctx context.Context, tx *sql.Tx, id uuid.UUID, genre models.UpdateGenre) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGenre", ctx, tx, id, genre)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGenre indicates an expected call of UpdateGenre.
+func (mr *MockstoreGenreMockRecorder) UpdateGenre(ctx, tx, id, genre interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGenre", reflect.TypeOf((*MockstoreGenre)(nil).UpdateGenre), ctx, tx, id, genre)
+}
@@ -6,6 +6,8 @@ package mocks
 
 import (
 	models "cinema/internal/models"
+	pagination "cinema/internal/pagination"
+	context "context"
 	sql "database/sql"
 	reflect "reflect"
 
@@ -37,191 +39,336 @@ func (m *MockstoreMovie) EXPECT() *MockstoreMovieMockRecorder {
 }
 
 // AddMovieActorRelations mocks base method.
-func (m *MockstoreMovie) AddMovieActorRelations(tx *sql.Tx, movieID uuid.UUID, actorIDs []uuid.UUID) error {
+func (m *MockstoreMovie) AddMovieActorRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID, credits []models.CreditInput) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddMovieActorRelations", tx, movieID, actorIDs)
+	ret := m.ctrl.Call(m, "AddMovieActorRelations", ctx, tx, movieID, credits)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddMovieActorRelations indicates an expected call of AddMovieActorRelations.
-func (mr *MockstoreMovieMockRecorder) AddMovieActorRelations(tx, movieID, actorIDs interface{}) *gomock.Call {
+func (mr *MockstoreMovieMockRecorder) AddMovieActorRelations(ctx, tx, movieID, credits interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovieActorRelations", reflect.TypeOf((*MockstoreMovie)(nil).AddMovieActorRelations), tx, movieID, actorIDs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovieActorRelations", reflect.TypeOf((*MockstoreMovie)(nil).AddMovieActorRelations), ctx, tx, movieID, credits)
+}
+
+// AddMovieGenreRelations mocks base method.
+func (m *MockstoreMovie) AddMovieGenreRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID, genreIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMovieGenreRelations", ctx, tx, movieID, genreIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMovieGenreRelations indicates an expected call of AddMovieGenreRelations.
+func (mr *MockstoreMovieMockRecorder) AddMovieGenreRelations(ctx, tx, movieID, genreIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovieGenreRelations", reflect.TypeOf((*MockstoreMovie)(nil).AddMovieGenreRelations), ctx, tx, movieID, genreIDs)
 }
 
 // BeginTransaction mocks base method.
-func (m *MockstoreMovie) BeginTransaction() (*sql.Tx, error) {
+func (m *MockstoreMovie) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BeginTransaction")
+	ret := m.ctrl.Call(m, "BeginTransaction", ctx)
 	ret0, _ := ret[0].(*sql.Tx)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // BeginTransaction indicates an expected call of BeginTransaction.
-func (mr *MockstoreMovieMockRecorder) BeginTransaction() *gomock.Call {
+func (mr *MockstoreMovieMockRecorder) BeginTransaction(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockstoreMovie)(nil).BeginTransaction))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockstoreMovie)(nil).BeginTransaction), ctx)
 }
 
 // CheckActorsExist mocks base method.
-func (m *MockstoreMovie) CheckActorsExist(actorIDs []uuid.UUID) (bool, error) {
+func (m *MockstoreMovie) CheckActorsExist(ctx context.Context, actorIDs []uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckActorsExist", actorIDs)
+	ret := m.ctrl.Call(m, "CheckActorsExist", ctx, actorIDs)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CheckActorsExist indicates an expected call of CheckActorsExist.
-func (mr *MockstoreMovieMockRecorder) CheckActorsExist(actorIDs interface{}) *gomock.Call {
+func (mr *MockstoreMovieMockRecorder) CheckActorsExist(ctx, actorIDs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckActorsExist", reflect.TypeOf((*MockstoreMovie)(nil).CheckActorsExist), actorIDs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckActorsExist", reflect.TypeOf((*MockstoreMovie)(nil).CheckActorsExist), ctx, actorIDs)
+}
+
+// CheckGenresExist mocks base method.
+func (m *MockstoreMovie) CheckGenresExist(ctx context.Context, genreIDs []uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckGenresExist", ctx, genreIDs)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckGenresExist indicates an expected call of CheckGenresExist.
+func (mr *MockstoreMovieMockRecorder) CheckGenresExist(ctx, genreIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckGenresExist", reflect.TypeOf((*MockstoreMovie)(nil).CheckGenresExist), ctx, genreIDs)
 }
 
 // CheckMovieExists mocks base method.
-func (m *MockstoreMovie) CheckMovieExists(movieID uuid.UUID) (bool, error) {
+func (m *MockstoreMovie) CheckMovieExists(ctx context.Context, movieID uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckMovieExists", movieID)
+	ret := m.ctrl.Call(m, "CheckMovieExists", ctx, movieID)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CheckMovieExists indicates an expected call of CheckMovieExists.
-func (mr *MockstoreMovieMockRecorder) CheckMovieExists(movieID interface{}) *gomock.Call {
+func (mr *MockstoreMovieMockRecorder) CheckMovieExists(ctx, movieID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckMovieExists", reflect.TypeOf((*MockstoreMovie)(nil).CheckMovieExists), movieID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckMovieExists", reflect.TypeOf((*MockstoreMovie)(nil).CheckMovieExists), ctx, movieID)
+}
+
+// CountMovies mocks base method.
+func (m *MockstoreMovie) CountMovies(ctx context.Context, filter models.MovieFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMovies", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMovies indicates an expected call of CountMovies.
+func (mr *MockstoreMovieMockRecorder) CountMovies(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMovies", reflect.TypeOf((*MockstoreMovie)(nil).CountMovies), ctx, filter)
+}
+
+// CountMoviesByActorID mocks base method.
+func (m *MockstoreMovie) CountMoviesByActorID(ctx context.Context, actorID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMoviesByActorID", ctx, actorID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMoviesByActorID indicates an expected call of CountMoviesByActorID.
+func (mr *MockstoreMovieMockRecorder) CountMoviesByActorID(ctx, actorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMoviesByActorID", reflect.TypeOf((*MockstoreMovie)(nil).CountMoviesByActorID), ctx, actorID)
 }
 
 // CreateMovie mocks base method.
-func (m *MockstoreMovie) CreateMovie(tx *sql.Tx, movie models.CreateMovie) (uuid.UUID, error) {
+func (m *MockstoreMovie) CreateMovie(ctx context.Context, tx *sql.Tx, movie models.CreateMovie) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateMovie", tx, movie)
+	ret := m.ctrl.Call(m, "CreateMovie", ctx, tx, movie)
 	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateMovie indicates an expected call of CreateMovie.
-func (mr *MockstoreMovieMockRecorder) CreateMovie(tx, movie interface{}) *gomock.Call {
+func (mr *MockstoreMovieMockRecorder) CreateMovie(ctx, tx, movie interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMovie", reflect.TypeOf((*MockstoreMovie)(nil).CreateMovie), tx, movie)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMovie", reflect.TypeOf((*MockstoreMovie)(nil).CreateMovie), ctx, tx, movie)
 }
 
 // DeleteMovie mocks base method.
-func (m *MockstoreMovie) DeleteMovie(id uuid.UUID) error {
+func (m *MockstoreMovie) DeleteMovie(ctx context.Context, tx *sql.Tx, id uuid.UUID, version *int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteMovie", id)
+	ret := m.ctrl.Call(m, "DeleteMovie", ctx, tx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteMovie indicates an expected call of DeleteMovie.
-func (mr *MockstoreMovieMockRecorder) DeleteMovie(id interface{}) *gomock.Call {
+func (mr *MockstoreMovieMockRecorder) DeleteMovie(ctx, tx, id, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovie", reflect.TypeOf((*MockstoreMovie)(nil).DeleteMovie), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovie", reflect.TypeOf((*MockstoreMovie)(nil).DeleteMovie), ctx, tx, id, version)
 }
 
 // GetMovieByID mocks base method.
-func (m *MockstoreMovie) GetMovieByID(id uuid.UUID) (map[string]interface{}, error) {
+func (m *MockstoreMovie) GetMovieByID(ctx context.Context, id uuid.UUID) (*models.Movie, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMovieByID", id)
-	ret0, _ := ret[0].(map[string]interface{})
+	ret := m.ctrl.Call(m, "GetMovieByID", ctx, id)
+	ret0, _ := ret[0].(*models.Movie)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMovieByID indicates an expected call of GetMovieByID.
-func (mr *MockstoreMovieMockRecorder) GetMovieByID(id interface{}) *gomock.Call {
+func (mr *MockstoreMovieMockRecorder) GetMovieByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieByID", reflect.TypeOf((*MockstoreMovie)(nil).GetMovieByID), ctx, id)
+}
+
+// GetMovieCredits mocks base method.
+func (m *MockstoreMovie) GetMovieCredits(ctx context.Context, movieID uuid.UUID) ([]models.Credit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovieCredits", ctx, movieID)
+	ret0, _ := ret[0].([]models.Credit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovieCredits indicates an expected call of GetMovieCredits.
+func (mr *MockstoreMovieMockRecorder) GetMovieCredits(ctx, movieID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieByID", reflect.TypeOf((*MockstoreMovie)(nil).GetMovieByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieCredits", reflect.TypeOf((*MockstoreMovie)(nil).GetMovieCredits), ctx, movieID)
+}
+
+// GetMovieFields mocks base method.
+func (m *MockstoreMovie) GetMovieFields(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*models.MovieFields, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovieFields", ctx, tx, id)
+	ret0, _ := ret[0].(*models.MovieFields)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovieFields indicates an expected call of GetMovieFields.
+func (mr *MockstoreMovieMockRecorder) GetMovieFields(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieFields", reflect.TypeOf((*MockstoreMovie)(nil).GetMovieFields), ctx, tx, id)
 }
 
 // GetMoviesByActorID mocks base method.
-func (m *MockstoreMovie) GetMoviesByActorID(actorID uuid.UUID, limit, offset int) ([]map[string]interface{}, error) {
+func (m *MockstoreMovie) GetMoviesByActorID(ctx context.Context, actorID uuid.UUID, after *pagination.Cursor, limit int) ([]models.Movie, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMoviesByActorID", actorID, limit, offset)
-	ret0, _ := ret[0].([]map[string]interface{})
+	ret := m.ctrl.Call(m, "GetMoviesByActorID", ctx, actorID, after, limit)
+	ret0, _ := ret[0].([]models.Movie)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMoviesByActorID indicates an expected call of GetMoviesByActorID.
-func (mr *MockstoreMovieMockRecorder) GetMoviesByActorID(actorID, limit, offset interface{}) *gomock.Call {
+func (mr *MockstoreMovieMockRecorder) GetMoviesByActorID(ctx, actorID, after, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMoviesByActorID", reflect.TypeOf((*MockstoreMovie)(nil).GetMoviesByActorID), actorID, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMoviesByActorID", reflect.TypeOf((*MockstoreMovie)(nil).GetMoviesByActorID), ctx, actorID, after, limit)
 }
 
 // GetMoviesWithFilters mocks base method.
-func (m *MockstoreMovie) GetMoviesWithFilters(sortBy, order string, limit, offset int) ([]map[string]interface{}, error) {
+func (m *MockstoreMovie) GetMoviesWithFilters(ctx context.Context, filter models.MovieFilter, sortBy, order string, after *pagination.Cursor, limit int) ([]models.Movie, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMoviesWithFilters", sortBy, order, limit, offset)
-	ret0, _ := ret[0].([]map[string]interface{})
+	ret := m.ctrl.Call(m, "GetMoviesWithFilters", ctx, filter, sortBy, order, after, limit)
+	ret0, _ := ret[0].([]models.Movie)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMoviesWithFilters indicates an expected call of GetMoviesWithFilters.
-func (mr *MockstoreMovieMockRecorder) GetMoviesWithFilters(sortBy, order, limit, offset interface{}) *gomock.Call {
+func (mr *MockstoreMovieMockRecorder) GetMoviesWithFilters(ctx, filter, sortBy, order, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMoviesWithFilters", reflect.TypeOf((*MockstoreMovie)(nil).GetMoviesWithFilters), ctx, filter, sortBy, order, after, limit)
+}
+
+// LockMovie mocks base method.
+func (m *MockstoreMovie) LockMovie(ctx context.Context, tx *sql.Tx, movieID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockMovie", ctx, tx, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockMovie indicates an expected call of LockMovie.
+func (mr *MockstoreMovieMockRecorder) LockMovie(ctx, tx, movieID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMoviesWithFilters", reflect.TypeOf((*MockstoreMovie)(nil).GetMoviesWithFilters), sortBy, order, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockMovie", reflect.TypeOf((*MockstoreMovie)(nil).LockMovie), ctx, tx, movieID)
 }
 
 // RemoveMovieActorRelations mocks base method.
-func (m *MockstoreMovie) RemoveMovieActorRelations(tx *sql.Tx, movieID uuid.UUID) error {
+func (m *MockstoreMovie) RemoveMovieActorRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveMovieActorRelations", tx, movieID)
+	ret := m.ctrl.Call(m, "RemoveMovieActorRelations", ctx, tx, movieID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveMovieActorRelations indicates an expected call of RemoveMovieActorRelations.
-func (mr *MockstoreMovieMockRecorder) RemoveMovieActorRelations(tx, movieID interface{}) *gomock.Call {
+func (mr *MockstoreMovieMockRecorder) RemoveMovieActorRelations(ctx, tx, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMovieActorRelations", reflect.TypeOf((*MockstoreMovie)(nil).RemoveMovieActorRelations), ctx, tx, movieID)
+}
+
+// RemoveMovieCastRelations mocks base method.
+func (m *MockstoreMovie) RemoveMovieCastRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMovieCastRelations", ctx, tx, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMovieCastRelations indicates an expected call of RemoveMovieCastRelations.
+func (mr *MockstoreMovieMockRecorder) RemoveMovieCastRelations(ctx, tx, movieID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMovieCastRelations", reflect.TypeOf((*MockstoreMovie)(nil).RemoveMovieCastRelations), ctx, tx, movieID)
+}
+
+// RemoveMovieGenreRelations mocks base method.
+func (m *MockstoreMovie) RemoveMovieGenreRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMovieGenreRelations", ctx, tx, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMovieGenreRelations indicates an expected call of RemoveMovieGenreRelations.
+func (mr *MockstoreMovieMockRecorder) RemoveMovieGenreRelations(ctx, tx, movieID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMovieActorRelations", reflect.TypeOf((*MockstoreMovie)(nil).RemoveMovieActorRelations), tx, movieID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMovieGenreRelations", reflect.TypeOf((*MockstoreMovie)(nil).RemoveMovieGenreRelations), ctx, tx, movieID)
 }
 
 // RemoveSelectedMovieActorRelations mocks base method.
-func (m *MockstoreMovie) RemoveSelectedMovieActorRelations(tx *sql.Tx, movieID uuid.UUID, actorIDs []uuid.UUID) error {
+func (m *MockstoreMovie) RemoveSelectedMovieActorRelations(ctx context.Context, tx *sql.Tx, movieID uuid.UUID, actorIDs []uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveSelectedMovieActorRelations", tx, movieID, actorIDs)
+	ret := m.ctrl.Call(m, "RemoveSelectedMovieActorRelations", ctx, tx, movieID, actorIDs)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveSelectedMovieActorRelations indicates an expected call of RemoveSelectedMovieActorRelations.
-func (mr *MockstoreMovieMockRecorder) RemoveSelectedMovieActorRelations(tx, movieID, actorIDs interface{}) *gomock.Call {
+func (mr *MockstoreMovieMockRecorder) RemoveSelectedMovieActorRelations(ctx, tx, movieID, actorIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSelectedMovieActorRelations", reflect.TypeOf((*MockstoreMovie)(nil).RemoveSelectedMovieActorRelations), ctx, tx, movieID, actorIDs)
+}
+
+// ReplaceMovie mocks base method.
+func (m *MockstoreMovie) ReplaceMovie(ctx context.Context, tx *sql.Tx, id uuid.UUID, fields models.MovieFields, version *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceMovie", ctx, tx, id, fields, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceMovie indicates an expected call of ReplaceMovie.
+func (mr *MockstoreMovieMockRecorder) ReplaceMovie(ctx, tx, id, fields, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSelectedMovieActorRelations", reflect.TypeOf((*MockstoreMovie)(nil).RemoveSelectedMovieActorRelations), tx, movieID, actorIDs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceMovie", reflect.TypeOf((*MockstoreMovie)(nil).ReplaceMovie), ctx, tx, id, fields, version)
 }
 
-// SearchMoviesByTitleAndActor mocks base method.
-func (m *MockstoreMovie) SearchMoviesByTitleAndActor(titleFragment, actorNameFragment string, limit, offset int) ([]map[string]interface{}, error) {
+// SearchMovies mocks base method.
+func (m *MockstoreMovie) SearchMovies(ctx context.Context, query string, limit, offset int) ([]models.MovieSearchResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchMoviesByTitleAndActor", titleFragment, actorNameFragment, limit, offset)
-	ret0, _ := ret[0].([]map[string]interface{})
+	ret := m.ctrl.Call(m, "SearchMovies", ctx, query, limit, offset)
+	ret0, _ := ret[0].([]models.MovieSearchResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SearchMoviesByTitleAndActor indicates an expected call of SearchMoviesByTitleAndActor.
-func (mr *MockstoreMovieMockRecorder) SearchMoviesByTitleAndActor(titleFragment, actorNameFragment, limit, offset interface{}) *gomock.Call {
+// SearchMovies indicates an expected call of SearchMovies.
+func (mr *MockstoreMovieMockRecorder) SearchMovies(ctx, query, limit, offset interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMoviesByTitleAndActor", reflect.TypeOf((*MockstoreMovie)(nil).SearchMoviesByTitleAndActor), titleFragment, actorNameFragment, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMovies", reflect.TypeOf((*MockstoreMovie)(nil).SearchMovies), ctx, query, limit, offset)
 }
 
 // UpdateMovie mocks base method.
-func (m *MockstoreMovie) UpdateMovie(tx *sql.Tx, id uuid.UUID, movie models.UpdateMovie) error {
+func (m *MockstoreMovie) UpdateMovie(ctx context.Context, tx *sql.Tx, id uuid.UUID, movie models.UpdateMovie, version *int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateMovie", tx, id, movie)
+	ret := m.ctrl.Call(m, "UpdateMovie", ctx, tx, id, movie, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateMovie indicates an expected call of UpdateMovie.
-func (mr *MockstoreMovieMockRecorder) UpdateMovie(tx, id, movie interface{}) *gomock.Call {
+func (mr *MockstoreMovieMockRecorder) UpdateMovie(ctx, tx, id, movie, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovie", reflect.TypeOf((*MockstoreMovie)(nil).UpdateMovie), tx, id, movie)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovie", reflect.TypeOf((*MockstoreMovie)(nil).UpdateMovie), ctx, tx, id, movie, version)
 }
@@ -0,0 +1,184 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/trash.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "cinema/internal/models"
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockstoreMovieTrash is a mock of storeMovieTrash interface.
+type MockstoreMovieTrash struct {
+	ctrl     *gomock.Controller
+	recorder *MockstoreMovieTrashMockRecorder
+}
+
+// MockstoreMovieTrashMockRecorder is the mock recorder for MockstoreMovieTrash.
+type MockstoreMovieTrashMockRecorder struct {
+	mock *MockstoreMovieTrash
+}
+
+// NewMockstoreMovieTrash creates a new mock instance.
+func NewMockstoreMovieTrash(ctrl *gomock.Controller) *MockstoreMovieTrash {
+	mock := &MockstoreMovieTrash{ctrl: ctrl}
+	mock.recorder = &MockstoreMovieTrashMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstoreMovieTrash) EXPECT() *MockstoreMovieTrashMockRecorder {
+	return m.recorder
+}
+
+// BeginTransaction mocks base method.
+func (m *MockstoreMovieTrash) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTransaction", ctx)
+	ret0, _ := ret[0].(*sql.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTransaction indicates an expected call of BeginTransaction.
+func (mr *MockstoreMovieTrashMockRecorder) BeginTransaction(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockstoreMovieTrash)(nil).BeginTransaction), ctx)
+}
+
+// GetDeletedMovies mocks base method.
+func (m *MockstoreMovieTrash) GetDeletedMovies(ctx context.Context, limit, offset int) ([]models.TrashedMovie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedMovies", ctx, limit, offset)
+	ret0, _ := ret[0].([]models.TrashedMovie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedMovies indicates an expected call of GetDeletedMovies.
+func (mr *MockstoreMovieTrashMockRecorder) GetDeletedMovies(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedMovies", reflect.TypeOf((*MockstoreMovieTrash)(nil).GetDeletedMovies), ctx, limit, offset)
+}
+
+// PurgeMovies mocks base method.
+func (m *MockstoreMovieTrash) PurgeMovies(ctx context.Context, before time.Time) (int64, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeMovies", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PurgeMovies indicates an expected call of PurgeMovies.
+func (mr *MockstoreMovieTrashMockRecorder) PurgeMovies(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeMovies", reflect.TypeOf((*MockstoreMovieTrash)(nil).PurgeMovies), ctx, before)
+}
+
+// RestoreMovie mocks base method.
+func (m *MockstoreMovieTrash) RestoreMovie(ctx context.Context, tx *sql.Tx, id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreMovie", ctx, tx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreMovie indicates an expected call of RestoreMovie.
+func (mr *MockstoreMovieTrashMockRecorder) RestoreMovie(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMovie", reflect.TypeOf((*MockstoreMovieTrash)(nil).RestoreMovie), ctx, tx, id)
+}
+
+// MockstoreActorTrash is a mock of storeActorTrash interface.
+type MockstoreActorTrash struct {
+	ctrl     *gomock.Controller
+	recorder *MockstoreActorTrashMockRecorder
+}
+
+// MockstoreActorTrashMockRecorder is the mock recorder for MockstoreActorTrash.
+type MockstoreActorTrashMockRecorder struct {
+	mock *MockstoreActorTrash
+}
+
+// NewMockstoreActorTrash creates a new mock instance.
+func NewMockstoreActorTrash(ctrl *gomock.Controller) *MockstoreActorTrash {
+	mock := &MockstoreActorTrash{ctrl: ctrl}
+	mock.recorder = &MockstoreActorTrashMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstoreActorTrash) EXPECT() *MockstoreActorTrashMockRecorder {
+	return m.recorder
+}
+
+// BeginTransaction mocks base method.
+func (m *MockstoreActorTrash) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTransaction", ctx)
+	ret0, _ := ret[0].(*sql.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTransaction indicates an expected call of BeginTransaction.
+func (mr *MockstoreActorTrashMockRecorder) BeginTransaction(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockstoreActorTrash)(nil).BeginTransaction), ctx)
+}
+
+// GetDeletedActors mocks base method.
+func (m *MockstoreActorTrash) GetDeletedActors(ctx context.Context, limit, offset int) ([]models.TrashedActor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedActors", ctx, limit, offset)
+	ret0, _ := ret[0].([]models.TrashedActor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedActors indicates an expected call of GetDeletedActors.
+func (mr *MockstoreActorTrashMockRecorder) GetDeletedActors(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedActors", reflect.TypeOf((*MockstoreActorTrash)(nil).GetDeletedActors), ctx, limit, offset)
+}
+
+// PurgeActors mocks base method.
+func (m *MockstoreActorTrash) PurgeActors(ctx context.Context, before time.Time) (int64, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeActors", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PurgeActors indicates an expected call of PurgeActors.
+func (mr *MockstoreActorTrashMockRecorder) PurgeActors(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeActors", reflect.TypeOf((*MockstoreActorTrash)(nil).PurgeActors), ctx, before)
+}
+
+// RestoreActor mocks base method.
+func (m *MockstoreActorTrash) RestoreActor(ctx context.Context, tx *sql.Tx, id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreActor", ctx, tx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreActor indicates an expected call of RestoreActor.
+func (mr *MockstoreActorTrashMockRecorder) RestoreActor(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreActor", reflect.TypeOf((*MockstoreActorTrash)(nil).RestoreActor), ctx, tx, id)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/auth.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "cinema/internal/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockstoreUser is a mock of storeUser interface.
+type MockstoreUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockstoreUserMockRecorder
+}
+
+// MockstoreUserMockRecorder is the mock recorder for MockstoreUser.
+type MockstoreUserMockRecorder struct {
+	mock *MockstoreUser
+}
+
+// NewMockstoreUser creates a new mock instance.
+func NewMockstoreUser(ctrl *gomock.Controller) *MockstoreUser {
+	mock := &MockstoreUser{ctrl: ctrl}
+	mock.recorder = &MockstoreUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstoreUser) EXPECT() *MockstoreUserMockRecorder {
+	return m.recorder
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockstoreUser) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", ctx, username)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockstoreUserMockRecorder) GetUserByUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockstoreUser)(nil).GetUserByUsername), ctx, username)
+}